@@ -18,7 +18,13 @@ import (
 	"github.com/mabidoli/gravity-bff/internal/api/middleware"
 	"github.com/mabidoli/gravity-bff/internal/cache"
 	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/insights"
 	"github.com/mabidoli/gravity-bff/internal/repository"
+	"github.com/mabidoli/gravity-bff/internal/scheduler"
+	"github.com/mabidoli/gravity-bff/internal/scoring"
 	"github.com/mabidoli/gravity-bff/internal/service"
 	"github.com/mabidoli/gravity-bff/pkg/logger"
 )
@@ -61,18 +67,64 @@ func main() {
 	// Initialize cache
 	redisCache := cache.NewRedisCache(redisClient)
 
+	// Initialize live event broker
+	eventBroker := events.NewRedisBroker(redisClient, cfg.Events.HistorySize, cfg.Events.HistoryTTL)
+
 	// Initialize repositories
 	streamRepo := repository.NewPgStreamRepository(db)
+	ingestRepo := repository.NewPgIngestRepository(db)
+	calendarRepo := repository.NewPgCalendarRepository(db)
+	scoringRepo := repository.NewPgScoringRepository(db)
+	ruleRepo := repository.NewPgRuleRepository(db)
+	labelRepo := repository.NewPgLabelRepository(db)
+	insightRepo := repository.NewPgInsightRepository(db)
+	userRepo := repository.NewPgUserRepository(db)
+
+	// Initialize ingestion connectors
+	connectors := initConnectors(cfg, log)
 
 	// Initialize services
-	streamService := service.NewStreamService(streamRepo, redisCache, cfg, log)
+	streamService := service.NewStreamService(streamRepo, redisCache, eventBroker, cfg, log)
+	scorer := scoring.New(scoring.DefaultWeights(), scoring.DefaultThresholds())
+	scoringService := service.NewScoringService(scoringRepo, scorer, redisCache, eventBroker, cfg, log)
+	ingestService := service.NewIngestService(ingestRepo, connectors, redisCache, eventBroker, log)
+	ruleService := service.NewRuleService(ruleRepo, log)
+	ingestService.SetTriager(ruleService)
+	ingestService.SetScorer(scoringService)
+	streamService.SetScorer(scoringService)
+	calendarService := service.NewCalendarService(calendarRepo, ingestService, cfg, log)
+	ingestService.SetConflictChecker(calendarService)
+	streamService.SetConflictChecker(calendarService)
+	labelService := service.NewLabelService(labelRepo, redisCache, eventBroker, log)
+	insightProvider, err := insights.NewProvider(cfg.Insights.Provider)
+	if err != nil {
+		log.Fatal("Failed to initialize insights: %v", err)
+	}
+	insightService := service.NewInsightService(insightRepo, insightProvider, streamService, redisCache, eventBroker, cfg, log)
+	ingestService.SetInsighter(insightService)
+	briefingService := service.NewBriefingService(streamRepo, calendarRepo, redisCache, cfg, log)
+	peopleService := service.NewPeopleService(userRepo, streamRepo, log)
+	peopleService.SetScorer(scoringService)
+	log.Info("Insights provider: %s", insightProvider.Name())
+
+	// Start background jobs
+	jobs := initScheduler(redisClient, scoringService, streamService, insightService, cfg, log)
+	jobs.Start(context.Background())
 
 	// Initialize handlers
 	healthHandler := handler.NewHealthHandler()
 	streamHandler := handler.NewStreamHandler(streamService, log)
+	eventsHandler := handler.NewEventsHandler(eventBroker, cfg.Events.HeartbeatInterval, log)
+	ingestHandler := handler.NewIngestHandler(ingestService, log)
+	calendarHandler := handler.NewCalendarHandler(calendarService, log)
+	ruleHandler := handler.NewRuleHandler(ruleService, log)
+	labelHandler := handler.NewLabelHandler(labelService, log)
+	insightHandler := handler.NewInsightHandler(insightService, log)
+	briefingHandler := handler.NewBriefingHandler(briefingService, log)
+	peopleHandler := handler.NewPeopleHandler(peopleService, log)
 
 	// Initialize router
-	router := api.NewRouter(healthHandler, streamHandler, log)
+	router := api.NewRouter(healthHandler, streamHandler, eventsHandler, ingestHandler, calendarHandler, ruleHandler, labelHandler, insightHandler, briefingHandler, peopleHandler, log)
 
 	// Setup Fiber app
 	app := fiber.New(fiber.Config{
@@ -112,6 +164,7 @@ func main() {
 	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
 		log.Error("Server forced to shutdown: %v", err)
 	}
+	jobs.Stop()
 
 	log.Info("Server shutdown complete")
 }
@@ -167,3 +220,93 @@ func initRedis(cfg *config.Config, log *logger.Logger) (*redis.Client, error) {
 	log.Info("Redis connected: %s, pool=%d", cfg.Redis.Address(), cfg.Redis.PoolSize)
 	return client, nil
 }
+
+// initConnectors registers a webhook connector for every source with a configured secret.
+func initConnectors(cfg *config.Config, log *logger.Logger) *ingestion.Registry {
+	registry := ingestion.NewRegistry()
+	for name, secret := range cfg.Ingest.Secrets {
+		source := model.SourceType(name)
+		if !source.IsValid() {
+			log.Warn("Ignoring ingest secret for unknown source: %s", name)
+			continue
+		}
+		registry.Register(ingestion.NewJSONConnector(source, secret))
+	}
+
+	log.Info("Ingestion connectors registered: %v", registry.Sources())
+	return registry
+}
+
+// initScheduler registers the periodic background jobs.
+func initScheduler(
+	client *redis.Client,
+	scoringService *service.ScoringService,
+	streamService *service.StreamService,
+	insightService *service.InsightService,
+	cfg *config.Config,
+	log *logger.Logger,
+) *scheduler.Scheduler {
+	jobs := scheduler.New(scheduler.NewRedisLocker(client), log)
+
+	jobs.Add(scheduler.Job{
+		Name:     "rescore",
+		Interval: cfg.Scoring.Interval,
+		Run: func(ctx context.Context) error {
+			n, err := scoringService.RescoreStale(ctx)
+			if n > 0 {
+				log.Info("Rescored %d items", n)
+			}
+			return err
+		},
+	})
+
+	jobs.Add(scheduler.Job{
+		Name:     "wake-snoozed",
+		Interval: cfg.Snooze.WakeInterval,
+		Run: func(ctx context.Context) error {
+			n, err := streamService.WakeSnoozed(ctx)
+			if n > 0 {
+				log.Info("Woke %d snoozed items", n)
+			}
+			return err
+		},
+	})
+
+	jobs.Add(scheduler.Job{
+		Name:     "resurface-follow-ups",
+		Interval: cfg.FollowUp.Interval,
+		Run: func(ctx context.Context) error {
+			n, err := streamService.ResurfaceFollowUps(ctx)
+			if n > 0 {
+				log.Info("Resurfaced %d items awaiting a reply", n)
+			}
+			return err
+		},
+	})
+
+	jobs.Add(scheduler.Job{
+		Name:     "purge-trash",
+		Interval: cfg.Trash.PurgeInterval,
+		Run: func(ctx context.Context) error {
+			n, err := streamService.PurgeTrash(ctx)
+			if n > 0 {
+				log.Info("Purged %d trashed items", n)
+			}
+			return err
+		},
+	})
+
+	jobs.Add(scheduler.Job{
+		Name:     "insights",
+		Interval: cfg.Insights.Interval,
+		Run: func(ctx context.Context) error {
+			n, err := insightService.ProcessQueue(ctx)
+			if n > 0 {
+				log.Info("Generated insights for %d items", n)
+			}
+			return err
+		},
+	})
+
+	return jobs
+}
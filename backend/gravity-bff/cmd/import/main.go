@@ -0,0 +1,238 @@
+// Package main is the entry point for the importer.
+// It reads mbox archives, single .eml files and .ics calendars and ingests them as priority items.
+//
+// Usage:
+//
+//	import -user <clerk user id> [-owner me@example.com] [-unread] [-attachments ./data] archive.mbox message.eml team.ics ...
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mabidoli/gravity-bff/internal/cache"
+	"github.com/mabidoli/gravity-bff/internal/calendar"
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/ingestion/email"
+	"github.com/mabidoli/gravity-bff/internal/insights"
+	"github.com/mabidoli/gravity-bff/internal/repository"
+	"github.com/mabidoli/gravity-bff/internal/scoring"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+func main() {
+	userID := flag.String("user", "", "Clerk user ID that owns the imported items (required)")
+	ownerEmail := flag.String("owner", "", "Owner's email address; their messages are marked as sent by the user")
+	unread := flag.Bool("unread", false, "Import items as unread")
+	attachmentsDir := flag.String("attachments", "", "Directory to store attachments in; attachments are not stored if empty")
+	attachmentsURL := flag.String("attachments-url", "/attachments", "Base URL attachments are served from")
+	flag.Parse()
+
+	log := logger.New()
+
+	if *userID == "" || flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: import -user <id> [flags] <file.mbox|file.eml|file.ics>...")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	// main exits only once run has returned, so that its deferred cleanup runs
+	err := run(log, importFlags{
+		userID:         *userID,
+		ownerEmail:     *ownerEmail,
+		unread:         *unread,
+		attachmentsDir: *attachmentsDir,
+		attachmentsURL: *attachmentsURL,
+		paths:          flag.Args(),
+	})
+	if err != nil {
+		log.Fatal("Import failed: %v", err)
+	}
+}
+
+// importFlags are the command-line options of an import.
+type importFlags struct {
+	userID         string
+	ownerEmail     string
+	unread         bool
+	attachmentsDir string
+	attachmentsURL string
+	paths          []string
+}
+
+// run connects to the database and Redis and imports the files named by f.
+func run(log *logger.Logger, f importFlags) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ctx := context.Background()
+
+	db, err := pgxpool.New(ctx, cfg.Database.ConnectionString())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+	if err := db.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Address(),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	defer redisClient.Close()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	// Imports go through the same path as webhooks so items are scored, caches
+	// are invalidated, connected clients see the new items, conflicting events
+	// are flagged and insights are queued for the API's insight job.
+	redisCache := cache.NewRedisCache(redisClient)
+	eventBroker := events.NewRedisBroker(redisClient, cfg.Events.HistorySize, cfg.Events.HistoryTTL)
+	ingestService := service.NewIngestService(
+		repository.NewPgIngestRepository(db),
+		ingestion.NewRegistry(),
+		redisCache,
+		eventBroker,
+		log,
+	)
+	ingestService.SetTriager(service.NewRuleService(repository.NewPgRuleRepository(db), log))
+	ingestService.SetScorer(service.NewScoringService(
+		repository.NewPgScoringRepository(db),
+		scoring.New(scoring.DefaultWeights(), scoring.DefaultThresholds()),
+		redisCache,
+		eventBroker,
+		cfg,
+		log,
+	))
+	ingestService.SetConflictChecker(service.NewCalendarService(repository.NewPgCalendarRepository(db), ingestService, cfg, log))
+	insightProvider, err := insights.NewProvider(cfg.Insights.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to initialize insights: %w", err)
+	}
+	ingestService.SetInsighter(service.NewInsightService(
+		repository.NewPgInsightRepository(db),
+		insightProvider,
+		service.NewStreamService(repository.NewPgStreamRepository(db), redisCache, eventBroker, cfg, log),
+		redisCache,
+		eventBroker,
+		cfg,
+		log,
+	))
+
+	opts := email.Options{
+		UserID:     f.userID,
+		OwnerEmail: f.ownerEmail,
+		Unread:     f.unread,
+	}
+	if f.attachmentsDir != "" {
+		opts.Attachments = ingestion.NewDirStore(f.attachmentsDir, f.attachmentsURL)
+	}
+
+	var messages []*email.Message
+	var calendarEvents []calendar.Event
+	for _, path := range f.paths {
+		if strings.EqualFold(filepath.Ext(path), ".ics") {
+			parsed, err := readCalendar(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			log.Info("Read %d events from %s", len(parsed), path)
+			calendarEvents = append(calendarEvents, parsed...)
+			continue
+		}
+
+		parsed, err := readFile(path, log)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		log.Info("Read %d messages from %s", len(parsed), path)
+		messages = append(messages, parsed...)
+	}
+
+	start := time.Now()
+	if len(messages) > 0 {
+		resp, err := ingestService.ImportEmail(ctx, messages, opts)
+		if err := report(log, "email threads", resp, err, start); err != nil {
+			return err
+		}
+	}
+	if len(calendarEvents) > 0 {
+		// Recurring events are expanded over the same window the API uses
+		now := time.Now().UTC()
+		calendarOpts := calendar.Options{UserID: f.userID, OwnerEmail: f.ownerEmail, Unread: f.unread}
+		resp, err := ingestService.ImportCalendar(ctx, calendarEvents, calendarOpts,
+			now.Add(-cfg.Calendar.FeedPast), now.Add(cfg.Calendar.RecurrenceHorizon))
+		if err := report(log, "calendar events", resp, err, start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// report logs the outcome of a successful import, or describes its failure.
+func report(log *logger.Logger, kind string, resp *model.IngestResponse, err error, start time.Time) error {
+	if err != nil {
+		imported := 0
+		if resp != nil {
+			imported = len(resp.Results)
+		}
+		return fmt.Errorf("import of %s failed after %d items: %w", kind, imported, err)
+	}
+
+	created, newMessages := 0, 0
+	for _, result := range resp.Results {
+		if result.Created {
+			created++
+		}
+		newMessages += len(result.NewMessages)
+	}
+	log.Info("Imported %d %s (%d new) with %d new messages in %s",
+		len(resp.Results), kind, created, newMessages, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// readCalendar parses an iCalendar file.
+func readCalendar(path string) ([]calendar.Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return calendar.Parse(f)
+}
+
+// readFile parses an mbox archive or a single message, logging messages that are skipped.
+func readFile(path string, log *logger.Logger) ([]*email.Message, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	messages, skipped, err := email.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range skipped {
+		log.Warn("%s: skipped %v", path, err)
+	}
+	return messages, nil
+}
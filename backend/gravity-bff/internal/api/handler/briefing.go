@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// BriefingHandler handles daily briefing requests.
+type BriefingHandler struct {
+	service *service.BriefingService
+	log     *logger.Logger
+}
+
+// NewBriefingHandler creates a new briefing handler.
+func NewBriefingHandler(svc *service.BriefingService, log *logger.Logger) *BriefingHandler {
+	return &BriefingHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// GetBriefing handles GET /v2/briefing requests.
+// @Summary Daily briefing
+// @Description Returns what needs the current user's attention on a day: its calendar events, unread high priority items, snoozed items waking before it ends and conversations awaiting their reply
+// @Tags briefing
+// @Produce json
+// @Param date query string false "Day, YYYY-MM-DD (default today in tz)"
+// @Param tz query string false "IANA time zone the day is taken in (default UTC)"
+// @Success 200 {object} model.BriefingResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/briefing [get]
+func (h *BriefingHandler) GetBriefing(c *fiber.Ctx) error {
+	day, err := service.ValidateBriefingDay(c.Query("date"), c.Query("tz"), time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	response, err := h.service.GetBriefing(c.Context(), currentUserID(c), day)
+	if err != nil {
+		h.log.Error("Failed to get briefing: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to get briefing",
+		))
+	}
+
+	return c.JSON(response)
+}
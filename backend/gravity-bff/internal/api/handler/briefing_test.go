@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+func setupBriefingTestApp(streamRepo *MockStreamRepository, calendarRepo *MockCalendarRepository, mockCache *MockCache) *fiber.App {
+	log := logger.New()
+	handler := NewBriefingHandler(service.NewBriefingService(streamRepo, calendarRepo, mockCache, newTestConfig(), log), log)
+
+	app := fiber.New()
+	app.Get("/v2/briefing", func(c *fiber.Ctx) error {
+		c.Locals("userID", "test-user")
+		return c.Next()
+	}, handler.GetBriefing)
+
+	return app
+}
+
+func TestBriefingHandler_GetBriefing(t *testing.T) {
+	// Arrange
+	streamRepo := new(MockStreamRepository)
+	calendarRepo := new(MockCalendarRepository)
+	mockCache := new(MockCache)
+	app := setupBriefingTestApp(streamRepo, calendarRepo, mockCache)
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, tokyo)
+
+	mockCache.On("GetBriefing", mock.Anything, "stream:test-user:briefing:2026-03-02:Asia/Tokyo").Return(nil, nil)
+	calendarRepo.On("GetEventSlots", mock.Anything, "test-user", day, day.AddDate(0, 0, 1)).Return([]model.EventSlot{}, nil)
+	streamRepo.On("GetPinnedItems", mock.Anything, "test-user", mock.Anything).Return([]model.PriorityItem{}, nil)
+	streamRepo.On("GetStream", mock.Anything, mock.Anything).Return([]model.PriorityItem{{ID: "item-1"}}, (*string)(nil), nil)
+	mockCache.On("SetBriefing", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	req := httptest.NewRequest("GET", "/v2/briefing?date=2026-03-02&tz=Asia/Tokyo", nil)
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.BriefingResponse
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "2026-03-02", result.Date)
+	assert.Equal(t, "Asia/Tokyo", result.TimeZone)
+	assert.Empty(t, result.Events)
+	assert.Len(t, result.HighPriority, 1)
+	calendarRepo.AssertExpectations(t)
+}
+
+func TestBriefingHandler_GetBriefing_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		repoErr        error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid date", "?date=tomorrow", nil, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"invalid time zone", "?tz=Nowhere/Else", nil, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"repository error", "?date=2026-03-02", errors.New("db down"), fiber.StatusInternalServerError, model.ErrCodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			calendarRepo := new(MockCalendarRepository)
+			mockCache := new(MockCache)
+			app := setupBriefingTestApp(new(MockStreamRepository), calendarRepo, mockCache)
+
+			mockCache.On("GetBriefing", mock.Anything, mock.Anything).Return(nil, nil)
+			calendarRepo.On("GetEventSlots", mock.Anything, "test-user", mock.Anything, mock.Anything).Return([]model.EventSlot{}, tt.repoErr)
+
+			req := httptest.NewRequest("GET", "/v2/briefing"+tt.query, nil)
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var errResp model.ErrorResponse
+			assert.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+		})
+	}
+}
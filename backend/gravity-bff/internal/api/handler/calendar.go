@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mabidoli/gravity-bff/internal/calendar"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// CalendarHandler handles calendar import and feed requests.
+type CalendarHandler struct {
+	service *service.CalendarService
+	log     *logger.Logger
+}
+
+// NewCalendarHandler creates a new calendar handler.
+func NewCalendarHandler(service *service.CalendarService, log *logger.Logger) *CalendarHandler {
+	return &CalendarHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetFeed handles GET /v2/calendar.ics requests.
+// @Summary Calendar feed
+// @Description Subscribable iCalendar feed of the user's events. Authenticated by the token in the URL, not Clerk.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token query string true "Feed token from GET /v2/calendar/feed"
+// @Success 200 {string} string "text/calendar"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/calendar.ics [get]
+func (h *CalendarHandler) GetFeed(c *fiber.Ctx) error {
+	feed, err := h.service.GetFeed(c.Context(), c.Query("token"))
+	switch {
+	case err == nil:
+		c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+		c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+		return c.Send(feed)
+	case errors.Is(err, service.ErrFeedDisabled):
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"Calendar feeds are not enabled",
+		))
+	case errors.Is(err, calendar.ErrInvalidToken):
+		return c.Status(fiber.StatusUnauthorized).JSON(model.NewErrorResponse(
+			model.ErrCodeUnauthorized,
+			"Invalid feed token",
+		))
+	default:
+		h.log.Error("Failed to render calendar feed: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to render calendar feed",
+		))
+	}
+}
+
+// GetFeedURL handles GET /v2/calendar/feed requests.
+// @Summary Calendar feed URL
+// @Description Returns the current user's subscribable calendar feed URL
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} model.CalendarFeedResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /v2/calendar/feed [get]
+func (h *CalendarHandler) GetFeedURL(c *fiber.Ctx) error {
+	token, err := h.service.FeedToken(currentUserID(c))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"Calendar feeds are not enabled",
+		))
+	}
+
+	return c.JSON(model.CalendarFeedResponse{
+		URL:   c.BaseURL() + "/v2/calendar.ics?token=" + url.QueryEscape(token),
+		Token: token,
+	})
+}
+
+// Import handles POST /v2/calendar/import requests.
+// @Summary Import a calendar
+// @Description Imports the events of an iCalendar (.ics) document as calendar items. Recurring events are expanded.
+// @Tags calendar
+// @Accept text/calendar
+// @Produce json
+// @Success 200 {object} model.IngestResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/calendar/import [post]
+func (h *CalendarHandler) Import(c *fiber.Ctx) error {
+	response, err := h.service.Import(c.Context(), currentUserID(c), bytes.NewReader(c.Body()))
+	switch {
+	case err == nil:
+		return c.JSON(response)
+	case errors.Is(err, ingestion.ErrInvalidPayload):
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	default:
+		h.log.Error("Failed to import calendar: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to import calendar",
+		))
+	}
+}
+
+// FreeBusy handles GET /v2/calendar/freebusy requests.
+// @Summary Free/busy
+// @Description Returns when the current user is busy: the times taken by their calendar events, merged. Items in the trash are left out.
+// @Tags calendar
+// @Produce json
+// @Param from query string false "Start of the range, RFC 3339 (default now)"
+// @Param to query string false "End of the range, RFC 3339 (default 7 days after from, at most 62 days)"
+// @Success 200 {object} model.FreeBusyResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/calendar/freebusy [get]
+func (h *CalendarHandler) FreeBusy(c *fiber.Ctx) error {
+	from, to, err := service.ValidateFreeBusyRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	response, err := h.service.FreeBusy(c.Context(), currentUserID(c), from, to)
+	if err != nil {
+		h.log.Error("Failed to get free/busy: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to get free/busy",
+		))
+	}
+
+	return c.JSON(response)
+}
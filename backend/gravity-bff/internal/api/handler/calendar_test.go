@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockCalendarRepository is a mock implementation of CalendarRepository.
+type MockCalendarRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarRepository) GetEvents(ctx context.Context, userID string, from, to time.Time) ([]model.CalendarEvent, error) {
+	args := m.Called(ctx, userID, from, to)
+	return args.Get(0).([]model.CalendarEvent), args.Error(1)
+}
+
+func (m *MockCalendarRepository) GetEventSlots(ctx context.Context, userID string, from, to time.Time) ([]model.EventSlot, error) {
+	args := m.Called(ctx, userID, from, to)
+	return args.Get(0).([]model.EventSlot), args.Error(1)
+}
+
+func (m *MockCalendarRepository) SetConflictInsight(ctx context.Context, messageID string, insight *model.AIInsight) error {
+	args := m.Called(ctx, messageID, insight)
+	return args.Error(0)
+}
+
+func setupCalendarTestApp(repo *MockCalendarRepository, secret string) *fiber.App {
+	log := logger.New()
+	cfg := newTestConfig()
+	cfg.Calendar = config.CalendarConfig{
+		FeedSecret: secret,
+		FeedPast:   30 * 24 * time.Hour,
+		FeedFuture: 365 * 24 * time.Hour,
+	}
+	ingest := service.NewIngestService(new(MockIngestRepository), ingestion.NewRegistry(), new(MockCache), events.NopPublisher{}, log)
+	handler := NewCalendarHandler(service.NewCalendarService(repo, ingest, cfg, log), log)
+
+	app := fiber.New()
+	app.Get("/v2/calendar.ics", handler.GetFeed)
+
+	authed := app.Group("/v2/calendar", func(c *fiber.Ctx) error {
+		c.Locals("userID", "test-user")
+		return c.Next()
+	})
+	authed.Get("/feed", handler.GetFeedURL)
+	authed.Post("/import", handler.Import)
+	authed.Get("/freebusy", handler.FreeBusy)
+
+	return app
+}
+
+func TestCalendarHandler_FeedURLAndFeed(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCalendarRepository)
+	app := setupCalendarTestApp(mockRepo, "secret")
+
+	mockRepo.On("GetEvents", mock.Anything, "test-user", mock.Anything, mock.Anything).Return([]model.CalendarEvent{{
+		ID:        "evt-1",
+		Title:     "Standup",
+		StartTime: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 1, 5, 9, 15, 0, 0, time.UTC),
+	}}, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/v2/calendar/feed", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var feed model.CalendarFeedResponse
+	assert.NoError(t, json.Unmarshal(body, &feed))
+	assert.True(t, strings.HasSuffix(feed.URL, "/v2/calendar.ics?token="+url.QueryEscape(feed.Token)))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/v2/calendar.ics?token="+url.QueryEscape(feed.Token), nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/calendar; charset=utf-8", resp.Header.Get("Content-Type"))
+	body, _ = io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "UID:evt-1")
+}
+
+func TestCalendarHandler_GetFeed_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		token      string
+		wantStatus int
+	}{
+		{"invalid token", "secret", "forged.token", fiber.StatusUnauthorized},
+		{"missing token", "secret", "", fiber.StatusUnauthorized},
+		{"feeds disabled", "", "any", fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupCalendarTestApp(new(MockCalendarRepository), tt.secret)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/v2/calendar.ics?token="+tt.token, nil))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestCalendarHandler_Import_InvalidCalendar(t *testing.T) {
+	app := setupCalendarTestApp(new(MockCalendarRepository), "")
+
+	req := httptest.NewRequest("POST", "/v2/calendar/import", strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n"))
+	req.Header.Set("Content-Type", "text/calendar")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCalendarHandler_FreeBusy(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCalendarRepository)
+	app := setupCalendarTestApp(mockRepo, "")
+
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("GetEventSlots", mock.Anything, "test-user", from, to).Return([]model.EventSlot{
+		{EventID: "evt-1", StartTime: from.Add(9 * time.Hour), EndTime: from.Add(10 * time.Hour)},
+		{EventID: "evt-2", StartTime: from.Add(9*time.Hour + 30*time.Minute), EndTime: from.Add(11 * time.Hour)},
+	}, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/v2/calendar/freebusy?from=2026-03-02T00:00:00Z&to=2026-03-03T00:00:00Z", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.FreeBusyResponse
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, []model.BusyPeriod{{Start: from.Add(9 * time.Hour), End: from.Add(11 * time.Hour)}}, result.Busy)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCalendarHandler_FreeBusy_InvalidRange(t *testing.T) {
+	mockRepo := new(MockCalendarRepository)
+	app := setupCalendarTestApp(mockRepo, "")
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/v2/calendar/freebusy?from=2026-03-03T00:00:00Z&to=2026-03-02T00:00:00Z", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var errResp model.ErrorResponse
+	assert.NoError(t, json.Unmarshal(body, &errResp))
+	assert.Equal(t, model.ErrCodeValidationFailed, errResp.Error.Code)
+	mockRepo.AssertNotCalled(t, "GetEventSlots", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
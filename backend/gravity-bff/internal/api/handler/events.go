@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// sseRetry is the reconnect delay suggested to clients, in milliseconds.
+const sseRetry = 3000
+
+// EventsHandler streams live update events to clients.
+type EventsHandler struct {
+	subscriber events.Subscriber
+	heartbeat  time.Duration
+	log        *logger.Logger
+}
+
+// NewEventsHandler creates a new events handler.
+func NewEventsHandler(subscriber events.Subscriber, heartbeat time.Duration, log *logger.Logger) *EventsHandler {
+	return &EventsHandler{
+		subscriber: subscriber,
+		heartbeat:  heartbeat,
+		log:        log,
+	}
+}
+
+// StreamEvents handles GET /v2/stream/events requests.
+// @Summary Live stream updates
+// @Description Server-Sent Events feed of item.created, item.updated, message.created and item.read events
+// @Tags stream
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "Resume after this event ID"
+// @Param lastEventId query string false "Resume after this event ID, for clients that cannot set headers"
+// @Param token query string false "Clerk session token, for EventSource clients that cannot send an Authorization header"
+// @Success 200 {string} string "text/event-stream"
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/events [get]
+func (h *EventsHandler) StreamEvents(c *fiber.Ctx) error {
+	userID := currentUserID(c)
+
+	// EventSource sends Last-Event-ID on reconnect; allow a query fallback
+	// for clients that cannot set headers.
+	lastEventID := c.Get("Last-Event-ID", c.Query("lastEventId"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	feed, err := h.subscriber.Subscribe(ctx, userID, lastEventID)
+	if err != nil {
+		cancel()
+		h.log.Error("Failed to subscribe to events: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to subscribe to events",
+		))
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The server's WriteTimeout would otherwise end the stream early;
+	// each flush pushes the deadline past the next heartbeat instead.
+	conn := c.Context().Conn()
+	extendDeadline := func() {
+		if conn != nil {
+			_ = conn.SetWriteDeadline(time.Now().Add(2 * h.heartbeat))
+		}
+	}
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		extendDeadline()
+
+		fmt.Fprintf(w, "retry: %d\n\n", sseRetry)
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(h.heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case evt, ok := <-feed:
+				if !ok {
+					return
+				}
+				if err := writeEvent(w, evt); err != nil {
+					h.log.Warn("Failed to encode event %s: %v", evt.ID, err)
+					continue
+				}
+			case <-ticker.C:
+				// Comment lines keep proxies from closing idle connections
+				fmt.Fprint(w, ": ping\n\n")
+			}
+
+			// A failed flush means the client went away
+			if err := w.Flush(); err != nil {
+				return
+			}
+			extendDeadline()
+		}
+	})
+
+	return nil
+}
+
+// writeEvent writes an event in Server-Sent Events wire format.
+func writeEvent(w *bufio.Writer, evt events.Event) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", evt.ID, evt.Type, data)
+	return err
+}
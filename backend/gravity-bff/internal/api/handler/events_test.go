@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mabidoli/gravity-bff/internal/api/middleware"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// fakeSubscriber replays a fixed set of events and then ends the feed.
+type fakeSubscriber struct {
+	events      []events.Event
+	userID      string
+	lastEventID string
+}
+
+func (s *fakeSubscriber) Subscribe(ctx context.Context, userID, lastEventID string) (<-chan events.Event, error) {
+	s.userID = userID
+	s.lastEventID = lastEventID
+
+	out := make(chan events.Event, len(s.events))
+	for _, evt := range s.events {
+		out <- evt
+	}
+	close(out)
+	return out, nil
+}
+
+func TestEventsHandler_StreamEvents(t *testing.T) {
+	// Arrange
+	subscriber := &fakeSubscriber{
+		events: []events.Event{
+			{ID: "1700000000000-1", Type: events.ItemRead, ItemID: "item-1", Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "1700000000000-2", Type: events.MessageCreated, ItemID: "item-2", Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	handler := NewEventsHandler(subscriber, time.Minute, logger.New())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", "test-user")
+		return c.Next()
+	})
+	app.Get("/v2/stream/events", handler.StreamEvents)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream/events", nil)
+	req.Header.Set("Last-Event-ID", "1700000000000-0")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "test-user", subscriber.userID)
+	assert.Equal(t, "1700000000000-0", subscriber.lastEventID)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "retry: 3000\n\n")
+	assert.Contains(t, string(body), "id: 1700000000000-1\nevent: item.read\ndata: {")
+	assert.Contains(t, string(body), "id: 1700000000000-2\nevent: message.created\ndata: {")
+}
+
+func TestEventsHandler_StreamEvents_QueryToken(t *testing.T) {
+	// Arrange
+	subscriber := &fakeSubscriber{}
+	handler := NewEventsHandler(subscriber, time.Minute, logger.New())
+
+	var authorization string
+	app := fiber.New()
+	app.Get("/v2/stream/events", middleware.QueryTokenAuth(), func(c *fiber.Ctx) error {
+		authorization = c.Get("Authorization")
+		return handler.StreamEvents(c)
+	})
+
+	// Act: a browser EventSource sends neither an Authorization nor a Last-Event-ID header
+	req := httptest.NewRequest("GET", "/v2/stream/events?token=session-token&lastEventId=1700000000000-0", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer session-token", authorization)
+	assert.NotEmpty(t, subscriber.userID)
+	assert.Equal(t, "1700000000000-0", subscriber.lastEventID)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// IngestHandler handles webhook deliveries from source connectors.
+type IngestHandler struct {
+	service *service.IngestService
+	log     *logger.Logger
+}
+
+// NewIngestHandler creates a new ingest handler.
+func NewIngestHandler(service *service.IngestService, log *logger.Logger) *IngestHandler {
+	return &IngestHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// HandleWebhook handles POST /v2/ingest/:source requests.
+// @Summary Ingest a webhook delivery
+// @Description Stores items pushed by a source connector. Deliveries are signed with an HMAC-SHA256 of the body and are idempotent by external ID.
+// @Tags ingest
+// @Accept json
+// @Produce json
+// @Param source path string true "Source type" Enums(email, whatsapp, slack, teams, calendar, task, youtube, linkedin, twitter)
+// @Param X-Gravity-Signature header string true "sha256=<hex HMAC of the body>"
+// @Success 200 {object} model.IngestResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/ingest/{source} [post]
+func (h *IngestHandler) HandleWebhook(c *fiber.Ctx) error {
+	source := model.SourceType(c.Params("source"))
+
+	response, err := h.service.HandleWebhook(c.Context(), source, c.Body(), c.Get(ingestion.SignatureHeader))
+	switch {
+	case err == nil:
+		return c.JSON(response)
+	case errors.Is(err, ingestion.ErrUnknownSource):
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"No connector configured for source: "+string(source),
+		))
+	case errors.Is(err, ingestion.ErrInvalidSignature):
+		return c.Status(fiber.StatusUnauthorized).JSON(model.NewErrorResponse(
+			model.ErrCodeUnauthorized,
+			"Invalid webhook signature",
+		))
+	case errors.Is(err, ingestion.ErrInvalidPayload):
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	default:
+		h.log.Error("Failed to ingest %s delivery: %v", source, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to ingest delivery",
+		))
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockIngestRepository is a mock implementation of IngestRepository.
+type MockIngestRepository struct {
+	mock.Mock
+}
+
+func (m *MockIngestRepository) UpsertItem(ctx context.Context, item model.IngestItem) (*model.IngestResult, error) {
+	args := m.Called(ctx, item)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*model.IngestResult), args.Error(1)
+}
+
+func (m *MockIngestRepository) GetCountedItems(ctx context.Context, itemIDs []string) (map[string]model.CountedItem, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]model.CountedItem), args.Error(1)
+}
+
+func setupIngestTestApp(repo *MockIngestRepository, mockCache *MockCache, connectors ...ingestion.Connector) *fiber.App {
+	svc := service.NewIngestService(repo, ingestion.NewRegistry(connectors...), mockCache, events.NopPublisher{}, logger.New())
+	handler := NewIngestHandler(svc, logger.New())
+
+	app := fiber.New()
+	app.Post("/v2/ingest/:source", handler.HandleWebhook)
+	return app
+}
+
+func newFakeSlackConnector() *ingestion.FakeConnector {
+	connector := ingestion.NewFakeConnector(model.SourceSlack, model.IngestItem{
+		UserID:     "user_1",
+		ExternalID: "C1/1700000000.000100",
+		Title:      "#deploys",
+		Timestamp:  time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+	})
+	connector.Signature = "sha256=valid"
+	return connector
+}
+
+func TestIngestHandler_HandleWebhook_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockIngestRepository)
+	mockCache := new(MockCache)
+	connector := newFakeSlackConnector()
+	app := setupIngestTestApp(mockRepo, mockCache, connector)
+
+	mockRepo.On("UpsertItem", mock.Anything, mock.Anything).
+		Return(&model.IngestResult{ItemID: "item-1", ExternalID: "C1/1700000000.000100", Created: true, NewMessages: []string{}}, nil)
+	mockRepo.On("GetCountedItems", mock.Anything, []string{"item-1"}).Return(map[string]model.CountedItem{}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "user_1", mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/ingest/slack", bytes.NewReader([]byte(`{"event":"message"}`)))
+	req.Header.Set(ingestion.SignatureHeader, "sha256=valid")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.IngestResponse
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "item-1", result.Results[0].ItemID)
+	assert.Equal(t, [][]byte{[]byte(`{"event":"message"}`)}, connector.Received())
+}
+
+func TestIngestHandler_HandleWebhook_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		signature  string
+		parseErr   error
+		wantStatus int
+	}{
+		{"unknown source", "teams", "sha256=valid", nil, fiber.StatusNotFound},
+		{"invalid signature", "slack", "sha256=forged", nil, fiber.StatusUnauthorized},
+		{"invalid payload", "slack", "sha256=valid", ingestion.ErrInvalidPayload, fiber.StatusBadRequest},
+		{"connector failure", "slack", "sha256=valid", errors.New("boom"), fiber.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockIngestRepository)
+			connector := newFakeSlackConnector()
+			connector.Err = tt.parseErr
+			app := setupIngestTestApp(mockRepo, new(MockCache), connector)
+
+			// Act
+			req := httptest.NewRequest("POST", "/v2/ingest/"+tt.source, bytes.NewReader([]byte(`{}`)))
+			req.Header.Set(ingestion.SignatureHeader, tt.signature)
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			mockRepo.AssertNotCalled(t, "UpsertItem", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/insights"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// InsightHandler handles AI insight requests.
+type InsightHandler struct {
+	service *service.InsightService
+	log     *logger.Logger
+}
+
+// NewInsightHandler creates a new insight handler.
+func NewInsightHandler(svc *service.InsightService, log *logger.Logger) *InsightHandler {
+	return &InsightHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// Regenerate handles POST /v2/stream/:itemId/insights/regenerate requests.
+// @Summary Regenerate item insights
+// @Description Generates the summary, action items and suggested reply for an item's latest message right away, replacing its stored insights
+// @Tags insights
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.InsightsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/insights/regenerate [post]
+func (h *InsightHandler) Regenerate(c *fiber.Ctx) error {
+	response, err := h.service.RegenerateInsights(c.Context(), currentUserID(c), c.Params("itemId"))
+	if err != nil {
+		h.log.Error("Failed to regenerate insights: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to regenerate insights",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// RefineDraft handles POST /v2/stream/:itemId/messages/:messageId/insights/:insightId/refine requests.
+// @Summary Refine a draft reply
+// @Description Rewrites a draft reply following an instruction such as "shorter" or "more formal", keeping its version history
+// @Tags insights
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param messageId path string true "Message ID"
+// @Param insightId path string true "Draft insight ID"
+// @Param body body model.DraftRequest true "Refinement instruction"
+// @Success 200 {object} model.AIInsight
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/messages/{messageId}/insights/{insightId}/refine [post]
+func (h *InsightHandler) RefineDraft(c *fiber.Ctx) error {
+	var req model.DraftRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	instruction, err := service.ValidateDraftInstruction(req.Instruction)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	req = draftRequest(c)
+	req.Instruction = instruction
+
+	draft, err := h.service.RefineDraft(c.Context(), req)
+	if err != nil {
+		return h.draftError(c, err, "Failed to refine draft")
+	}
+	if draft == nil {
+		return draftNotFound(c)
+	}
+
+	return c.JSON(draft)
+}
+
+// RegenerateDraft handles POST /v2/stream/:itemId/messages/:messageId/insights/:insightId/regenerate requests.
+// @Summary Regenerate a draft reply
+// @Description Replaces a draft reply with a different one in the same tone, keeping its version history
+// @Tags insights
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param messageId path string true "Message ID"
+// @Param insightId path string true "Draft insight ID"
+// @Success 200 {object} model.AIInsight
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/messages/{messageId}/insights/{insightId}/regenerate [post]
+func (h *InsightHandler) RegenerateDraft(c *fiber.Ctx) error {
+	draft, err := h.service.RegenerateDraft(c.Context(), draftRequest(c))
+	if err != nil {
+		return h.draftError(c, err, "Failed to regenerate draft")
+	}
+	if draft == nil {
+		return draftNotFound(c)
+	}
+
+	return c.JSON(draft)
+}
+
+// SendDraft handles POST /v2/stream/:itemId/messages/:messageId/insights/:insightId/send requests.
+// @Summary Send a draft reply
+// @Description Sends a draft reply into the item's thread as a message from the authenticated user and marks the draft as sent
+// @Tags insights
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param messageId path string true "Message ID"
+// @Param insightId path string true "Draft insight ID"
+// @Success 201 {object} model.Message
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/messages/{messageId}/insights/{insightId}/send [post]
+func (h *InsightHandler) SendDraft(c *fiber.Ctx) error {
+	msg, err := h.service.SendDraft(c.Context(), draftRequest(c))
+	if err != nil {
+		return h.draftError(c, err, "Failed to send draft")
+	}
+	if msg == nil {
+		return draftNotFound(c)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(msg)
+}
+
+// draftRequest builds a draft request from the authenticated user and the URL path.
+func draftRequest(c *fiber.Ctx) model.DraftRequest {
+	return model.DraftRequest{
+		UserID:    currentUserID(c),
+		ItemID:    c.Params("itemId"),
+		MessageID: c.Params("messageId"),
+		InsightID: c.Params("insightId"),
+	}
+}
+
+// draftError maps draft service errors to responses.
+func (h *InsightHandler) draftError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, insights.ErrUnsupportedInstruction):
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	case errors.Is(err, service.ErrNotDraft):
+		return c.Status(fiber.StatusConflict).JSON(model.NewErrorResponse(
+			model.ErrCodeConflict,
+			"The insight is not a draft or was already sent",
+		))
+	case errors.Is(err, service.ErrDraftChanged):
+		return c.Status(fiber.StatusConflict).JSON(model.NewErrorResponse(
+			model.ErrCodeConflict,
+			"The draft was changed by another request",
+		))
+	}
+
+	h.log.Error("%s: %v", message, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+		model.ErrCodeInternalError,
+		message,
+	))
+}
+
+// draftNotFound responds that the draft in the path does not exist.
+func draftNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+		model.ErrCodeNotFound,
+		"The requested draft does not exist",
+	))
+}
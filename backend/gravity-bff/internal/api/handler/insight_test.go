@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/insights"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockInsightRepository is a mock implementation of InsightRepository.
+type MockInsightRepository struct {
+	mock.Mock
+}
+
+func (m *MockInsightRepository) QueueItems(ctx context.Context, itemIDs []string) error {
+	args := m.Called(ctx, itemIDs)
+	return args.Error(0)
+}
+
+func (m *MockInsightRepository) DequeueItems(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockInsightRepository) GetThread(ctx context.Context, itemID string) (*model.InsightThread, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InsightThread), args.Error(1)
+}
+
+func (m *MockInsightRepository) SaveInsights(ctx context.Context, messageID string, insights []model.AIInsight) error {
+	args := m.Called(ctx, messageID, insights)
+	return args.Error(0)
+}
+
+func (m *MockInsightRepository) GetMessageInsights(ctx context.Context, itemID, messageID string) ([]model.AIInsight, error) {
+	args := m.Called(ctx, itemID, messageID)
+	return args.Get(0).([]model.AIInsight), args.Error(1)
+}
+
+func (m *MockInsightRepository) ReplaceInsight(ctx context.Context, messageID string, prev, next model.AIInsight) (bool, error) {
+	args := m.Called(ctx, messageID, prev, next)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupInsightTestApp(repo *MockInsightRepository, streamRepo *MockStreamRepository, mockCache *MockCache) *fiber.App {
+	log := logger.New()
+	sender := service.NewStreamService(streamRepo, mockCache, events.NopPublisher{}, newTestConfig(), log)
+	svc := service.NewInsightService(repo, insights.NewRuleBased(), sender, mockCache, events.NopPublisher{}, &config.Config{}, log)
+	handler := NewInsightHandler(svc, log)
+
+	app := fiber.New()
+	v2 := app.Group("/v2", func(c *fiber.Ctx) error {
+		c.Locals("userID", "test-user")
+		return c.Next()
+	})
+	v2.Post("/stream/:itemId/insights/regenerate", handler.Regenerate)
+	v2.Post("/stream/:itemId/messages/:messageId/insights/:insightId/refine", handler.RefineDraft)
+	v2.Post("/stream/:itemId/messages/:messageId/insights/:insightId/regenerate", handler.RegenerateDraft)
+	v2.Post("/stream/:itemId/messages/:messageId/insights/:insightId/send", handler.SendDraft)
+
+	return app
+}
+
+func newTestDraftThread() *model.InsightThread {
+	return &model.InsightThread{
+		ItemID: "item-1",
+		UserID: "test-user",
+		Messages: []model.Message{{
+			ID:         "msg-1",
+			SenderType: model.SenderOther,
+			SenderInfo: &model.User{Name: "Ana Lima"},
+			Content:    "Please review the draft.",
+		}},
+	}
+}
+
+func newTestDraft() model.AIInsight {
+	return model.AIInsight{
+		ID:      "msg-1-reply",
+		Type:    model.InsightDraft,
+		Content: "Thanks, Ana. I'll take care of it and let you know once it's done.",
+		IsDraft: true,
+	}
+}
+
+func TestInsightHandler_Regenerate(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockInsightRepository)
+	mockCache := new(MockCache)
+	app := setupInsightTestApp(mockRepo, new(MockStreamRepository), mockCache)
+
+	mockRepo.On("GetThread", mock.Anything, "item-1").Return(newTestDraftThread(), nil)
+	mockRepo.On("SaveInsights", mock.Anything, "msg-1", mock.Anything).Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	req := httptest.NewRequest("POST", "/v2/stream/item-1/insights/regenerate", nil)
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.InsightsResponse
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "msg-1", result.MessageID)
+	assert.Len(t, result.Data, 3)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestInsightHandler_Regenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		thread         *model.InsightThread
+		threadErr      error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"not found", nil, nil, fiber.StatusNotFound, model.ErrCodeNotFound},
+		{"other user's item", &model.InsightThread{ItemID: "item-1", UserID: "other-user"}, nil, fiber.StatusNotFound, model.ErrCodeNotFound},
+		{"repository error", nil, errors.New("db down"), fiber.StatusInternalServerError, model.ErrCodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockInsightRepository)
+			app := setupInsightTestApp(mockRepo, new(MockStreamRepository), new(MockCache))
+
+			mockRepo.On("GetThread", mock.Anything, "item-1").Return(tt.thread, tt.threadErr)
+
+			req := httptest.NewRequest("POST", "/v2/stream/item-1/insights/regenerate", nil)
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var errResp model.ErrorResponse
+			assert.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+			mockRepo.AssertNotCalled(t, "SaveInsights", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInsightHandler_RefineDraft(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockInsightRepository)
+	mockCache := new(MockCache)
+	app := setupInsightTestApp(mockRepo, new(MockStreamRepository), mockCache)
+
+	mockRepo.On("GetThread", mock.Anything, "item-1").Return(newTestDraftThread(), nil)
+	mockRepo.On("GetMessageInsights", mock.Anything, "item-1", "msg-1").Return([]model.AIInsight{newTestDraft()}, nil)
+	mockRepo.On("ReplaceInsight", mock.Anything, "msg-1", newTestDraft(), mock.Anything).Return(true, nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	req := httptest.NewRequest("POST", "/v2/stream/item-1/messages/msg-1/insights/msg-1-reply/refine", strings.NewReader(`{"instruction": "more formal"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var draft model.AIInsight
+	assert.NoError(t, json.Unmarshal(body, &draft))
+	assert.True(t, strings.HasPrefix(draft.Content, "Dear Ana,"))
+	assert.Len(t, draft.Versions, 2)
+	assert.Equal(t, "more formal", draft.Versions[1].Instruction)
+}
+
+func TestInsightHandler_RefineDraft_Errors(t *testing.T) {
+	sent := newTestDraft()
+	sent.IsDraft = false
+
+	tests := []struct {
+		name           string
+		body           string
+		stored         []model.AIInsight
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid body", `{"instruction": `, nil, fiber.StatusBadRequest, model.ErrCodeBadRequest},
+		{"empty instruction", `{"instruction": " "}`, nil, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"unsupported instruction", `{"instruction": "make it rhyme"}`, []model.AIInsight{newTestDraft()}, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"already sent", `{"instruction": "shorter"}`, []model.AIInsight{sent}, fiber.StatusConflict, model.ErrCodeConflict},
+		{"no such insight", `{"instruction": "shorter"}`, []model.AIInsight{}, fiber.StatusNotFound, model.ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockInsightRepository)
+			app := setupInsightTestApp(mockRepo, new(MockStreamRepository), new(MockCache))
+
+			mockRepo.On("GetThread", mock.Anything, "item-1").Return(newTestDraftThread(), nil)
+			mockRepo.On("GetMessageInsights", mock.Anything, "item-1", "msg-1").Return(tt.stored, nil)
+
+			req := httptest.NewRequest("POST", "/v2/stream/item-1/messages/msg-1/insights/msg-1-reply/refine", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var errResp model.ErrorResponse
+			assert.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+			mockRepo.AssertNotCalled(t, "ReplaceInsight", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInsightHandler_SendDraft(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockInsightRepository)
+	streamRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	app := setupInsightTestApp(mockRepo, streamRepo, mockCache)
+	draft := newTestDraft()
+
+	mockRepo.On("GetThread", mock.Anything, "item-1").Return(newTestDraftThread(), nil)
+	mockRepo.On("GetMessageInsights", mock.Anything, "item-1", "msg-1").Return([]model.AIInsight{draft}, nil)
+	mockRepo.On("ReplaceInsight", mock.Anything, "msg-1", mock.Anything, mock.Anything).Return(true, nil)
+	streamRepo.On("CreateMessage", mock.Anything, "test-user", "item-1", mock.MatchedBy(func(msg model.Message) bool {
+		return msg.Content == draft.Content && msg.SenderType == model.SenderUser
+	}), mock.Anything).Return(&model.Message{ID: "msg-2", Content: draft.Content}, nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+
+	req := httptest.NewRequest("POST", "/v2/stream/item-1/messages/msg-1/insights/msg-1-reply/send", nil)
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var msg model.Message
+	assert.NoError(t, json.Unmarshal(body, &msg))
+	assert.Equal(t, "msg-2", msg.ID)
+	streamRepo.AssertExpectations(t)
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// LabelHandler handles label requests.
+type LabelHandler struct {
+	service *service.LabelService
+	log     *logger.Logger
+}
+
+// NewLabelHandler creates a new label handler.
+func NewLabelHandler(svc *service.LabelService, log *logger.Logger) *LabelHandler {
+	return &LabelHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// ListLabels handles GET /v2/labels requests.
+// @Summary List labels
+// @Description Lists the user's labels ordered by name
+// @Tags labels
+// @Produce json
+// @Success 200 {object} model.LabelsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/labels [get]
+func (h *LabelHandler) ListLabels(c *fiber.Ctx) error {
+	response, err := h.service.ListLabels(c.Context(), currentUserID(c))
+	if err != nil {
+		h.log.Error("Failed to list labels: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to list labels",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// GetLabel handles GET /v2/labels/:labelId requests.
+// @Summary Get a label
+// @Tags labels
+// @Produce json
+// @Param labelId path string true "Label ID"
+// @Success 200 {object} model.Label
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/labels/{labelId} [get]
+func (h *LabelHandler) GetLabel(c *fiber.Ctx) error {
+	label, err := h.service.GetLabel(c.Context(), currentUserID(c), c.Params("labelId"))
+	if err != nil {
+		h.log.Error("Failed to get label: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to retrieve label",
+		))
+	}
+
+	if label == nil {
+		return labelNotFound(c)
+	}
+
+	return c.JSON(label)
+}
+
+// CreateLabel handles POST /v2/labels requests.
+// @Summary Create a label
+// @Description Creates a label. Names are unique per user, ignoring case.
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Param body body model.LabelRequest true "Label"
+// @Success 201 {object} model.Label
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/labels [post]
+func (h *LabelHandler) CreateLabel(c *fiber.Ctx) error {
+	var req model.LabelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidLabelBody(c)
+	}
+
+	label, err := h.service.CreateLabel(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return h.labelError(c, err, "Failed to create label")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(label)
+}
+
+// UpdateLabel handles PUT /v2/labels/:labelId requests.
+// @Summary Replace a label
+// @Description Renames or recolors a label. Omitting the color clears it.
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Param labelId path string true "Label ID"
+// @Param body body model.LabelRequest true "Label"
+// @Success 200 {object} model.Label
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/labels/{labelId} [put]
+func (h *LabelHandler) UpdateLabel(c *fiber.Ctx) error {
+	var req model.LabelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidLabelBody(c)
+	}
+
+	label, err := h.service.UpdateLabel(c.Context(), currentUserID(c), c.Params("labelId"), req)
+	if err != nil {
+		return h.labelError(c, err, "Failed to update label")
+	}
+
+	if label == nil {
+		return labelNotFound(c)
+	}
+
+	return c.JSON(label)
+}
+
+// DeleteLabel handles DELETE /v2/labels/:labelId requests.
+// @Summary Delete a label
+// @Description Deletes a label and removes it from all items
+// @Tags labels
+// @Param labelId path string true "Label ID"
+// @Success 204
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/labels/{labelId} [delete]
+func (h *LabelHandler) DeleteLabel(c *fiber.Ctx) error {
+	deleted, err := h.service.DeleteLabel(c.Context(), currentUserID(c), c.Params("labelId"))
+	if err != nil {
+		h.log.Error("Failed to delete label: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to delete label",
+		))
+	}
+
+	if !deleted {
+		return labelNotFound(c)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AddItemLabels handles POST /v2/stream/:itemId/labels requests.
+// @Summary Apply labels to an item
+// @Description Applies labels to a priority item and returns all of its labels. Labels already applied are kept.
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param body body model.ItemLabelsRequest true "Label IDs"
+// @Success 200 {object} model.LabelsResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/labels [post]
+func (h *LabelHandler) AddItemLabels(c *fiber.Ctx) error {
+	var req model.ItemLabelsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidLabelBody(c)
+	}
+
+	response, err := h.service.AddItemLabels(c.Context(), currentUserID(c), c.Params("itemId"), req.LabelIDs)
+	if err != nil {
+		return h.labelError(c, err, "Failed to apply labels")
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// RemoveItemLabel handles DELETE /v2/stream/:itemId/labels/:labelId requests.
+// @Summary Remove a label from an item
+// @Description Removes a label from a priority item and returns its remaining labels
+// @Tags labels
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param labelId path string true "Label ID"
+// @Success 200 {object} model.LabelsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/labels/{labelId} [delete]
+func (h *LabelHandler) RemoveItemLabel(c *fiber.Ctx) error {
+	response, err := h.service.RemoveItemLabel(c.Context(), currentUserID(c), c.Params("itemId"), c.Params("labelId"))
+	if err != nil {
+		h.log.Error("Failed to remove label: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to remove label",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The item does not exist or does not have this label",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// labelError maps label service errors to responses.
+func (h *LabelHandler) labelError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidLabel) || errors.Is(err, service.ErrTooManyLabels):
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	case errors.Is(err, repository.ErrLabelExists):
+		return c.Status(fiber.StatusConflict).JSON(model.NewErrorResponse(
+			model.ErrCodeConflict,
+			"A label with this name already exists",
+		))
+	}
+
+	h.log.Error("%s: %v", message, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+		model.ErrCodeInternalError,
+		message,
+	))
+}
+
+func invalidLabelBody(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+		model.ErrCodeBadRequest,
+		"Invalid request body",
+	))
+}
+
+func labelNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+		model.ErrCodeNotFound,
+		"The requested label does not exist",
+	))
+}
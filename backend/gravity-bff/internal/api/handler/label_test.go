@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockLabelRepository is a mock implementation of LabelRepository.
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) ListLabels(ctx context.Context, userID string) ([]model.Label, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetLabel(ctx context.Context, userID, labelID string) (*model.Label, error) {
+	args := m.Called(ctx, userID, labelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) CreateLabel(ctx context.Context, label model.Label) (*model.Label, error) {
+	args := m.Called(ctx, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) UpdateLabel(ctx context.Context, label model.Label) (*model.Label, error) {
+	args := m.Called(ctx, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) DeleteLabel(ctx context.Context, userID, labelID string) (bool, error) {
+	args := m.Called(ctx, userID, labelID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) ListLabeledItemIDs(ctx context.Context, userID, labelID string) ([]string, error) {
+	args := m.Called(ctx, userID, labelID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockLabelRepository) AddItemLabels(ctx context.Context, userID, itemID string, labelIDs []string) (bool, error) {
+	args := m.Called(ctx, userID, itemID, labelIDs)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) RemoveItemLabel(ctx context.Context, userID, itemID, labelID string) (bool, error) {
+	args := m.Called(ctx, userID, itemID, labelID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetItemLabels(ctx context.Context, itemID string) ([]model.Label, error) {
+	args := m.Called(ctx, itemID)
+	return args.Get(0).([]model.Label), args.Error(1)
+}
+
+func setupLabelTestApp(repo *MockLabelRepository, mockCache *MockCache) *fiber.App {
+	log := logger.New()
+	handler := NewLabelHandler(service.NewLabelService(repo, mockCache, events.NopPublisher{}, log), log)
+
+	app := fiber.New()
+	v2 := app.Group("/v2", func(c *fiber.Ctx) error {
+		c.Locals("userID", "test-user")
+		return c.Next()
+	})
+	v2.Post("/labels", handler.CreateLabel)
+	v2.Get("/labels/:labelId", handler.GetLabel)
+	v2.Post("/stream/:itemId/labels", handler.AddItemLabels)
+	v2.Delete("/stream/:itemId/labels/:labelId", handler.RemoveItemLabel)
+
+	return app
+}
+
+const testLabelID = "0b6f4a8e-3c1d-4e2f-9a7b-5c6d7e8f9a0b"
+
+func TestLabelHandler_CreateLabel(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockLabelRepository)
+	app := setupLabelTestApp(mockRepo, new(MockCache))
+
+	mockRepo.On("ListLabels", mock.Anything, "test-user").Return([]model.Label{}, nil)
+	mockRepo.On("CreateLabel", mock.Anything, mock.Anything).Return(&model.Label{ID: testLabelID, Name: "Launch"}, nil)
+
+	req := httptest.NewRequest("POST", "/v2/labels", strings.NewReader(`{"name": "Launch"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var label model.Label
+	assert.NoError(t, json.Unmarshal(body, &label))
+	assert.Equal(t, testLabelID, label.ID)
+}
+
+func TestLabelHandler_CreateLabel_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createErr      error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid body", `{"name": `, nil, fiber.StatusBadRequest, model.ErrCodeBadRequest},
+		{"invalid color", `{"name": "Launch", "color": "blue"}`, nil, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"duplicate name", `{"name": "Launch"}`, repository.ErrLabelExists, fiber.StatusConflict, model.ErrCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockLabelRepository)
+			app := setupLabelTestApp(mockRepo, new(MockCache))
+
+			mockRepo.On("ListLabels", mock.Anything, "test-user").Return([]model.Label{}, nil)
+			mockRepo.On("CreateLabel", mock.Anything, mock.Anything).Return(nil, tt.createErr)
+
+			req := httptest.NewRequest("POST", "/v2/labels", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var errResp model.ErrorResponse
+			assert.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+		})
+	}
+}
+
+func TestLabelHandler_GetLabel_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockLabelRepository)
+	app := setupLabelTestApp(mockRepo, new(MockCache))
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/v2/labels/label-9", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	mockRepo.AssertNotCalled(t, "GetLabel", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLabelHandler_AddItemLabels(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockLabelRepository)
+	mockCache := new(MockCache)
+	app := setupLabelTestApp(mockRepo, mockCache)
+
+	labels := []model.Label{{ID: testLabelID, Name: "Launch"}}
+	mockRepo.On("ListLabels", mock.Anything, "test-user").Return(labels, nil)
+	mockRepo.On("AddItemLabels", mock.Anything, "test-user", "item-1", []string{testLabelID}).Return(true, nil)
+	mockRepo.On("GetItemLabels", mock.Anything, "item-1").Return(labels, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	req := httptest.NewRequest("POST", "/v2/stream/item-1/labels", strings.NewReader(`{"labelIds": ["`+testLabelID+`"]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.LabelsResponse
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, labels, result.Data)
+}
+
+func TestLabelHandler_RemoveItemLabel_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockLabelRepository)
+	app := setupLabelTestApp(mockRepo, new(MockCache))
+
+	mockRepo.On("RemoveItemLabel", mock.Anything, "test-user", "item-9", testLabelID).Return(false, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/v2/stream/item-9/labels/"+testLabelID, nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// PeopleHandler handles people directory requests.
+type PeopleHandler struct {
+	service *service.PeopleService
+	log     *logger.Logger
+}
+
+// NewPeopleHandler creates a new people handler.
+func NewPeopleHandler(svc *service.PeopleService, log *logger.Logger) *PeopleHandler {
+	return &PeopleHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// ListPeople handles GET /v2/people requests.
+// @Summary List people
+// @Description Lists the people who take part in the user's items, ranked by how many items they share and how recently
+// @Tags people
+// @Produce json
+// @Param vip query bool false "Only people marked as VIP"
+// @Param limit query int false "Maximum people to return" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Pagination cursor"
+// @Success 200 {object} model.PeopleResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/people [get]
+func (h *PeopleHandler) ListPeople(c *fiber.Ctx) error {
+	vipOnly, err := service.ParseVIPOnly(c.Query("vip"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "20"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+
+	req := model.PeopleRequest{
+		UserID:  currentUserID(c),
+		VIPOnly: vipOnly,
+		Limit:   limit,
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		req.Cursor = &cursor
+	}
+
+	response, err := h.service.ListPeople(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to list people: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to list people",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// GetPerson handles GET /v2/people/:personId requests.
+// @Summary Get a person
+// @Description Retrieves a person's profile with the latest items they take part in, from every source
+// @Tags people
+// @Produce json
+// @Param personId path string true "Person (user) ID"
+// @Success 200 {object} model.PersonResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/people/{personId} [get]
+func (h *PeopleHandler) GetPerson(c *fiber.Ctx) error {
+	response, err := h.service.GetPerson(c.Context(), currentUserID(c), c.Params("personId"))
+	if err != nil {
+		h.log.Error("Failed to get person: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to retrieve person",
+		))
+	}
+
+	if response == nil {
+		return personNotFound(c)
+	}
+
+	return c.JSON(response)
+}
+
+// MarkVIP handles POST /v2/people/:personId/vip requests.
+// @Summary Mark a person as VIP
+// @Description Marks a person as a VIP. Items they take part in score higher in the stream.
+// @Tags people
+// @Produce json
+// @Param personId path string true "Person (user) ID"
+// @Success 200 {object} model.Person
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/people/{personId}/vip [post]
+func (h *PeopleHandler) MarkVIP(c *fiber.Ctx) error {
+	return h.setVIP(c, true)
+}
+
+// UnmarkVIP handles DELETE /v2/people/:personId/vip requests.
+// @Summary Unmark a person as VIP
+// @Tags people
+// @Produce json
+// @Param personId path string true "Person (user) ID"
+// @Success 200 {object} model.Person
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/people/{personId}/vip [delete]
+func (h *PeopleHandler) UnmarkVIP(c *fiber.Ctx) error {
+	return h.setVIP(c, false)
+}
+
+// setVIP marks or unmarks the person in the path as a VIP.
+func (h *PeopleHandler) setVIP(c *fiber.Ctx, vip bool) error {
+	person, err := h.service.SetVIP(c.Context(), currentUserID(c), c.Params("personId"), vip)
+	if err != nil {
+		h.log.Error("Failed to update vip: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to update VIP",
+		))
+	}
+
+	if person == nil {
+		return personNotFound(c)
+	}
+
+	return c.JSON(person)
+}
+
+func personNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+		model.ErrCodeNotFound,
+		"The requested person does not exist",
+	))
+}
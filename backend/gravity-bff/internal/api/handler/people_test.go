@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockUserRepository is a mock implementation of UserRepository.
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUsersByIDs(ctx context.Context, userIDs []string) ([]model.User, error) {
+	args := m.Called(ctx, userIDs)
+	return args.Get(0).([]model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListPeople(ctx context.Context, req model.PeopleRequest) ([]model.Person, *string, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]model.Person), args.Get(1).(*string), args.Error(2)
+}
+
+func (m *MockUserRepository) GetPerson(ctx context.Context, userID, personID string) (*model.Person, error) {
+	args := m.Called(ctx, userID, personID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Person), args.Error(1)
+}
+
+func (m *MockUserRepository) SetVIP(ctx context.Context, userID, personID string, vip bool) ([]string, error) {
+	args := m.Called(ctx, userID, personID, vip)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func setupPeopleTestApp(users *MockUserRepository, streamRepo *MockStreamRepository) *fiber.App {
+	log := logger.New()
+	handler := NewPeopleHandler(service.NewPeopleService(users, streamRepo, log), log)
+
+	app := fiber.New()
+	v2 := app.Group("/v2", func(c *fiber.Ctx) error {
+		c.Locals("userID", "test-user")
+		return c.Next()
+	})
+	v2.Get("/people", handler.ListPeople)
+	v2.Get("/people/:personId", handler.GetPerson)
+	v2.Post("/people/:personId/vip", handler.MarkVIP)
+	v2.Delete("/people/:personId/vip", handler.UnmarkVIP)
+
+	return app
+}
+
+const testPersonID = "8a6e0804-2bd0-4672-b79d-d97027f9071a"
+
+func TestPeopleHandler_ListPeople(t *testing.T) {
+	// Arrange
+	users := new(MockUserRepository)
+	app := setupPeopleTestApp(users, new(MockStreamRepository))
+
+	users.On("ListPeople", mock.Anything, model.PeopleRequest{UserID: "test-user", VIPOnly: true, Limit: 5}).
+		Return([]model.Person{{User: model.User{ID: testPersonID, Name: "Ada"}, ItemCount: 3, VIP: true}}, (*string)(nil), nil)
+
+	req := httptest.NewRequest("GET", "/v2/people?vip=true&limit=5", nil)
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.PeopleResponse
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Len(t, result.Data, 1)
+	assert.Equal(t, "Ada", result.Data[0].Name)
+	assert.True(t, result.Data[0].VIP)
+	assert.Nil(t, result.NextCursor)
+}
+
+func TestPeopleHandler_ListPeople_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		repoErr        error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid vip", "?vip=maybe", nil, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"repository error", "", errors.New("db down"), fiber.StatusInternalServerError, model.ErrCodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			users := new(MockUserRepository)
+			app := setupPeopleTestApp(users, new(MockStreamRepository))
+			users.On("ListPeople", mock.Anything, mock.Anything).Return([]model.Person{}, (*string)(nil), tt.repoErr)
+
+			req := httptest.NewRequest("GET", "/v2/people"+tt.query, nil)
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var errResp model.ErrorResponse
+			assert.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+		})
+	}
+}
+
+func TestPeopleHandler_GetPerson(t *testing.T) {
+	// Arrange
+	users := new(MockUserRepository)
+	streamRepo := new(MockStreamRepository)
+	app := setupPeopleTestApp(users, streamRepo)
+
+	users.On("GetPerson", mock.Anything, "test-user", testPersonID).
+		Return(&model.Person{User: model.User{ID: testPersonID, Name: "Ada"}, ItemCount: 1}, nil)
+	streamRepo.On("GetPinnedItems", mock.Anything, "test-user", mock.Anything).Return([]model.PriorityItem{}, nil)
+	streamRepo.On("GetStream", mock.Anything, mock.Anything).Return([]model.PriorityItem{{ID: "item-1"}}, (*string)(nil), nil)
+
+	req := httptest.NewRequest("GET", "/v2/people/"+testPersonID, nil)
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.PersonResponse
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, testPersonID, result.ID)
+	assert.Equal(t, 1, result.ItemCount)
+	assert.Len(t, result.RecentItems, 1)
+}
+
+func TestPeopleHandler_GetPerson_NotFound(t *testing.T) {
+	// Arrange
+	users := new(MockUserRepository)
+	app := setupPeopleTestApp(users, new(MockStreamRepository))
+	users.On("GetPerson", mock.Anything, "test-user", testPersonID).Return(nil, nil)
+
+	req := httptest.NewRequest("GET", "/v2/people/"+testPersonID, nil)
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestPeopleHandler_SetVIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		vip            bool
+		itemIDs        []string
+		expectedStatus int
+	}{
+		{"mark", "POST", true, []string{"item-1"}, fiber.StatusOK},
+		{"unmark", "DELETE", false, []string{"item-1"}, fiber.StatusOK},
+		{"not one of the user's people", "POST", true, nil, fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			users := new(MockUserRepository)
+			app := setupPeopleTestApp(users, new(MockStreamRepository))
+			users.On("SetVIP", mock.Anything, "test-user", testPersonID, tt.vip).Return(tt.itemIDs, nil)
+			users.On("GetPerson", mock.Anything, "test-user", testPersonID).
+				Return(&model.Person{User: model.User{ID: testPersonID}, VIP: tt.vip}, nil)
+
+			req := httptest.NewRequest(tt.method, "/v2/people/"+testPersonID+"/vip", nil)
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			users.AssertCalled(t, "SetVIP", mock.Anything, "test-user", testPersonID, tt.vip)
+		})
+	}
+}
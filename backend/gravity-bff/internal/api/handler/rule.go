@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/rules"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// RuleHandler handles triage rule requests.
+type RuleHandler struct {
+	service *service.RuleService
+	log     *logger.Logger
+}
+
+// NewRuleHandler creates a new rule handler.
+func NewRuleHandler(svc *service.RuleService, log *logger.Logger) *RuleHandler {
+	return &RuleHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// ListRules handles GET /v2/rules requests.
+// @Summary List rules
+// @Description Lists the user's triage rules in the order they apply
+// @Tags rules
+// @Produce json
+// @Success 200 {object} model.RulesResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/rules [get]
+func (h *RuleHandler) ListRules(c *fiber.Ctx) error {
+	response, err := h.service.ListRules(c.Context(), currentUserID(c))
+	if err != nil {
+		h.log.Error("Failed to list rules: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to list rules",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// GetRule handles GET /v2/rules/:ruleId requests.
+// @Summary Get a rule
+// @Tags rules
+// @Produce json
+// @Param ruleId path string true "Rule ID"
+// @Success 200 {object} model.Rule
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/rules/{ruleId} [get]
+func (h *RuleHandler) GetRule(c *fiber.Ctx) error {
+	rule, err := h.service.GetRule(c.Context(), currentUserID(c), c.Params("ruleId"))
+	if err != nil {
+		h.log.Error("Failed to get rule: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to retrieve rule",
+		))
+	}
+
+	if rule == nil {
+		return ruleNotFound(c)
+	}
+
+	return c.JSON(rule)
+}
+
+// CreateRule handles POST /v2/rules requests.
+// @Summary Create a rule
+// @Description Creates a triage rule. Rules apply to items as they are ingested.
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param body body model.RuleRequest true "Rule"
+// @Success 201 {object} model.Rule
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/rules [post]
+func (h *RuleHandler) CreateRule(c *fiber.Ctx) error {
+	var req model.RuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidRuleBody(c)
+	}
+
+	rule, err := h.service.CreateRule(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return h.ruleError(c, err, "Failed to create rule")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(rule)
+}
+
+// UpdateRule handles PUT /v2/rules/:ruleId requests.
+// @Summary Replace a rule
+// @Description Replaces a triage rule. Omitting the position keeps the current one.
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param ruleId path string true "Rule ID"
+// @Param body body model.RuleRequest true "Rule"
+// @Success 200 {object} model.Rule
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/rules/{ruleId} [put]
+func (h *RuleHandler) UpdateRule(c *fiber.Ctx) error {
+	var req model.RuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidRuleBody(c)
+	}
+
+	rule, err := h.service.UpdateRule(c.Context(), currentUserID(c), c.Params("ruleId"), req)
+	if err != nil {
+		return h.ruleError(c, err, "Failed to update rule")
+	}
+
+	if rule == nil {
+		return ruleNotFound(c)
+	}
+
+	return c.JSON(rule)
+}
+
+// DeleteRule handles DELETE /v2/rules/:ruleId requests.
+// @Summary Delete a rule
+// @Tags rules
+// @Param ruleId path string true "Rule ID"
+// @Success 204
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/rules/{ruleId} [delete]
+func (h *RuleHandler) DeleteRule(c *fiber.Ctx) error {
+	deleted, err := h.service.DeleteRule(c.Context(), currentUserID(c), c.Params("ruleId"))
+	if err != nil {
+		h.log.Error("Failed to delete rule: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to delete rule",
+		))
+	}
+
+	if !deleted {
+		return ruleNotFound(c)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// DryRun handles POST /v2/rules/dry-run requests.
+// @Summary Dry-run a rule
+// @Description Reports which of the user's most recent items an unsaved rule would match. Nothing is changed.
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param body body model.RuleRequest true "Rule"
+// @Success 200 {object} model.RuleDryRunResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/rules/dry-run [post]
+func (h *RuleHandler) DryRun(c *fiber.Ctx) error {
+	var req model.RuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidRuleBody(c)
+	}
+
+	response, err := h.service.DryRun(c.Context(), currentUserID(c), req)
+	if err != nil {
+		return h.ruleError(c, err, "Failed to dry-run rule")
+	}
+
+	return c.JSON(response)
+}
+
+// DryRunRule handles POST /v2/rules/:ruleId/dry-run requests.
+// @Summary Dry-run a saved rule
+// @Description Reports which of the user's most recent items a saved rule would match, even if it is disabled
+// @Tags rules
+// @Produce json
+// @Param ruleId path string true "Rule ID"
+// @Success 200 {object} model.RuleDryRunResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/rules/{ruleId}/dry-run [post]
+func (h *RuleHandler) DryRunRule(c *fiber.Ctx) error {
+	response, err := h.service.DryRunRule(c.Context(), currentUserID(c), c.Params("ruleId"))
+	if err != nil {
+		h.log.Error("Failed to dry-run rule: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to dry-run rule",
+		))
+	}
+
+	if response == nil {
+		return ruleNotFound(c)
+	}
+
+	return c.JSON(response)
+}
+
+// ruleError maps rule service errors to responses.
+func (h *RuleHandler) ruleError(c *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, rules.ErrInvalidRule) || errors.Is(err, service.ErrTooManyRules) {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	h.log.Error("%s: %v", message, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+		model.ErrCodeInternalError,
+		message,
+	))
+}
+
+func invalidRuleBody(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+		model.ErrCodeBadRequest,
+		"Invalid request body",
+	))
+}
+
+func ruleNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+		model.ErrCodeNotFound,
+		"The requested rule does not exist",
+	))
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/service"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockRuleRepository is a mock implementation of RuleRepository.
+type MockRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRuleRepository) ListRules(ctx context.Context, userID string) ([]model.Rule, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) GetRule(ctx context.Context, userID, ruleID string) (*model.Rule, error) {
+	args := m.Called(ctx, userID, ruleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) CreateRule(ctx context.Context, rule model.Rule) (*model.Rule, error) {
+	args := m.Called(ctx, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) UpdateRule(ctx context.Context, rule model.Rule) (*model.Rule, error) {
+	args := m.Called(ctx, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) DeleteRule(ctx context.Context, userID, ruleID string) (bool, error) {
+	args := m.Called(ctx, userID, ruleID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRuleRepository) NextRulePosition(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRuleRepository) ApplyRuleEffect(ctx context.Context, userID, itemID string, effect model.RuleEffect) error {
+	args := m.Called(ctx, userID, itemID, effect)
+	return args.Error(0)
+}
+
+func (m *MockRuleRepository) ListRuleCandidates(ctx context.Context, userID string, limit int) ([]model.PriorityItem, error) {
+	args := m.Called(ctx, userID, limit)
+	return args.Get(0).([]model.PriorityItem), args.Error(1)
+}
+
+func setupRuleTestApp(repo *MockRuleRepository) *fiber.App {
+	log := logger.New()
+	handler := NewRuleHandler(service.NewRuleService(repo, log), log)
+
+	app := fiber.New()
+	rules := app.Group("/v2/rules", func(c *fiber.Ctx) error {
+		c.Locals("userID", "test-user")
+		return c.Next()
+	})
+	rules.Post("/", handler.CreateRule)
+	rules.Post("/dry-run", handler.DryRun)
+	rules.Get("/:ruleId", handler.GetRule)
+	rules.Delete("/:ruleId", handler.DeleteRule)
+
+	return app
+}
+
+const testRuleBody = `{
+	"name": "Deploys are low priority",
+	"conditions": [{"field": "title", "op": "contains", "value": "deploy"}],
+	"actions": [{"type": "set_priority", "priority": "low"}]
+}`
+
+func TestRuleHandler_CreateRule(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRuleRepository)
+	app := setupRuleTestApp(mockRepo)
+
+	mockRepo.On("ListRules", mock.Anything, "test-user").Return([]model.Rule{}, nil)
+	mockRepo.On("NextRulePosition", mock.Anything, "test-user").Return(0, nil)
+	mockRepo.On("CreateRule", mock.Anything, mock.Anything).Return(&model.Rule{ID: "rule-1", Name: "Deploys are low priority"}, nil)
+
+	req := httptest.NewRequest("POST", "/v2/rules", strings.NewReader(testRuleBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var rule model.Rule
+	assert.NoError(t, json.Unmarshal(body, &rule))
+	assert.Equal(t, "rule-1", rule.ID)
+}
+
+func TestRuleHandler_CreateRule_Invalid(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRuleRepository)
+	app := setupRuleTestApp(mockRepo)
+
+	req := httptest.NewRequest("POST", "/v2/rules", strings.NewReader(`{
+		"name": "Bad field",
+		"conditions": [{"field": "nope", "op": "eq", "value": "x"}],
+		"actions": [{"type": "mark_read"}]
+	}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var errResp model.ErrorResponse
+	assert.NoError(t, json.Unmarshal(body, &errResp))
+	assert.Equal(t, model.ErrCodeValidationFailed, errResp.Error.Code)
+}
+
+func TestRuleHandler_GetRule_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRuleRepository)
+	app := setupRuleTestApp(mockRepo)
+
+	mockRepo.On("GetRule", mock.Anything, "test-user", "rule-9").Return(nil, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/v2/rules/rule-9", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestRuleHandler_DeleteRule(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRuleRepository)
+	app := setupRuleTestApp(mockRepo)
+
+	mockRepo.On("DeleteRule", mock.Anything, "test-user", "rule-1").Return(true, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/v2/rules/rule-1", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+}
+
+func TestRuleHandler_DryRun(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockRuleRepository)
+	app := setupRuleTestApp(mockRepo)
+
+	mockRepo.On("ListRuleCandidates", mock.Anything, "test-user", service.DryRunLimit+1).Return([]model.PriorityItem{
+		{ID: "item-1", Title: "Deploy finished"},
+		{ID: "item-2", Title: "Lunch?"},
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/v2/rules/dry-run", strings.NewReader(testRuleBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.RuleDryRunResponse
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, 2, result.Scanned)
+	assert.Equal(t, 1, len(result.Matches))
+	assert.Equal(t, "item-1", result.Matches[0].ID)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -24,13 +25,32 @@ func NewStreamHandler(svc *service.StreamService, log *logger.Logger) *StreamHan
 	}
 }
 
+// currentUserID returns the user ID set by the auth middleware.
+func currentUserID(c *fiber.Ctx) string {
+	userID, ok := c.Locals("userID").(string)
+	if !ok || userID == "" {
+		// For development/testing, use a default user ID
+		return "default-user"
+	}
+	return userID
+}
+
 // GetStream handles GET /v2/stream requests.
 // @Summary Get unified stream
 // @Description Retrieves a paginated list of priority items for the authenticated user
 // @Tags stream
 // @Accept json
 // @Produce json
-// @Param filter query string false "Filter items (all, high, unread)" default(all)
+// @Param filter query string false "Filter preset (all, high, unread, snoozed, archived, trash, awaiting_reply, needs_my_reply). Snoozed, archived and trashed items only appear with their preset." default(all)
+// @Param source query string false "Comma-separated sources (email, slack, ...)"
+// @Param priority query string false "Comma-separated priorities (high, medium, low)"
+// @Param unread query bool false "Only unread (true) or read (false) items"
+// @Param since query string false "Items at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param until query string false "Items before this time (RFC 3339 or YYYY-MM-DD)"
+// @Param participant query string false "Items with this participant user ID"
+// @Param has_attachments query bool false "Items with (true) or without (false) attachments"
+// @Param label query string false "Comma-separated label IDs; items with any of the labels"
+// @Param sort query string false "Order by latest activity (recent) or priority score (score)" default(recent)
 // @Param limit query int false "Maximum items to return" default(20) minimum(1) maximum(100)
 // @Param cursor query string false "Pagination cursor"
 // @Success 200 {object} model.StreamResponse
@@ -40,15 +60,28 @@ func NewStreamHandler(svc *service.StreamService, log *logger.Logger) *StreamHan
 // @Router /v2/stream [get]
 func (h *StreamHandler) GetStream(c *fiber.Ctx) error {
 	// Get user ID from context (set by auth middleware)
-	userID := c.Locals("userID")
-	if userID == nil {
-		// For development/testing, use a default user ID
-		userID = "default-user"
-	}
+	userID := currentUserID(c)
 
 	// Parse query parameters
-	filterStr := c.Query("filter", "all")
-	filter, err := service.ValidateFilter(filterStr)
+	filter, err := service.ParseStreamFilter(service.StreamFilterParams{
+		Preset:         c.Query("filter", "all"),
+		Sources:        c.Query("source"),
+		Priorities:     c.Query("priority"),
+		Unread:         c.Query("unread"),
+		Since:          c.Query("since"),
+		Until:          c.Query("until"),
+		ParticipantID:  c.Query("participant"),
+		HasAttachments: c.Query("has_attachments"),
+		Labels:         c.Query("label"),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	sort, err := service.ValidateSort(c.Query("sort"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
 			model.ErrCodeValidationFailed,
@@ -73,8 +106,9 @@ func (h *StreamHandler) GetStream(c *fiber.Ctx) error {
 
 	// Build request
 	req := model.StreamRequest{
-		UserID: userID.(string),
+		UserID: userID,
 		Filter: filter,
+		Sort:   sort,
 		Limit:  limit,
 		Cursor: cursorPtr,
 	}
@@ -92,9 +126,132 @@ func (h *StreamHandler) GetStream(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// SearchStream handles GET /v2/stream/search requests.
+// @Summary Search the stream
+// @Description Full-text search over item titles, snippets and message bodies, ranked by relevance
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query (web search syntax)"
+// @Param limit query int false "Maximum results to return" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Pagination cursor"
+// @Success 200 {object} model.SearchResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/search [get]
+func (h *StreamHandler) SearchStream(c *fiber.Ctx) error {
+	query, err := service.ValidateSearchQuery(c.Query("q"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "20"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	cursor := c.Query("cursor")
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	req := model.SearchRequest{
+		UserID: currentUserID(c),
+		Query:  query,
+		Limit:  limit,
+		Cursor: cursorPtr,
+	}
+
+	response, err := h.service.SearchStream(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to search stream: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to search stream",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// GetCounts handles GET /v2/stream/counts requests.
+// @Summary Get stream counts
+// @Description Counts the items in the default stream (not archived, trashed or snoozed): total, unread and high priority, overall and by source and label
+// @Tags stream
+// @Produce json
+// @Success 200 {object} model.CountsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/counts [get]
+func (h *StreamHandler) GetCounts(c *fiber.Ctx) error {
+	response, err := h.service.GetCounts(c.Context(), currentUserID(c))
+	if err != nil {
+		h.log.Error("Failed to get counts: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to retrieve stream counts",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// Sync handles GET /v2/sync requests.
+// @Summary Sync stream changes
+// @Description Retrieves the items created, updated and deleted since a sync token, in any state. Omit since for a full sync; call again with the returned token while hasMore is true.
+// @Tags sync
+// @Produce json
+// @Param since query string false "Sync token from the previous response"
+// @Param limit query int false "Max changes to return (default: 100, max: 500)"
+// @Success 200 {object} model.SyncResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/sync [get]
+func (h *StreamHandler) Sync(c *fiber.Ctx) error {
+	limit, err := strconv.Atoi(c.Query("limit", "100"))
+	if err != nil || limit < 1 {
+		limit = 100
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	req := model.SyncRequest{
+		UserID: currentUserID(c),
+		Since:  c.Query("since"),
+		Limit:  limit,
+	}
+
+	response, err := h.service.Sync(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSyncToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+				model.ErrCodeValidationFailed,
+				err.Error(),
+			))
+		}
+		h.log.Error("Failed to sync stream: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to retrieve changes",
+		))
+	}
+
+	return c.JSON(response)
+}
+
 // GetStreamItem handles GET /v2/stream/:itemId requests.
 // @Summary Get stream item details
-// @Description Retrieves full details of a single priority item including messages
+// @Description Retrieves full details of a single priority item including its latest messages
 // @Tags stream
 // @Accept json
 // @Produce json
@@ -107,11 +264,7 @@ func (h *StreamHandler) GetStream(c *fiber.Ctx) error {
 // @Router /v2/stream/{itemId} [get]
 func (h *StreamHandler) GetStreamItem(c *fiber.Ctx) error {
 	// Get user ID from context (set by auth middleware)
-	userID := c.Locals("userID")
-	if userID == nil {
-		// For development/testing, use a default user ID
-		userID = "default-user"
-	}
+	userID := currentUserID(c)
 
 	// Get item ID from path
 	itemID := c.Params("itemId")
@@ -124,7 +277,7 @@ func (h *StreamHandler) GetStreamItem(c *fiber.Ctx) error {
 
 	// Build request
 	req := model.StreamItemRequest{
-		UserID: userID.(string),
+		UserID: userID,
 		ItemID: itemID,
 	}
 
@@ -147,3 +300,865 @@ func (h *StreamHandler) GetStreamItem(c *fiber.Ctx) error {
 
 	return c.JSON(item)
 }
+
+// GetMessages handles GET /v2/stream/:itemId/messages requests.
+// @Summary Get item messages
+// @Description Retrieves a page of an item's messages, oldest first. Without before, returns the latest messages; pass nextCursor (or the item's messagesCursor) as before for older ones.
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param before query string false "Pagination cursor"
+// @Param limit query int false "Max messages to return (default: 50, max: 200)"
+// @Success 200 {object} model.MessagesResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/messages [get]
+func (h *StreamHandler) GetMessages(c *fiber.Ctx) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit < 1 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	before := c.Query("before")
+	var beforePtr *string
+	if before != "" {
+		beforePtr = &before
+	}
+
+	req := model.MessagesRequest{
+		UserID: currentUserID(c),
+		ItemID: itemID,
+		Before: beforePtr,
+		Limit:  limit,
+	}
+
+	response, err := h.service.GetMessages(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to get messages: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to retrieve messages",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// MarkRead handles POST /v2/stream/:itemId/read requests.
+// @Summary Mark item as read
+// @Description Marks a single priority item as read
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.ReadStateResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/read [post]
+func (h *StreamHandler) MarkRead(c *fiber.Ctx) error {
+	return h.setItemReadState(c, false)
+}
+
+// MarkUnread handles POST /v2/stream/:itemId/unread requests.
+// @Summary Mark item as unread
+// @Description Marks a single priority item as unread
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.ReadStateResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/unread [post]
+func (h *StreamHandler) MarkUnread(c *fiber.Ctx) error {
+	return h.setItemReadState(c, true)
+}
+
+// BulkMarkRead handles POST /v2/stream/read requests.
+// @Summary Mark items as read
+// @Description Marks up to 100 priority items as read
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param body body model.ReadStateRequest true "Item IDs"
+// @Success 200 {object} model.ReadStateResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/read [post]
+func (h *StreamHandler) BulkMarkRead(c *fiber.Ctx) error {
+	return h.setBulkReadState(c, false)
+}
+
+// BulkMarkUnread handles POST /v2/stream/unread requests.
+// @Summary Mark items as unread
+// @Description Marks up to 100 priority items as unread
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param body body model.ReadStateRequest true "Item IDs"
+// @Success 200 {object} model.ReadStateResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/unread [post]
+func (h *StreamHandler) BulkMarkUnread(c *fiber.Ctx) error {
+	return h.setBulkReadState(c, true)
+}
+
+// setItemReadState updates the read state of the item in the path.
+func (h *StreamHandler) setItemReadState(c *fiber.Ctx, unread bool) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	req := model.ReadStateRequest{
+		UserID:  currentUserID(c),
+		ItemIDs: []string{itemID},
+		Unread:  unread,
+	}
+
+	response, err := h.service.SetReadState(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to update read state: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to update read state",
+		))
+	}
+
+	if len(response.ItemIDs) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// setBulkReadState updates the read state of the items listed in the request body.
+func (h *StreamHandler) setBulkReadState(c *fiber.Ctx, unread bool) error {
+	var req model.ReadStateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	itemIDs, err := service.ValidateItemIDs(req.ItemIDs)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	req.UserID = currentUserID(c)
+	req.ItemIDs = itemIDs
+	req.Unread = unread
+
+	response, err := h.service.SetReadState(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to update read state: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to update read state",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// Archive handles POST /v2/stream/:itemId/archive requests.
+// @Summary Archive an item
+// @Description Moves a priority item out of the stream into the archive. Archived items stay searchable.
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.ItemStateResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/archive [post]
+func (h *StreamHandler) Archive(c *fiber.Ctx) error {
+	return h.setItemState(c, model.ItemArchived)
+}
+
+// Trash handles POST /v2/stream/:itemId/trash requests.
+// @Summary Trash an item
+// @Description Moves a priority item to the trash, where it is permanently deleted after the retention period
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.ItemStateResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/trash [post]
+func (h *StreamHandler) Trash(c *fiber.Ctx) error {
+	return h.setItemState(c, model.ItemTrashed)
+}
+
+// Restore handles POST /v2/stream/:itemId/restore requests.
+// @Summary Restore an item
+// @Description Returns an archived or trashed priority item to the stream
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.ItemStateResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/restore [post]
+func (h *StreamHandler) Restore(c *fiber.Ctx) error {
+	return h.setItemState(c, model.ItemActive)
+}
+
+// BulkArchive handles POST /v2/stream/archive requests.
+// @Summary Archive items
+// @Description Moves up to 100 priority items into the archive
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param body body model.ItemStateRequest true "Item IDs"
+// @Success 200 {object} model.ItemStateResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/archive [post]
+func (h *StreamHandler) BulkArchive(c *fiber.Ctx) error {
+	return h.setBulkItemState(c, model.ItemArchived)
+}
+
+// BulkTrash handles POST /v2/stream/trash requests.
+// @Summary Trash items
+// @Description Moves up to 100 priority items to the trash
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param body body model.ItemStateRequest true "Item IDs"
+// @Success 200 {object} model.ItemStateResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/trash [post]
+func (h *StreamHandler) BulkTrash(c *fiber.Ctx) error {
+	return h.setBulkItemState(c, model.ItemTrashed)
+}
+
+// BulkRestore handles POST /v2/stream/restore requests.
+// @Summary Restore items
+// @Description Returns up to 100 archived or trashed priority items to the stream
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param body body model.ItemStateRequest true "Item IDs"
+// @Success 200 {object} model.ItemStateResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/restore [post]
+func (h *StreamHandler) BulkRestore(c *fiber.Ctx) error {
+	return h.setBulkItemState(c, model.ItemActive)
+}
+
+// setItemState moves the item in the path to a state.
+func (h *StreamHandler) setItemState(c *fiber.Ctx, state model.ItemState) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	req := model.ItemStateRequest{
+		UserID:  currentUserID(c),
+		ItemIDs: []string{itemID},
+		State:   state,
+	}
+
+	response, err := h.service.SetItemState(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to update item state: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to update item state",
+		))
+	}
+
+	if len(response.ItemIDs) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// setBulkItemState moves the items listed in the request body to a state.
+func (h *StreamHandler) setBulkItemState(c *fiber.Ctx, state model.ItemState) error {
+	var req model.ItemStateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	itemIDs, err := service.ValidateItemIDs(req.ItemIDs)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	req.UserID = currentUserID(c)
+	req.ItemIDs = itemIDs
+	req.State = state
+
+	response, err := h.service.SetItemState(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to update item state: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to update item state",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// SendMessage handles POST /v2/stream/:itemId/messages requests.
+// @Summary Send a reply
+// @Description Appends a message from the authenticated user to a priority item's thread
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param body body model.SendMessageRequest true "Message content"
+// @Success 201 {object} model.Message
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/messages [post]
+func (h *StreamHandler) SendMessage(c *fiber.Ctx) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	var req model.SendMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	content, err := service.ValidateMessageContent(req.Content)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	req.UserID = currentUserID(c)
+	req.ItemID = itemID
+	req.Content = content
+
+	msg, err := h.service.SendMessage(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to send message: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to send message",
+		))
+	}
+
+	if msg == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(msg)
+}
+
+// RescheduleEvent handles PATCH /v2/stream/:itemId/messages/:messageId/event requests.
+// @Summary Reschedule an event
+// @Description Moves the calendar event in a message to new times and appends a system message recording the change
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param messageId path string true "Message ID"
+// @Param body body model.RescheduleEventRequest true "New start and end times"
+// @Success 200 {object} model.EventUpdateResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/messages/{messageId}/event [patch]
+func (h *StreamHandler) RescheduleEvent(c *fiber.Ctx) error {
+	var req model.RescheduleEventRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	if _, _, err := service.ValidateEventTimes(req.StartTime, req.EndTime); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	req.UserID = currentUserID(c)
+	req.ItemID = c.Params("itemId")
+	req.MessageID = c.Params("messageId")
+
+	response, err := h.service.RescheduleEvent(c.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to reschedule event: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to reschedule event",
+		))
+	}
+
+	if response == nil {
+		return eventNotFound(c)
+	}
+
+	return c.JSON(response)
+}
+
+// RespondToEvent handles POST /v2/stream/:itemId/messages/:messageId/event/rsvp requests.
+// @Summary RSVP to an event
+// @Description Records an attendee's response to the calendar event in a message and appends a system message recording it
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param messageId path string true "Message ID"
+// @Param body body model.RSVPRequest true "Attendee and response"
+// @Success 200 {object} model.EventUpdateResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/messages/{messageId}/event/rsvp [post]
+func (h *StreamHandler) RespondToEvent(c *fiber.Ctx) error {
+	var req model.RSVPRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	if err := service.ValidateRSVP(req.Attendee, req.Status); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	req.UserID = currentUserID(c)
+	req.ItemID = c.Params("itemId")
+	req.MessageID = c.Params("messageId")
+
+	response, err := h.service.RespondToEvent(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownAttendee) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+				model.ErrCodeValidationFailed,
+				err.Error(),
+			))
+		}
+		h.log.Error("Failed to record RSVP: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to record RSVP",
+		))
+	}
+
+	if response == nil {
+		return eventNotFound(c)
+	}
+
+	return c.JSON(response)
+}
+
+// eventNotFound responds that the message in the path does not exist or has no event.
+func eventNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+		model.ErrCodeNotFound,
+		"The requested message does not exist or has no event",
+	))
+}
+
+// Snooze handles POST /v2/stream/:itemId/snooze requests.
+// @Summary Snooze an item
+// @Description Hides a priority item from the stream until a time, when it returns as unread at the top
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param body body model.SnoozeRequest true "Wake-up time"
+// @Success 200 {object} model.SnoozeResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/snooze [post]
+func (h *StreamHandler) Snooze(c *fiber.Ctx) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	var req model.SnoozeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	req.UserID = currentUserID(c)
+	req.ItemID = itemID
+
+	response, err := h.service.SnoozeItem(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSnooze) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+				model.ErrCodeValidationFailed,
+				err.Error(),
+			))
+		}
+		h.log.Error("Failed to snooze item: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to snooze item",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// Unsnooze handles DELETE /v2/stream/:itemId/snooze requests.
+// @Summary Unsnooze an item
+// @Description Returns a snoozed priority item to the stream right away
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.SnoozeResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/snooze [delete]
+func (h *StreamHandler) Unsnooze(c *fiber.Ctx) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	response, err := h.service.UnsnoozeItem(c.Context(), currentUserID(c), itemID)
+	if err != nil {
+		h.log.Error("Failed to unsnooze item: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to unsnooze item",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// SetFollowUp handles POST /v2/stream/:itemId/follow-up requests.
+// @Summary Follow up on an item
+// @Description Resurfaces a priority item where the user wrote last, unread at the top of the stream, if nobody replies within some days
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Param body body model.FollowUpRequest true "Days to wait for a reply"
+// @Success 200 {object} model.FollowUpResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/follow-up [post]
+func (h *StreamHandler) SetFollowUp(c *fiber.Ctx) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	var req model.FollowUpRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	if err := service.ValidateFollowUpDays(req.Days); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			err.Error(),
+		))
+	}
+
+	req.UserID = currentUserID(c)
+	req.ItemID = itemID
+
+	response, err := h.service.SetFollowUp(c.Context(), req)
+	if errors.Is(err, service.ErrNotAwaitingReply) {
+		return c.Status(fiber.StatusConflict).JSON(model.NewErrorResponse(
+			model.ErrCodeConflict,
+			"The item is not awaiting a reply: the user did not write last",
+		))
+	}
+	if err != nil {
+		h.log.Error("Failed to set follow-up: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to set follow-up",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// ClearFollowUp handles DELETE /v2/stream/:itemId/follow-up requests.
+// @Summary Cancel a follow-up
+// @Description Cancels a priority item's follow-up, so it does not resurface
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.FollowUpResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/follow-up [delete]
+func (h *StreamHandler) ClearFollowUp(c *fiber.Ctx) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	response, err := h.service.ClearFollowUp(c.Context(), currentUserID(c), itemID)
+	if err != nil {
+		h.log.Error("Failed to clear follow-up: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to clear follow-up",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// Pin handles POST /v2/stream/:itemId/pin requests.
+// @Summary Pin an item
+// @Description Pins a priority item after the user's other pinned items. Pinned items are listed above the stream.
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.PinsResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/pin [post]
+func (h *StreamHandler) Pin(c *fiber.Ctx) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	response, err := h.service.PinItem(c.Context(), currentUserID(c), itemID)
+	if err != nil {
+		if errors.Is(err, service.ErrTooManyPins) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+				model.ErrCodeValidationFailed,
+				err.Error(),
+			))
+		}
+		h.log.Error("Failed to pin item: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to pin item",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// Unpin handles DELETE /v2/stream/:itemId/pin requests.
+// @Summary Unpin an item
+// @Description Returns a pinned priority item to its place in the stream
+// @Tags stream
+// @Produce json
+// @Param itemId path string true "Priority item ID"
+// @Success 200 {object} model.PinsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/{itemId}/pin [delete]
+func (h *StreamHandler) Unpin(c *fiber.Ctx) error {
+	itemID := c.Params("itemId")
+	if itemID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeValidationFailed,
+			"Item ID is required",
+		))
+	}
+
+	response, err := h.service.UnpinItem(c.Context(), currentUserID(c), itemID)
+	if err != nil {
+		h.log.Error("Failed to unpin item: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to unpin item",
+		))
+	}
+
+	if response == nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.NewErrorResponse(
+			model.ErrCodeNotFound,
+			"The requested priority item does not exist",
+		))
+	}
+
+	return c.JSON(response)
+}
+
+// ReorderPins handles PATCH /v2/stream/pins requests.
+// @Summary Reorder pinned items
+// @Description Sets the order of the user's pinned items. The body must list every pinned item exactly once.
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param body body model.PinOrderRequest true "Pinned item IDs in the new order"
+// @Success 200 {object} model.PinsResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v2/stream/pins [patch]
+func (h *StreamHandler) ReorderPins(c *fiber.Ctx) error {
+	var req model.PinOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+			model.ErrCodeBadRequest,
+			"Invalid request body",
+		))
+	}
+
+	response, err := h.service.ReorderPins(c.Context(), currentUserID(c), req.ItemIDs)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPinOrder) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.NewErrorResponse(
+				model.ErrCodeValidationFailed,
+				err.Error(),
+			))
+		}
+		h.log.Error("Failed to reorder pinned items: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(model.NewErrorResponse(
+			model.ErrCodeInternalError,
+			"Failed to reorder pinned items",
+		))
+	}
+
+	return c.JSON(response)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 
 	"github.com/mabidoli/gravity-bff/internal/config"
 	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
 	"github.com/mabidoli/gravity-bff/internal/service"
 	"github.com/mabidoli/gravity-bff/pkg/logger"
 )
@@ -32,6 +35,15 @@ func (m *MockStreamRepository) GetStream(ctx context.Context, req model.StreamRe
 	return items.([]model.PriorityItem), args.Get(1).(*string), args.Error(2)
 }
 
+func (m *MockStreamRepository) SearchStream(ctx context.Context, req model.SearchRequest) ([]model.SearchResult, *string, error) {
+	args := m.Called(ctx, req)
+	results := args.Get(0)
+	if results == nil {
+		return nil, args.Get(1).(*string), args.Error(2)
+	}
+	return results.([]model.SearchResult), args.Get(1).(*string), args.Error(2)
+}
+
 func (m *MockStreamRepository) GetStreamItemByID(ctx context.Context, userID, itemID string) (*model.PriorityItem, error) {
 	args := m.Called(ctx, userID, itemID)
 	item := args.Get(0)
@@ -46,9 +58,122 @@ func (m *MockStreamRepository) GetParticipantsByItemID(ctx context.Context, item
 	return args.Get(0).([]model.User), args.Error(1)
 }
 
-func (m *MockStreamRepository) GetMessagesByItemID(ctx context.Context, itemID string) ([]model.Message, error) {
-	args := m.Called(ctx, itemID)
-	return args.Get(0).([]model.Message), args.Error(1)
+func (m *MockStreamRepository) GetParticipantsByItemIDs(ctx context.Context, itemIDs []string) (map[string][]model.User, error) {
+	args := m.Called(ctx, itemIDs)
+	return args.Get(0).(map[string][]model.User), args.Error(1)
+}
+
+func (m *MockStreamRepository) GetMessages(ctx context.Context, req model.MessagesRequest) ([]model.Message, *string, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]model.Message), args.Get(1).(*string), args.Error(2)
+}
+
+func (m *MockStreamRepository) SetReadState(ctx context.Context, userID string, itemIDs []string, unread bool) ([]model.CountChange, error) {
+	args := m.Called(ctx, userID, itemIDs, unread)
+	changes := args.Get(0)
+	if changes == nil {
+		return nil, args.Error(1)
+	}
+	return changes.([]model.CountChange), args.Error(1)
+}
+
+func (m *MockStreamRepository) GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CountsResponse), args.Error(1)
+}
+
+func (m *MockStreamRepository) GetChanges(ctx context.Context, userID string, since int64, limit int) ([]model.ItemChange, error) {
+	args := m.Called(ctx, userID, since, limit)
+	return args.Get(0).([]model.ItemChange), args.Error(1)
+}
+
+func (m *MockStreamRepository) CreateMessage(ctx context.Context, userID, itemID string, msg model.Message, snippet string) (*model.Message, error) {
+	args := m.Called(ctx, userID, itemID, msg, snippet)
+	stored := args.Get(0)
+	if stored == nil {
+		return nil, args.Error(1)
+	}
+	return stored.(*model.Message), args.Error(1)
+}
+
+func (m *MockStreamRepository) UpdateEvent(ctx context.Context, userID, itemID, messageID string, change repository.EventChange) (*model.EventUpdateResponse, error) {
+	args := m.Called(ctx, userID, itemID, messageID, change)
+	event, ok := args.Get(0).(*model.CalendarEvent)
+	if !ok || event == nil {
+		return nil, args.Error(1)
+	}
+	// Apply the change to a copy of the stubbed event, as the repository does
+	updated := *event
+	note, _, err := change(&updated)
+	if err != nil {
+		return nil, err
+	}
+	note.ID = "msg-note"
+	return &model.EventUpdateResponse{Event: updated, Message: note}, nil
+}
+
+func (m *MockStreamRepository) SetSnooze(ctx context.Context, userID, itemID string, until *time.Time) (bool, error) {
+	args := m.Called(ctx, userID, itemID, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStreamRepository) WakeSnoozed(ctx context.Context, now time.Time, limit int) ([]model.ItemRef, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]model.ItemRef), args.Error(1)
+}
+
+func (m *MockStreamRepository) SetFollowUp(ctx context.Context, userID, itemID string, at *time.Time) (*model.FollowUpResponse, error) {
+	args := m.Called(ctx, userID, itemID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.FollowUpResponse), args.Error(1)
+}
+
+func (m *MockStreamRepository) ResurfaceFollowUps(ctx context.Context, now time.Time, limit int) ([]model.ItemRef, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]model.ItemRef), args.Error(1)
+}
+
+func (m *MockStreamRepository) SetItemState(ctx context.Context, userID string, itemIDs []string, state model.ItemState) ([]string, error) {
+	args := m.Called(ctx, userID, itemIDs, state)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStreamRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) ([]model.ItemRef, error) {
+	args := m.Called(ctx, deletedBefore, limit)
+	return args.Get(0).([]model.ItemRef), args.Error(1)
+}
+
+func (m *MockStreamRepository) GetPinnedItems(ctx context.Context, userID string, filter model.StreamFilter) ([]model.PriorityItem, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).([]model.PriorityItem), args.Error(1)
+}
+
+func (m *MockStreamRepository) ListPinnedItemIDs(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStreamRepository) PinItem(ctx context.Context, userID, itemID string) (bool, error) {
+	args := m.Called(ctx, userID, itemID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStreamRepository) UnpinItem(ctx context.Context, userID, itemID string) (bool, error) {
+	args := m.Called(ctx, userID, itemID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStreamRepository) SetPinOrder(ctx context.Context, userID string, itemIDs []string) error {
+	args := m.Called(ctx, userID, itemIDs)
+	return args.Error(0)
 }
 
 // MockCache for testing
@@ -89,6 +214,52 @@ func (m *MockCache) Delete(ctx context.Context, keys ...string) error {
 	return args.Error(0)
 }
 
+func (m *MockCache) InvalidateUserCache(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockCache) PinVersion(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCache) BumpPinVersion(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockCache) GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CountsResponse), args.Error(1)
+}
+
+func (m *MockCache) SetCounts(ctx context.Context, userID string, counts *model.CountsResponse, ttl time.Duration) error {
+	args := m.Called(ctx, userID, counts, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCache) GetBriefing(ctx context.Context, key string) (*model.BriefingResponse, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BriefingResponse), args.Error(1)
+}
+
+func (m *MockCache) SetBriefing(ctx context.Context, key string, briefing *model.BriefingResponse, ttl time.Duration) error {
+	args := m.Called(ctx, key, briefing, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCache) AdjustCounts(ctx context.Context, userID string, delta *model.CountsResponse) error {
+	args := m.Called(ctx, userID, delta)
+	return args.Error(0)
+}
+
 func (m *MockCache) Ping(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -105,7 +276,27 @@ func setupTestApp(handler *StreamHandler) *fiber.App {
 	})
 
 	app.Get("/v2/stream", handler.GetStream)
+	app.Get("/v2/stream/search", handler.SearchStream)
+	app.Get("/v2/stream/counts", handler.GetCounts)
+	app.Post("/v2/stream/read", handler.BulkMarkRead)
+	app.Post("/v2/stream/unread", handler.BulkMarkUnread)
+	app.Post("/v2/stream/trash", handler.BulkTrash)
 	app.Get("/v2/stream/:itemId", handler.GetStreamItem)
+	app.Post("/v2/stream/:itemId/read", handler.MarkRead)
+	app.Post("/v2/stream/:itemId/unread", handler.MarkUnread)
+	app.Post("/v2/stream/:itemId/archive", handler.Archive)
+	app.Post("/v2/stream/:itemId/restore", handler.Restore)
+	app.Get("/v2/stream/:itemId/messages", handler.GetMessages)
+	app.Post("/v2/stream/:itemId/messages", handler.SendMessage)
+	app.Patch("/v2/stream/:itemId/messages/:messageId/event", handler.RescheduleEvent)
+	app.Post("/v2/stream/:itemId/messages/:messageId/event/rsvp", handler.RespondToEvent)
+	app.Post("/v2/stream/:itemId/snooze", handler.Snooze)
+	app.Delete("/v2/stream/:itemId/snooze", handler.Unsnooze)
+	app.Post("/v2/stream/:itemId/follow-up", handler.SetFollowUp)
+	app.Delete("/v2/stream/:itemId/follow-up", handler.ClearFollowUp)
+	app.Post("/v2/stream/:itemId/pin", handler.Pin)
+	app.Patch("/v2/stream/pins", handler.ReorderPins)
+	app.Get("/v2/sync", handler.Sync)
 
 	return app
 }
@@ -127,7 +318,7 @@ func TestStreamHandler_GetStream_Success(t *testing.T) {
 	log := logger.New()
 	cfg := newTestConfig()
 
-	svc := service.NewStreamService(mockRepo, mockCache, cfg, log)
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
 	handler := NewStreamHandler(svc, log)
 	app := setupTestApp(handler)
 
@@ -142,8 +333,10 @@ func TestStreamHandler_GetStream_Success(t *testing.T) {
 	}
 
 	// Cache miss, repo returns data
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
 	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
 	mockRepo.On("GetStream", mock.Anything, mock.Anything).Return(expectedItems, (*string)(nil), nil)
+	mockRepo.On("GetPinnedItems", mock.Anything, mock.Anything, mock.Anything).Return([]model.PriorityItem{}, nil)
 	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
@@ -170,14 +363,16 @@ func TestStreamHandler_GetStream_WithFilter(t *testing.T) {
 	log := logger.New()
 	cfg := newTestConfig()
 
-	svc := service.NewStreamService(mockRepo, mockCache, cfg, log)
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
 	handler := NewStreamHandler(svc, log)
 	app := setupTestApp(handler)
 
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
 	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
 	mockRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(req model.StreamRequest) bool {
-		return req.Filter == model.FilterHigh
+		return len(req.Filter.Priorities) == 1 && req.Filter.Priorities[0] == model.PriorityHigh
 	})).Return([]model.PriorityItem{}, (*string)(nil), nil)
+	mockRepo.On("GetPinnedItems", mock.Anything, mock.Anything, mock.Anything).Return([]model.PriorityItem{}, nil)
 	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
@@ -190,6 +385,39 @@ func TestStreamHandler_GetStream_WithFilter(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestStreamHandler_GetStream_ComposedFilter(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(req model.StreamRequest) bool {
+		f := req.Filter
+		return len(f.Sources) == 1 && f.Sources[0] == model.SourceSlack &&
+			len(f.Priorities) == 2 &&
+			f.Unread != nil && *f.Unread &&
+			f.Since != nil && f.Until == nil
+	})).Return([]model.PriorityItem{}, (*string)(nil), nil)
+	mockRepo.On("GetPinnedItems", mock.Anything, mock.Anything, mock.Anything).Return([]model.PriorityItem{}, nil)
+	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream?source=slack&priority=high,medium&unread=true&since=2026-01-05", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestStreamHandler_GetStream_InvalidFilter(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockStreamRepository)
@@ -197,7 +425,7 @@ func TestStreamHandler_GetStream_InvalidFilter(t *testing.T) {
 	log := logger.New()
 	cfg := newTestConfig()
 
-	svc := service.NewStreamService(mockRepo, mockCache, cfg, log)
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
 	handler := NewStreamHandler(svc, log)
 	app := setupTestApp(handler)
 
@@ -216,6 +444,59 @@ func TestStreamHandler_GetStream_InvalidFilter(t *testing.T) {
 	assert.Equal(t, model.ErrCodeValidationFailed, result.Error.Code)
 }
 
+func TestStreamHandler_GetStream_SortByScore(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockCache.On("GetStream", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasSuffix(key, ":score:p0:none")
+	})).Return(nil, nil)
+	mockRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(req model.StreamRequest) bool {
+		return req.Sort == model.SortScore
+	})).Return([]model.PriorityItem{}, (*string)(nil), nil)
+	mockRepo.On("GetPinnedItems", mock.Anything, mock.Anything, mock.Anything).Return([]model.PriorityItem{}, nil)
+	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream?sort=score", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamHandler_GetStream_InvalidSort(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream?sort=oldest", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockRepo.AssertNotCalled(t, "GetStream", mock.Anything, mock.Anything)
+}
+
 func TestStreamHandler_GetStream_WithPagination(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockStreamRepository)
@@ -223,10 +504,11 @@ func TestStreamHandler_GetStream_WithPagination(t *testing.T) {
 	log := logger.New()
 	cfg := newTestConfig()
 
-	svc := service.NewStreamService(mockRepo, mockCache, cfg, log)
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
 	handler := NewStreamHandler(svc, log)
 	app := setupTestApp(handler)
 
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
 	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
 	mockRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(req model.StreamRequest) bool {
 		return req.Limit == 50 && req.Cursor != nil && *req.Cursor == "abc123"
@@ -251,7 +533,7 @@ func TestStreamHandler_GetStreamItem_Success(t *testing.T) {
 	log := logger.New()
 	cfg := newTestConfig()
 
-	svc := service.NewStreamService(mockRepo, mockCache, cfg, log)
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
 	handler := NewStreamHandler(svc, log)
 	app := setupTestApp(handler)
 
@@ -292,7 +574,7 @@ func TestStreamHandler_GetStreamItem_NotFound(t *testing.T) {
 	log := logger.New()
 	cfg := newTestConfig()
 
-	svc := service.NewStreamService(mockRepo, mockCache, cfg, log)
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
 	handler := NewStreamHandler(svc, log)
 	app := setupTestApp(handler)
 
@@ -313,3 +595,914 @@ func TestStreamHandler_GetStreamItem_NotFound(t *testing.T) {
 
 	assert.Equal(t, model.ErrCodeNotFound, result.Error.Code)
 }
+
+func TestStreamHandler_GetMessages_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	log := logger.New()
+
+	svc := service.NewStreamService(mockRepo, new(MockCache), events.NopPublisher{}, newTestConfig(), log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	before := "cursor-1"
+	nextCursor := "cursor-2"
+	messages := []model.Message{{ID: "msg-1"}, {ID: "msg-2"}}
+	mockRepo.On("GetMessages", mock.Anything, model.MessagesRequest{
+		UserID: "test-user",
+		ItemID: "item-123",
+		Before: &before,
+		Limit:  2,
+	}).Return(messages, &nextCursor, nil)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream/item-123/messages?before=cursor-1&limit=2", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result model.MessagesResponse
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, "cursor-2", *result.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamHandler_GetMessages_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	log := logger.New()
+
+	svc := service.NewStreamService(mockRepo, new(MockCache), events.NopPublisher{}, newTestConfig(), log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("GetMessages", mock.Anything, mock.Anything).Return(nil, nil, nil)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream/nonexistent/messages", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+// Tests for read state handlers
+func TestStreamHandler_MarkRead_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("SetReadState", mock.Anything, "test-user", []string{"item-123"}, false).
+		Return([]model.CountChange{{ItemID: "item-123"}}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "test-user", mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-123"}).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/item-123/read", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.ReadStateResponse
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, []string{"item-123"}, result.ItemIDs)
+	assert.False(t, result.Unread)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamHandler_MarkUnread_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("SetReadState", mock.Anything, "test-user", []string{"nonexistent"}, true).
+		Return([]model.CountChange{}, nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/nonexistent/unread", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestStreamHandler_BulkMarkUnread_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("SetReadState", mock.Anything, "test-user", []string{"item-1", "item-2"}, true).
+		Return([]model.CountChange{{ItemID: "item-1"}, {ItemID: "item-2"}}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "test-user", mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1", "item:item-2"}).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/unread", strings.NewReader(`{"itemIds":["item-1","item-2","item-1"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.ReadStateResponse
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, []string{"item-1", "item-2"}, result.ItemIDs)
+	assert.True(t, result.Unread)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamHandler_BulkMarkRead_EmptyBody(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/read", strings.NewReader(`{"itemIds":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.ErrorResponse
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, model.ErrCodeValidationFailed, result.Error.Code)
+	mockRepo.AssertNotCalled(t, "SetReadState")
+}
+
+// Tests for SendMessage handler
+func TestStreamHandler_SendMessage_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	stored := &model.Message{
+		ID:          "msg-1",
+		SenderType:  model.SenderUser,
+		Content:     "On it!",
+		ContentType: model.ContentText,
+	}
+
+	mockRepo.On("CreateMessage", mock.Anything, "test-user", "item-123", mock.MatchedBy(func(msg model.Message) bool {
+		return msg.Content == "On it!" && msg.SenderType == model.SenderUser
+	}), "On it!").Return(stored, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-123"}).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/item-123/messages", strings.NewReader(`{"content":"  On it!  "}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.Message
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, "msg-1", result.ID)
+	assert.Equal(t, model.SenderUser, result.SenderType)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamHandler_SendMessage_EmptyContent(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/item-123/messages", strings.NewReader(`{"content":"   "}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockRepo.AssertNotCalled(t, "CreateMessage")
+}
+
+func TestStreamHandler_SendMessage_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("CreateMessage", mock.Anything, "test-user", "nonexistent", mock.Anything, mock.Anything).
+		Return(nil, nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/nonexistent/messages", strings.NewReader(`{"content":"Hello"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+// Tests for event handlers
+func TestStreamHandler_RescheduleEvent_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	event := &model.CalendarEvent{
+		ID:        "evt-1",
+		Title:     "Design review",
+		StartTime: time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 3, 2, 16, 0, 0, 0, time.UTC),
+	}
+
+	mockRepo.On("UpdateEvent", mock.Anything, "test-user", "item-123", "msg-1", mock.Anything).Return(event, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-123"}).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("PATCH", "/v2/stream/item-123/messages/msg-1/event",
+		strings.NewReader(`{"startTime":"2026-03-03T10:00:00Z","endTime":"2026-03-03T11:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.EventUpdateResponse
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC), result.Event.StartTime)
+	assert.Equal(t, model.SenderSystem, result.Message.SenderType)
+	assert.Contains(t, result.Message.Content, "Rescheduled")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamHandler_RescheduleEvent_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		found          bool
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid body", `{"startTime":`, false, fiber.StatusBadRequest, model.ErrCodeBadRequest},
+		{"missing end", `{"startTime":"2026-03-03T10:00:00Z"}`, false, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"end before start", `{"startTime":"2026-03-03T10:00:00Z","endTime":"2026-03-03T09:00:00Z"}`, false, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"not found", `{"startTime":"2026-03-03T10:00:00Z","endTime":"2026-03-03T11:00:00Z"}`, true, fiber.StatusNotFound, model.ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockStreamRepository)
+			mockCache := new(MockCache)
+			log := logger.New()
+
+			svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, newTestConfig(), log)
+			handler := NewStreamHandler(svc, log)
+			app := setupTestApp(handler)
+
+			if tt.found {
+				mockRepo.On("UpdateEvent", mock.Anything, "test-user", "item-123", "msg-1", mock.Anything).Return(nil, nil)
+			}
+
+			// Act
+			req := httptest.NewRequest("PATCH", "/v2/stream/item-123/messages/msg-1/event", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var errResp model.ErrorResponse
+			json.Unmarshal(body, &errResp)
+			assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+		})
+	}
+}
+
+func TestStreamHandler_RespondToEvent(t *testing.T) {
+	email := "ana@example.com"
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"accepted", `{"attendee":"ana@example.com","status":"accepted"}`, fiber.StatusOK, ""},
+		{"invalid status", `{"attendee":"ana@example.com","status":"maybe"}`, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"missing attendee", `{"status":"declined"}`, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"unknown attendee", `{"attendee":"eve@example.com","status":"declined"}`, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockStreamRepository)
+			mockCache := new(MockCache)
+			log := logger.New()
+
+			svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, newTestConfig(), log)
+			handler := NewStreamHandler(svc, log)
+			app := setupTestApp(handler)
+
+			event := &model.CalendarEvent{ID: "evt-1", Title: "Design review", Attendees: []model.User{{Name: "Ana Lima", Email: &email}}}
+			mockRepo.On("UpdateEvent", mock.Anything, "test-user", "item-123", "msg-1", mock.Anything).Return(event, nil)
+			mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+			mockCache.On("Delete", mock.Anything, []string{"item:item-123"}).Return(nil)
+
+			// Act
+			req := httptest.NewRequest("POST", "/v2/stream/item-123/messages/msg-1/event/rsvp", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			if tt.expectedCode == "" {
+				var result model.EventUpdateResponse
+				json.Unmarshal(body, &result)
+				assert.Equal(t, model.RSVPAccepted, result.Event.RSVPs[0].Status)
+				assert.Equal(t, `Ana Lima accepted "Design review".`, result.Message.Content)
+				return
+			}
+			var errResp model.ErrorResponse
+			json.Unmarshal(body, &errResp)
+			assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+		})
+	}
+}
+
+// Tests for SearchStream handler
+func TestStreamHandler_SearchStream_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	highlight := "Quarterly <mark>budget</mark> review"
+	results := []model.SearchResult{
+		{
+			PriorityItem: model.PriorityItem{ID: "item-1", Title: "Quarterly budget review"},
+			Rank:         0.6,
+			Highlights:   model.SearchHighlights{Title: &highlight},
+		},
+	}
+	nextCursor := "next"
+
+	mockRepo.On("SearchStream", mock.Anything, mock.MatchedBy(func(req model.SearchRequest) bool {
+		return req.UserID == "test-user" && req.Query == "budget" && req.Limit == 10
+	})).Return(results, &nextCursor, nil)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream/search?q=%20budget%20&limit=10", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.SearchResponse
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, 1, len(result.Data))
+	assert.Equal(t, "item-1", result.Data[0].ID)
+	assert.Equal(t, highlight, *result.Data[0].Highlights.Title)
+	assert.Equal(t, "next", *result.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamHandler_SearchStream_MissingQuery(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream/search", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockRepo.AssertNotCalled(t, "SearchStream")
+}
+
+func TestStreamHandler_GetCounts_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	counts := &model.CountsResponse{
+		StreamCounts: model.StreamCounts{Total: 3, Unread: 2, High: 1},
+		Sources:      map[model.SourceType]model.StreamCounts{model.SourceEmail: {Total: 3, Unread: 2, High: 1}},
+		Labels:       map[string]model.StreamCounts{},
+	}
+	mockCache.On("GetCounts", mock.Anything, "test-user").Return(counts, nil)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/stream/counts", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.JSONEq(t, `{
+		"total": 3, "unread": 2, "high": 1,
+		"sources": {"email": {"total": 3, "unread": 2, "high": 1}},
+		"labels": {}
+	}`, string(body))
+	mockRepo.AssertNotCalled(t, "GetCounts", mock.Anything, mock.Anything)
+}
+
+func TestStreamHandler_Sync_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	log := logger.New()
+
+	svc := service.NewStreamService(mockRepo, new(MockCache), events.NopPublisher{}, newTestConfig(), log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	changes := []model.ItemChange{
+		{Seq: 3, ItemID: "item-1", Created: true, Item: &model.PriorityItem{ID: "item-1", Title: "New"}},
+		{Seq: 4, ItemID: "item-2"},
+	}
+	mockRepo.On("GetChanges", mock.Anything, "test-user", int64(0), 51).Return(changes, nil)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/sync?limit=50", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result model.SyncResponse
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Len(t, result.Created, 1)
+	assert.Empty(t, result.Updated)
+	assert.Equal(t, []string{"item-2"}, result.Deleted)
+	assert.NotEmpty(t, result.Token)
+	assert.False(t, result.HasMore)
+}
+
+func TestStreamHandler_Sync_InvalidToken(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	log := logger.New()
+
+	svc := service.NewStreamService(mockRepo, new(MockCache), events.NopPublisher{}, newTestConfig(), log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	// Act
+	req := httptest.NewRequest("GET", "/v2/sync?since=bogus!", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var result model.ErrorResponse
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, model.ErrCodeValidationFailed, result.Error.Code)
+}
+
+func TestStreamHandler_Snooze_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	until := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	mockRepo.On("SetSnooze", mock.Anything, "test-user", "item-123", &until).Return(true, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-123"}).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"counts:test-user"}).Return(nil)
+
+	// Act
+	body := `{"until":"` + until.Format(time.RFC3339) + `"}`
+	req := httptest.NewRequest("POST", "/v2/stream/item-123/snooze", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := io.ReadAll(resp.Body)
+	var result model.SnoozeResponse
+	json.Unmarshal(respBody, &result)
+
+	assert.Equal(t, "item-123", result.ItemID)
+	assert.True(t, until.Equal(*result.SnoozedUntil))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamHandler_Snooze_PastTime(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/item-123/snooze", strings.NewReader(`{"until":"2020-01-01T09:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockRepo.AssertNotCalled(t, "SetSnooze")
+}
+
+func TestStreamHandler_Unsnooze_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("SetSnooze", mock.Anything, "test-user", "nonexistent", (*time.Time)(nil)).Return(false, nil)
+
+	// Act
+	req := httptest.NewRequest("DELETE", "/v2/stream/nonexistent/snooze", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestStreamHandler_SetFollowUp_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	at := time.Now().AddDate(0, 0, 3).UTC()
+	mockRepo.On("SetFollowUp", mock.Anything, "test-user", "item-123", mock.AnythingOfType("*time.Time")).
+		Return(&model.FollowUpResponse{ItemID: "item-123", FollowUpAt: &at, AwaitingReply: true}, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-123"}).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/item-123/follow-up", strings.NewReader(`{"days":3}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := io.ReadAll(resp.Body)
+	var result model.FollowUpResponse
+	json.Unmarshal(respBody, &result)
+
+	assert.Equal(t, "item-123", result.ItemID)
+	assert.True(t, at.Equal(*result.FollowUpAt))
+	assert.True(t, result.AwaitingReply)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamHandler_SetFollowUp_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		stored         *model.FollowUpResponse
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid body", `{"days":`, nil, fiber.StatusBadRequest, model.ErrCodeBadRequest},
+		{"no days", `{}`, nil, fiber.StatusBadRequest, model.ErrCodeValidationFailed},
+		{"not awaiting reply", `{"days":3}`, &model.FollowUpResponse{ItemID: "item-123"}, fiber.StatusConflict, model.ErrCodeConflict},
+		{"not found", `{"days":3}`, nil, fiber.StatusNotFound, model.ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockStreamRepository)
+			log := logger.New()
+			svc := service.NewStreamService(mockRepo, new(MockCache), events.NopPublisher{}, newTestConfig(), log)
+			app := setupTestApp(NewStreamHandler(svc, log))
+
+			mockRepo.On("SetFollowUp", mock.Anything, "test-user", "item-123", mock.Anything).Return(tt.stored, nil)
+
+			// Act
+			req := httptest.NewRequest("POST", "/v2/stream/item-123/follow-up", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			var errResp model.ErrorResponse
+			assert.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+		})
+	}
+}
+
+func TestStreamHandler_ClearFollowUp_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("SetFollowUp", mock.Anything, "test-user", "nonexistent", (*time.Time)(nil)).Return(nil, nil)
+
+	// Act
+	req := httptest.NewRequest("DELETE", "/v2/stream/nonexistent/follow-up", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestStreamHandler_Archive_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("SetItemState", mock.Anything, "test-user", []string{"item-123"}, model.ItemArchived).
+		Return([]string{"item-123"}, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-123"}).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"counts:test-user"}).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/item-123/archive", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.ItemStateResponse
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, []string{"item-123"}, result.ItemIDs)
+	assert.Equal(t, model.ItemArchived, result.State)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamHandler_Restore_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("SetItemState", mock.Anything, "test-user", []string{"nonexistent"}, model.ItemActive).
+		Return([]string{}, nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/nonexistent/restore", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestStreamHandler_BulkTrash_EmptyBody(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/trash", strings.NewReader(`{"itemIds":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockRepo.AssertNotCalled(t, "SetItemState")
+}
+
+func TestStreamHandler_Pin_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("ListPinnedItemIDs", mock.Anything, "test-user").Return([]string{}, nil).Once()
+	mockRepo.On("PinItem", mock.Anything, "test-user", "item-123").Return(true, nil)
+	mockRepo.On("ListPinnedItemIDs", mock.Anything, "test-user").Return([]string{"item-123"}, nil).Once()
+	mockCache.On("BumpPinVersion", mock.Anything, "test-user").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-123"}).Return(nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/item-123/pin", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	respBody, _ := io.ReadAll(resp.Body)
+	var result model.PinsResponse
+	json.Unmarshal(respBody, &result)
+
+	assert.Equal(t, []string{"item-123"}, result.ItemIDs)
+}
+
+func TestStreamHandler_Pin_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("ListPinnedItemIDs", mock.Anything, "test-user").Return([]string{}, nil)
+	mockRepo.On("PinItem", mock.Anything, "test-user", "item-999").Return(false, nil)
+
+	// Act
+	req := httptest.NewRequest("POST", "/v2/stream/item-999/pin", nil)
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestStreamHandler_ReorderPins_Invalid(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	log := logger.New()
+	cfg := newTestConfig()
+
+	svc := service.NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, log)
+	handler := NewStreamHandler(svc, log)
+	app := setupTestApp(handler)
+
+	mockRepo.On("ListPinnedItemIDs", mock.Anything, "test-user").Return([]string{"item-1", "item-2"}, nil)
+
+	// Act
+	req := httptest.NewRequest("PATCH", "/v2/stream/pins", strings.NewReader(`{"itemIds":["item-2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	respBody, _ := io.ReadAll(resp.Body)
+	var result model.ErrorResponse
+	json.Unmarshal(respBody, &result)
+
+	assert.Equal(t, model.ErrCodeValidationFailed, result.Error.Code)
+	mockRepo.AssertNotCalled(t, "SetPinOrder", mock.Anything, mock.Anything, mock.Anything)
+}
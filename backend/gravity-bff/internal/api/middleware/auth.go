@@ -102,6 +102,21 @@ func ClerkAuth() fiber.Handler {
 	}
 }
 
+// QueryTokenAuth is ClerkAuth for browser EventSource clients, which cannot set
+// headers: without an Authorization header, the short-lived Clerk session token
+// is read from the token query parameter instead.
+func QueryTokenAuth() fiber.Handler {
+	auth := ClerkAuth()
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			if token := c.Query("token"); token != "" {
+				c.Request().Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		return auth(c)
+	}
+}
+
 // Auth is an alias for ClerkAuth for backward compatibility.
 // Deprecated: Use ClerkAuth() instead.
 func Auth() fiber.Handler {
@@ -12,21 +12,45 @@ import (
 
 // Router holds all the handlers and configures routes.
 type Router struct {
-	healthHandler *handler.HealthHandler
-	streamHandler *handler.StreamHandler
-	log           *logger.Logger
+	healthHandler   *handler.HealthHandler
+	streamHandler   *handler.StreamHandler
+	eventsHandler   *handler.EventsHandler
+	ingestHandler   *handler.IngestHandler
+	calendarHandler *handler.CalendarHandler
+	ruleHandler     *handler.RuleHandler
+	labelHandler    *handler.LabelHandler
+	insightHandler  *handler.InsightHandler
+	briefingHandler *handler.BriefingHandler
+	peopleHandler   *handler.PeopleHandler
+	log             *logger.Logger
 }
 
 // NewRouter creates a new router with the given handlers.
 func NewRouter(
 	healthHandler *handler.HealthHandler,
 	streamHandler *handler.StreamHandler,
+	eventsHandler *handler.EventsHandler,
+	ingestHandler *handler.IngestHandler,
+	calendarHandler *handler.CalendarHandler,
+	ruleHandler *handler.RuleHandler,
+	labelHandler *handler.LabelHandler,
+	insightHandler *handler.InsightHandler,
+	briefingHandler *handler.BriefingHandler,
+	peopleHandler *handler.PeopleHandler,
 	log *logger.Logger,
 ) *Router {
 	return &Router{
-		healthHandler: healthHandler,
-		streamHandler: streamHandler,
-		log:           log,
+		healthHandler:   healthHandler,
+		streamHandler:   streamHandler,
+		eventsHandler:   eventsHandler,
+		ingestHandler:   ingestHandler,
+		calendarHandler: calendarHandler,
+		ruleHandler:     ruleHandler,
+		labelHandler:    labelHandler,
+		insightHandler:  insightHandler,
+		briefingHandler: briefingHandler,
+		peopleHandler:   peopleHandler,
+		log:             log,
 	}
 }
 
@@ -44,8 +68,85 @@ func (r *Router) Setup(app *fiber.App) {
 	// API v2 routes
 	v2 := app.Group("/v2")
 
+	// Live updates, registered ahead of the stream group so that browser
+	// EventSource clients can authenticate with a query token
+	v2.Get("/stream/events", middleware.QueryTokenAuth(), r.eventsHandler.StreamEvents)
+
 	// Stream routes (auth required)
 	stream := v2.Group("/stream", middleware.Auth())
 	stream.Get("/", r.streamHandler.GetStream)
+	stream.Get("/search", r.streamHandler.SearchStream)
+	stream.Get("/counts", r.streamHandler.GetCounts)
+	stream.Post("/read", r.streamHandler.BulkMarkRead)
+	stream.Post("/unread", r.streamHandler.BulkMarkUnread)
+	stream.Post("/archive", r.streamHandler.BulkArchive)
+	stream.Post("/trash", r.streamHandler.BulkTrash)
+	stream.Post("/restore", r.streamHandler.BulkRestore)
+	stream.Patch("/pins", r.streamHandler.ReorderPins)
 	stream.Get("/:itemId", r.streamHandler.GetStreamItem)
+	stream.Post("/:itemId/read", r.streamHandler.MarkRead)
+	stream.Post("/:itemId/unread", r.streamHandler.MarkUnread)
+	stream.Post("/:itemId/archive", r.streamHandler.Archive)
+	stream.Post("/:itemId/trash", r.streamHandler.Trash)
+	stream.Post("/:itemId/restore", r.streamHandler.Restore)
+	stream.Get("/:itemId/messages", r.streamHandler.GetMessages)
+	stream.Post("/:itemId/messages", r.streamHandler.SendMessage)
+	stream.Patch("/:itemId/messages/:messageId/event", r.streamHandler.RescheduleEvent)
+	stream.Post("/:itemId/messages/:messageId/event/rsvp", r.streamHandler.RespondToEvent)
+	stream.Post("/:itemId/snooze", r.streamHandler.Snooze)
+	stream.Delete("/:itemId/snooze", r.streamHandler.Unsnooze)
+	stream.Post("/:itemId/follow-up", r.streamHandler.SetFollowUp)
+	stream.Delete("/:itemId/follow-up", r.streamHandler.ClearFollowUp)
+	stream.Post("/:itemId/pin", r.streamHandler.Pin)
+	stream.Delete("/:itemId/pin", r.streamHandler.Unpin)
+	stream.Post("/:itemId/labels", r.labelHandler.AddItemLabels)
+	stream.Delete("/:itemId/labels/:labelId", r.labelHandler.RemoveItemLabel)
+	stream.Post("/:itemId/insights/regenerate", r.insightHandler.Regenerate)
+	stream.Post("/:itemId/messages/:messageId/insights/:insightId/refine", r.insightHandler.RefineDraft)
+	stream.Post("/:itemId/messages/:messageId/insights/:insightId/regenerate", r.insightHandler.RegenerateDraft)
+	stream.Post("/:itemId/messages/:messageId/insights/:insightId/send", r.insightHandler.SendDraft)
+
+	// Delta sync routes (auth required)
+	sync := v2.Group("/sync", middleware.Auth())
+	sync.Get("/", r.streamHandler.Sync)
+
+	// Daily briefing (auth required)
+	v2.Get("/briefing", middleware.Auth(), r.briefingHandler.GetBriefing)
+
+	// Ingestion webhooks (authenticated by per-source HMAC signature, not Clerk)
+	v2.Post("/ingest/:source", r.ingestHandler.HandleWebhook)
+
+	// Calendar feed (authenticated by the feed token, since calendar clients cannot send Clerk JWTs)
+	v2.Get("/calendar.ics", r.calendarHandler.GetFeed)
+
+	// Calendar routes (auth required)
+	cal := v2.Group("/calendar", middleware.Auth())
+	cal.Get("/feed", r.calendarHandler.GetFeedURL)
+	cal.Post("/import", r.calendarHandler.Import)
+	cal.Get("/freebusy", r.calendarHandler.FreeBusy)
+
+	// Triage rule routes (auth required)
+	rules := v2.Group("/rules", middleware.Auth())
+	rules.Get("/", r.ruleHandler.ListRules)
+	rules.Post("/", r.ruleHandler.CreateRule)
+	rules.Post("/dry-run", r.ruleHandler.DryRun)
+	rules.Get("/:ruleId", r.ruleHandler.GetRule)
+	rules.Put("/:ruleId", r.ruleHandler.UpdateRule)
+	rules.Delete("/:ruleId", r.ruleHandler.DeleteRule)
+	rules.Post("/:ruleId/dry-run", r.ruleHandler.DryRunRule)
+
+	// Label routes (auth required)
+	labels := v2.Group("/labels", middleware.Auth())
+	labels.Get("/", r.labelHandler.ListLabels)
+	labels.Post("/", r.labelHandler.CreateLabel)
+	labels.Get("/:labelId", r.labelHandler.GetLabel)
+	labels.Put("/:labelId", r.labelHandler.UpdateLabel)
+	labels.Delete("/:labelId", r.labelHandler.DeleteLabel)
+
+	// People directory routes (auth required)
+	people := v2.Group("/people", middleware.Auth())
+	people.Get("/", r.peopleHandler.ListPeople)
+	people.Get("/:personId", r.peopleHandler.GetPerson)
+	people.Post("/:personId/vip", r.peopleHandler.MarkVIP)
+	people.Delete("/:personId/vip", r.peopleHandler.UnmarkVIP)
 }
@@ -3,8 +3,12 @@ package cache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -24,6 +28,22 @@ type Cache interface {
 	SetStreamItem(ctx context.Context, key string, item *model.PriorityItem, ttl time.Duration) error
 	// Delete removes a key from cache.
 	Delete(ctx context.Context, keys ...string) error
+	// InvalidateUserCache removes all cached stream pages for a user.
+	InvalidateUserCache(ctx context.Context, userID string) error
+	// PinVersion returns the version of a user's pins, for use in stream keys.
+	PinVersion(ctx context.Context, userID string) (int64, error)
+	// BumpPinVersion moves a user's pin version forward after their pins change.
+	BumpPinVersion(ctx context.Context, userID string) error
+	// GetCounts retrieves a user's cached stream counts.
+	GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error)
+	// SetCounts caches a user's stream counts with TTL.
+	SetCounts(ctx context.Context, userID string, counts *model.CountsResponse, ttl time.Duration) error
+	// AdjustCounts adds a delta to a user's cached stream counts, if they are cached.
+	AdjustCounts(ctx context.Context, userID string, delta *model.CountsResponse) error
+	// GetBriefing retrieves a cached briefing.
+	GetBriefing(ctx context.Context, key string) (*model.BriefingResponse, error)
+	// SetBriefing caches a briefing with TTL.
+	SetBriefing(ctx context.Context, key string, briefing *model.BriefingResponse, ttl time.Duration) error
 	// Ping checks if Redis is reachable.
 	Ping(ctx context.Context) error
 }
@@ -42,15 +62,47 @@ func NewRedisCache(client *redis.Client) *RedisCache {
 const (
 	streamKeyPrefix = "stream:"
 	itemKeyPrefix   = "item:"
+	pinsKeyPrefix   = "pins:"
+	countsKeyPrefix = "counts:"
 )
 
 // StreamKey generates a cache key for stream data.
-func StreamKey(userID string, filter model.StreamFilter, cursor *string) string {
+// The filter is identified by a hash of its canonical form, so equivalent
+// filters share cache entries regardless of parameter order. Pages cached
+// under an older pin version are never read again once pins change.
+func StreamKey(userID string, filter model.StreamFilter, sort model.StreamSort, pinVersion int64, cursor *string) string {
+	if sort == "" {
+		sort = model.SortRecent
+	}
 	cursorPart := "none"
 	if cursor != nil && *cursor != "" {
 		cursorPart = *cursor
 	}
-	return fmt.Sprintf("%s%s:%s:%s", streamKeyPrefix, userID, filter, cursorPart)
+	return fmt.Sprintf("%s%s:%s:%s:p%d:%s", streamKeyPrefix, userID, FilterHash(filter), sort, pinVersion, cursorPart)
+}
+
+// FilterHash returns a stable hash of the filter's canonical form.
+func FilterHash(filter model.StreamFilter) string {
+	data, _ := json.Marshal(filter.Normalize())
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// BriefingKey generates a cache key for a user's briefing of a day in a time zone.
+// It shares the stream prefix, so invalidating a user's stream pages drops it too.
+func BriefingKey(userID, date, timeZone string) string {
+	return fmt.Sprintf("%s%s:briefing:%s:%s", streamKeyPrefix, userID, date, timeZone)
+}
+
+// pinsKey generates the key holding a user's pin version.
+func pinsKey(userID string) string {
+	return pinsKeyPrefix + userID
+}
+
+// CountsKey generates the key holding a user's stream counts.
+// Deleting it makes the next read recompute the counts.
+func CountsKey(userID string) string {
+	return countsKeyPrefix + userID
 }
 
 // ItemKey generates a cache key for a stream item.
@@ -122,6 +174,38 @@ func (c *RedisCache) SetStreamItem(ctx context.Context, key string, item *model.
 	return nil
 }
 
+// GetBriefing retrieves a cached briefing.
+func (c *RedisCache) GetBriefing(ctx context.Context, key string) (*model.BriefingResponse, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Cache miss
+		}
+		return nil, fmt.Errorf("failed to get briefing from cache: %w", err)
+	}
+
+	var briefing model.BriefingResponse
+	if err := json.Unmarshal(data, &briefing); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal briefing data: %w", err)
+	}
+
+	return &briefing, nil
+}
+
+// SetBriefing caches a briefing with TTL.
+func (c *RedisCache) SetBriefing(ctx context.Context, key string, briefing *model.BriefingResponse, ttl time.Duration) error {
+	jsonData, err := json.Marshal(briefing)
+	if err != nil {
+		return fmt.Errorf("failed to marshal briefing data: %w", err)
+	}
+
+	if err := c.client.Set(ctx, key, jsonData, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set briefing in cache: %w", err)
+	}
+
+	return nil
+}
+
 // Delete removes keys from cache.
 func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
 	if len(keys) == 0 {
@@ -162,3 +246,165 @@ func (c *RedisCache) InvalidateUserCache(ctx context.Context, userID string) err
 
 	return nil
 }
+
+// PinVersion returns the version of a user's pins. Users who never changed
+// their pins are at version 0.
+func (c *RedisCache) PinVersion(ctx context.Context, userID string) (int64, error) {
+	version, err := c.client.Get(ctx, pinsKey(userID)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pin version: %w", err)
+	}
+	return version, nil
+}
+
+// BumpPinVersion moves a user's pin version forward.
+// The version does not expire, so it never returns to a value used by a cached page.
+func (c *RedisCache) BumpPinVersion(ctx context.Context, userID string) error {
+	if err := c.client.Incr(ctx, pinsKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to bump pin version: %w", err)
+	}
+	return nil
+}
+
+// adjustCountsScript applies HINCRBY field/increment pairs to a counts hash,
+// but only if the hash exists, so that a partial hash is never created.
+// Expired or invalidated counts are recomputed on the next read instead.
+var adjustCountsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+for i = 1, #ARGV, 2 do
+	redis.call('HINCRBY', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+return 1
+`)
+
+// GetCounts retrieves a user's cached stream counts.
+func (c *RedisCache) GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error) {
+	fields, err := c.client.HGetAll(ctx, CountsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get counts from cache: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, nil // Cache miss
+	}
+	return parseCountsFields(fields)
+}
+
+// SetCounts replaces a user's cached stream counts.
+func (c *RedisCache) SetCounts(ctx context.Context, userID string, counts *model.CountsResponse, ttl time.Duration) error {
+	key := CountsKey(userID)
+	fields := make(map[string]interface{})
+	for field, n := range countsFields(counts) {
+		fields[field] = n
+	}
+
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, fields)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set counts in cache: %w", err)
+	}
+	return nil
+}
+
+// AdjustCounts adds a delta to a user's cached stream counts.
+// Nothing is written if the counts are not cached.
+func (c *RedisCache) AdjustCounts(ctx context.Context, userID string, delta *model.CountsResponse) error {
+	args := []interface{}{}
+	for field, n := range countsFields(delta) {
+		if n != 0 {
+			args = append(args, field, n)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	if err := adjustCountsScript.Run(ctx, c.client, []string{CountsKey(userID)}, args...).Err(); err != nil {
+		return fmt.Errorf("failed to adjust counts in cache: %w", err)
+	}
+	return nil
+}
+
+// countsFields flattens counts into hash fields: "total", "unread" and "high"
+// for the whole stream, prefixed with "source:<source>:" or "label:<id>:" for
+// each source and label.
+func countsFields(counts *model.CountsResponse) map[string]int {
+	fields := make(map[string]int, 3*(1+len(counts.Sources)+len(counts.Labels)))
+	add := func(prefix string, sc model.StreamCounts) {
+		fields[prefix+"total"] = sc.Total
+		fields[prefix+"unread"] = sc.Unread
+		fields[prefix+"high"] = sc.High
+	}
+
+	add("", counts.StreamCounts)
+	for source, sc := range counts.Sources {
+		add("source:"+string(source)+":", sc)
+	}
+	for labelID, sc := range counts.Labels {
+		add("label:"+labelID+":", sc)
+	}
+	return fields
+}
+
+// parseCountsFields rebuilds counts from the hash fields written by countsFields.
+// Sources and labels whose counts dropped to zero are left out.
+func parseCountsFields(fields map[string]string) (*model.CountsResponse, error) {
+	counts := model.NewCountsResponse()
+	for field, value := range fields {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid counts field %s: %w", field, err)
+		}
+
+		scope, metric := "", field
+		if i := strings.LastIndex(field, ":"); i >= 0 {
+			scope, metric = field[:i], field[i+1:]
+		}
+
+		switch {
+		case scope == "":
+			err = setCount(&counts.StreamCounts, metric, n)
+		case strings.HasPrefix(scope, "source:"):
+			source := model.SourceType(strings.TrimPrefix(scope, "source:"))
+			sc := counts.Sources[source]
+			err = setCount(&sc, metric, n)
+			counts.Sources[source] = sc
+		case strings.HasPrefix(scope, "label:"):
+			labelID := strings.TrimPrefix(scope, "label:")
+			sc := counts.Labels[labelID]
+			err = setCount(&sc, metric, n)
+			counts.Labels[labelID] = sc
+		default:
+			err = fmt.Errorf("unknown scope")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid counts field %s: %w", field, err)
+		}
+	}
+
+	counts.Compact()
+	return counts, nil
+}
+
+// setCount sets the named count.
+func setCount(counts *model.StreamCounts, metric string, n int) error {
+	switch metric {
+	case "total":
+		counts.Total = n
+	case "unread":
+		counts.Unread = n
+	case "high":
+		counts.High = n
+	default:
+		return fmt.Errorf("unknown count %q", metric)
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package cache
 
 import (
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -9,51 +11,93 @@ import (
 )
 
 func TestStreamKey(t *testing.T) {
+	allHash := FilterHash(model.StreamFilter{})
+
 	tests := []struct {
 		name     string
 		userID   string
 		filter   model.StreamFilter
+		sort     model.StreamSort
+		pins     int64
 		cursor   *string
 		expected string
 	}{
 		{
-			name:     "all filter no cursor",
+			name:     "empty filter no cursor",
 			userID:   "user-123",
-			filter:   model.FilterAll,
-			cursor:   nil,
-			expected: "stream:user-123:all:none",
-		},
-		{
-			name:     "high filter no cursor",
-			userID:   "user-456",
-			filter:   model.FilterHigh,
+			filter:   model.StreamFilter{},
 			cursor:   nil,
-			expected: "stream:user-456:high:none",
+			expected: "stream:user-123:" + allHash + ":recent:p0:none",
 		},
 		{
-			name:     "unread filter with cursor",
+			name:     "empty filter with cursor",
 			userID:   "user-789",
-			filter:   model.FilterUnread,
+			filter:   model.StreamFilter{},
 			cursor:   strPtr("abc123"),
-			expected: "stream:user-789:unread:abc123",
+			expected: "stream:user-789:" + allHash + ":recent:p0:abc123",
 		},
 		{
 			name:     "empty cursor string",
 			userID:   "user-000",
-			filter:   model.FilterAll,
+			filter:   model.StreamFilter{},
 			cursor:   strPtr(""),
-			expected: "stream:user-000:all:none",
+			expected: "stream:user-000:" + allHash + ":recent:p0:none",
+		},
+		{
+			name:     "sorted by score",
+			userID:   "user-456",
+			filter:   model.StreamFilter{},
+			sort:     model.SortScore,
+			cursor:   strPtr("abc123"),
+			expected: "stream:user-456:" + allHash + ":score:p0:abc123",
+		},
+		{
+			name:     "after pins changed",
+			userID:   "user-456",
+			filter:   model.StreamFilter{},
+			pins:     3,
+			cursor:   nil,
+			expected: "stream:user-456:" + allHash + ":recent:p3:none",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := StreamKey(tt.userID, tt.filter, tt.cursor)
+			result := StreamKey(tt.userID, tt.filter, tt.sort, tt.pins, tt.cursor)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
+func TestFilterHash(t *testing.T) {
+	unread := true
+	since := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	sinceOtherZone := since.In(time.FixedZone("CET", 3600))
+
+	a := model.StreamFilter{
+		Sources:    []model.SourceType{model.SourceSlack, model.SourceEmail},
+		Priorities: []model.Priority{model.PriorityMedium, model.PriorityHigh},
+		Unread:     &unread,
+		Since:      &since,
+	}
+	b := model.StreamFilter{
+		Sources:    []model.SourceType{model.SourceEmail, model.SourceSlack, model.SourceEmail},
+		Priorities: []model.Priority{model.PriorityHigh, model.PriorityMedium},
+		Unread:     &unread,
+		Since:      &sinceOtherZone,
+	}
+
+	// Equivalent filters hash identically
+	assert.Equal(t, FilterHash(a), FilterHash(b))
+
+	// Different filters hash differently
+	assert.NotEqual(t, FilterHash(a), FilterHash(model.StreamFilter{}))
+	read := false
+	c := a
+	c.Unread = &read
+	assert.NotEqual(t, FilterHash(a), FilterHash(c))
+}
+
 func TestItemKey(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -80,6 +124,48 @@ func TestItemKey(t *testing.T) {
 	}
 }
 
+func TestCountsKey(t *testing.T) {
+	assert.Equal(t, "counts:user-123", CountsKey("user-123"))
+}
+
+func TestBriefingKey(t *testing.T) {
+	key := BriefingKey("user-123", "2026-03-02", "Europe/Lisbon")
+	assert.Equal(t, "stream:user-123:briefing:2026-03-02:Europe/Lisbon", key)
+}
+
+func TestCountsFields_RoundTrip(t *testing.T) {
+	counts := &model.CountsResponse{
+		StreamCounts: model.StreamCounts{Total: 3, Unread: 2, High: 1},
+		Sources: map[model.SourceType]model.StreamCounts{
+			model.SourceEmail: {Total: 2, Unread: 2, High: 1},
+			model.SourceSlack: {Total: 1},
+		},
+		Labels: map[string]model.StreamCounts{
+			"0b6f4a8e-3c1d-4e2f-9a7b-5c6d7e8f9a0b": {Total: 1, Unread: 1},
+		},
+	}
+
+	fields := countsFields(counts)
+	assert.Equal(t, 2, fields["source:email:unread"])
+	assert.Equal(t, 1, fields["label:0b6f4a8e-3c1d-4e2f-9a7b-5c6d7e8f9a0b:total"])
+
+	stored := make(map[string]string, len(fields))
+	for field, n := range fields {
+		stored[field] = strconv.Itoa(n)
+	}
+	// A source whose items were all read and removed since the counts were cached
+	stored["source:teams:total"] = "0"
+	stored["source:teams:unread"] = "0"
+	stored["source:teams:high"] = "0"
+
+	parsed, err := parseCountsFields(stored)
+	assert.NoError(t, err)
+	assert.Equal(t, counts, parsed)
+
+	_, err = parseCountsFields(map[string]string{"source:email:pending": "1"})
+	assert.Error(t, err)
+}
+
 // Helper function
 func strPtr(s string) *string {
 	return &s
@@ -0,0 +1,253 @@
+package calendar
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+func parseTestCalendar(t *testing.T) []Event {
+	f, err := os.Open("testdata/team.ics")
+	require.NoError(t, err)
+	defer f.Close()
+
+	events, err := Parse(f)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(events))
+	return events
+}
+
+func TestParse(t *testing.T) {
+	events := parseTestCalendar(t)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	sync := events[0]
+	assert.Equal(t, "weekly-sync@example.com", sync.UID)
+	assert.Equal(t, "Weekly sync, team", sync.Summary)
+	assert.Equal(t, "Agenda:\n1. Updates", sync.Description)
+	assert.Equal(t, "https://meet.example.com/abc", sync.URL)
+	assert.True(t, sync.Start.Equal(time.Date(2026, 1, 5, 10, 0, 0, 0, berlin)))
+	assert.Equal(t, 30*time.Minute, sync.End.Sub(sync.Start))
+	assert.Equal(t, "alice@example.com", sync.Organizer.Email)
+	require.Equal(t, 2, len(sync.Attendees))
+	assert.Equal(t, "Bob, Jr.", sync.Attendees[0].Name)
+	assert.Equal(t, "ACCEPTED", sync.Attendees[0].Status)
+	assert.Equal(t, Weekly, sync.RRule.Freq)
+	assert.Equal(t, 1, len(sync.ExDates))
+
+	offsite := events[3]
+	assert.True(t, offsite.AllDay)
+	assert.Equal(t, 24*time.Hour, offsite.End.Sub(offsite.Start))
+	assert.Equal(t, "Offsite with a long title that needs folding because it goes on and on past seventy-five octets", offsite.Summary)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []string{
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20260105T100000Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:x\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:x\r\nDTSTART:20260105T100000Z\r\nRRULE:FREQ=HOURLY\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n",
+		"not a calendar",
+	}
+
+	for _, input := range tests {
+		_, err := Parse(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestRRule_Occurrences(t *testing.T) {
+	start := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	dates := func(times []time.Time) []string {
+		out := make([]string, len(times))
+		for i, t := range times {
+			out[i] = t.Format("2006-01-02")
+		}
+		return out
+	}
+
+	tests := []struct {
+		rule  string
+		start time.Time
+		want  []string
+	}{
+		{"FREQ=DAILY;COUNT=3", start, []string{"2026-01-31", "2026-02-01", "2026-02-02"}},
+		{"FREQ=DAILY;INTERVAL=2;UNTIL=20260205", start, []string{"2026-01-31", "2026-02-02", "2026-02-04"}},
+		// Months without a 31st are skipped
+		{"FREQ=MONTHLY;COUNT=3", start, []string{"2026-01-31", "2026-03-31", "2026-05-31"}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC), []string{"2026-01-30", "2026-02-27", "2026-03-27"}},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=4", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), []string{"2026-01-01", "2026-01-31", "2026-02-01", "2026-02-28"}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,FR;COUNT=4", time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC), []string{"2026-01-06", "2026-01-09", "2026-01-20", "2026-01-23"}},
+		{"FREQ=YEARLY;BYMONTH=3,9;BYDAY=2SU;COUNT=3", time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC), []string{"2026-03-08", "2026-09-13", "2027-03-14"}},
+		{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=3", time.Date(2026, 1, 9, 9, 0, 0, 0, time.UTC), []string{"2026-01-09", "2026-01-12", "2026-01-13"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, dates(rule.Occurrences(tt.start, from, to.AddDate(1, 0, 0))))
+		})
+	}
+}
+
+func TestRRule_OccurrencesWindow(t *testing.T) {
+	rule, err := ParseRRule("FREQ=DAILY;COUNT=10")
+	require.NoError(t, err)
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// COUNT is counted from the series start, not the window
+	got := rule.Occurrences(start, time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 3, len(got))
+}
+
+func TestRRule_OccurrencesOfLongRunningSeries(t *testing.T) {
+	from := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule string
+		want int
+	}{
+		{"FREQ=DAILY", 7},
+		{"FREQ=DAILY;INTERVAL=3", 3},
+		{"FREQ=WEEKLY;BYDAY=MO,WE,FR", 3},
+		{"FREQ=MONTHLY;BYMONTHDAY=5", 1},
+		{"FREQ=MONTHLY;BYMONTHDAY=31;BYMONTH=2", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			require.NoError(t, err)
+
+			// Started more than maxPeriods days before the window
+			got := rule.Occurrences(time.Date(1990, 1, 5, 9, 0, 0, 0, time.UTC), from, to)
+
+			assert.Equal(t, tt.want, len(got))
+		})
+	}
+}
+
+func TestRRule_KeepsWallClockAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	rule, err := ParseRRule("FREQ=WEEKLY;COUNT=2")
+	require.NoError(t, err)
+
+	got := rule.Occurrences(time.Date(2026, 3, 23, 10, 0, 0, 0, berlin), time.Time{}, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	require.Equal(t, 2, len(got))
+	assert.Equal(t, 10, got[1].Hour())
+	assert.Equal(t, 167*time.Hour, got[1].Sub(got[0]))
+}
+
+func TestExpand(t *testing.T) {
+	events := parseTestCalendar(t)
+
+	occurrences := Expand(events, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	var ids, titles []string
+	for _, o := range occurrences {
+		ids = append(ids, o.ID)
+		titles = append(titles, o.Event.Summary)
+	}
+	// Mon 5th, (Thu 8th excluded), Mon 12th moved, (Thu 15th cancelled), Mon 19th, offsite 20th, Thu 22nd
+	assert.Equal(t, []string{
+		"weekly-sync@example.com/20260105T090000Z",
+		"weekly-sync@example.com/20260112T090000Z",
+		"weekly-sync@example.com/20260119T090000Z",
+		"offsite@example.com",
+		"weekly-sync@example.com/20260122T090000Z",
+	}, ids)
+	assert.Equal(t, "Weekly sync (moved)", titles[1])
+	assert.Equal(t, 14, occurrences[1].Event.Start.Hour())
+}
+
+func TestToItems(t *testing.T) {
+	occurrences := Expand(parseTestCalendar(t), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC))
+
+	items := ToItems(occurrences, Options{UserID: "user_1", OwnerEmail: "me@example.com"})
+
+	require.Equal(t, 2, len(items))
+	item := items[0]
+	assert.Equal(t, model.SourceCalendar, item.Source)
+	assert.Equal(t, "weekly-sync@example.com/20260105T090000Z", item.ExternalID)
+	assert.Equal(t, "Weekly sync, team", item.Title)
+
+	var participants []string
+	for _, p := range item.Participants {
+		participants = append(participants, *p.Email)
+	}
+	assert.Equal(t, []string{"bob@example.com", "alice@example.com"}, participants)
+
+	require.Equal(t, 1, len(item.Messages))
+	msg := item.Messages[0]
+	assert.Equal(t, model.ContentEvent, msg.ContentType)
+	assert.Equal(t, "alice@example.com", *msg.From.Email)
+	assert.Equal(t, time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC), msg.EventDetails.EndTime)
+	assert.Equal(t, 2, len(msg.EventDetails.Attendees))
+	assert.Equal(t, "https://meet.example.com/abc", *msg.EventDetails.MeetingLink)
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	description := "Line one\nLine two; with, punctuation"
+	email := "bob@example.com"
+	events := []model.CalendarEvent{{
+		ID:          "evt-1",
+		Title:       "Planning — Q1 roadmap review with the extended leadership team and guests",
+		StartTime:   time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+		Attendees:   []model.User{{Name: "Bob", Email: &email}},
+		RSVPs:       []model.RSVP{{Attendee: "bob@example.com", Status: model.RSVPAccepted}},
+		Description: &description,
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, "Gravity", events, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxOctets)
+	}
+	assert.Contains(t, buf.String(), `ATTENDEE;CN="Bob";PARTSTAT=ACCEPTED:mailto:bob@example.com`)
+
+	parsed, err := Parse(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(parsed))
+	assert.Equal(t, "evt-1", parsed[0].UID)
+	assert.Equal(t, events[0].Title, parsed[0].Summary)
+	assert.Equal(t, description, parsed[0].Description)
+	assert.True(t, parsed[0].Start.Equal(events[0].StartTime))
+	assert.Equal(t, "bob@example.com", parsed[0].Attendees[0].Email)
+}
+
+func TestFeedTokens(t *testing.T) {
+	tokens := NewFeedTokens("secret")
+
+	token := tokens.Token("user_2abc")
+	userID, err := tokens.Verify(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user_2abc", userID)
+
+	_, err = NewFeedTokens("rotated").Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	for _, bad := range []string{"", "abc", "." + strings.Split(token, ".")[1], strings.Split(token, ".")[0] + ".AAAA"} {
+		_, err := tokens.Verify(bad)
+		assert.ErrorIs(t, err, ErrInvalidToken, bad)
+	}
+}
@@ -0,0 +1,128 @@
+package calendar
+
+import (
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// Options controls how occurrences are converted into priority items.
+type Options struct {
+	// UserID owns the imported items.
+	UserID string
+	// OwnerEmail is left out of the item participants.
+	OwnerEmail string
+	// Unread imports items as unread.
+	Unread bool
+}
+
+// ToItems converts occurrences into calendar items for ingestion, one item per
+// occurrence keyed by its ID. Each item holds a single event message that is
+// refreshed when the event changes, so re-importing a calendar is idempotent.
+func ToItems(occurrences []Occurrence, opts Options) []model.IngestItem {
+	owner := strings.ToLower(strings.TrimSpace(opts.OwnerEmail))
+
+	items := make([]model.IngestItem, 0, len(occurrences))
+	for _, occ := range occurrences {
+		e := occ.Event
+
+		title := strings.TrimSpace(e.Summary)
+		if title == "" {
+			title = "(no title)"
+		}
+
+		details := &model.CalendarEvent{
+			ID:          occ.ID,
+			Title:       title,
+			StartTime:   e.Start.UTC(),
+			EndTime:     e.End.UTC(),
+			Attendees:   make([]model.User, 0, len(e.Attendees)),
+			Location:    optional(e.Location),
+			MeetingLink: optional(e.URL),
+			Description: optional(e.Description),
+		}
+
+		item := model.IngestItem{
+			UserID:     opts.UserID,
+			Source:     model.SourceCalendar,
+			ExternalID: occ.ID,
+			Title:      title,
+			IsUnread:   opts.Unread,
+			Timestamp:  e.Start.UTC(),
+		}
+
+		seen := make(map[string]bool)
+		addParticipant := func(a Attendee) {
+			if a.Email == "" || a.Email == owner || seen[a.Email] {
+				return
+			}
+			seen[a.Email] = true
+			item.Participants = append(item.Participants, contact(a))
+		}
+
+		for _, a := range e.Attendees {
+			details.Attendees = append(details.Attendees, attendeeUser(a))
+			addParticipant(a)
+		}
+
+		msg := model.IngestMessage{
+			ExternalID:   occ.ID,
+			SenderType:   model.SenderOther,
+			Content:      content(e, title),
+			Timestamp:    e.Start.UTC(),
+			ContentType:  model.ContentEvent,
+			EventDetails: details,
+		}
+		if e.Organizer != nil && e.Organizer.Email != "" {
+			organizer := contact(*e.Organizer)
+			msg.From = &organizer
+			if e.Organizer.Email == owner {
+				msg.SenderType = model.SenderUser
+			}
+			addParticipant(*e.Organizer)
+		}
+
+		item.Messages = []model.IngestMessage{msg}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// content returns the message text for an event: its description, or its title.
+func content(e Event, title string) string {
+	if d := strings.TrimSpace(e.Description); d != "" {
+		return d
+	}
+	return title
+}
+
+// contact converts an attendee into an ingestion contact.
+func contact(a Attendee) model.Contact {
+	email := a.Email
+	return model.Contact{Name: a.Name, Email: &email}
+}
+
+// attendeeUser converts an attendee into a user for event details.
+// Attendees are identified by email; they have no Gravity user ID here.
+func attendeeUser(a Attendee) model.User {
+	name := a.Name
+	if name == "" {
+		name = a.Email
+	}
+	user := model.User{Name: name}
+	if a.Email != "" {
+		email := a.Email
+		user.Email = &email
+	}
+	return user
+}
+
+// optional returns a pointer to s, or nil if s is blank.
+func optional(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,118 @@
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// productID identifies Gravity as the producer of exported calendars.
+const productID = "-//Gravity//Gravity BFF//EN"
+
+// partStats maps RSVP statuses to iCalendar participation statuses.
+var partStats = map[model.RSVPStatus]string{
+	model.RSVPAccepted:  "ACCEPTED",
+	model.RSVPDeclined:  "DECLINED",
+	model.RSVPTentative: "TENTATIVE",
+}
+
+// maxOctets is the longest content line allowed before folding.
+const maxOctets = 75
+
+// Encode writes events as an iCalendar feed named name.
+// stamp is used as DTSTAMP for every event.
+func Encode(w io.Writer, name string, events []model.CalendarEvent, stamp time.Time) error {
+	enc := &encoder{w: bufio.NewWriter(w)}
+
+	enc.line("BEGIN:VCALENDAR")
+	enc.line("VERSION:2.0")
+	enc.line("PRODID:" + productID)
+	enc.line("CALSCALE:GREGORIAN")
+	enc.line("METHOD:PUBLISH")
+	enc.line("X-WR-CALNAME:" + escapeText(name))
+
+	for _, e := range events {
+		enc.line("BEGIN:VEVENT")
+		enc.line("UID:" + e.ID)
+		enc.line("DTSTAMP:" + formatUTC(stamp))
+		enc.line("DTSTART:" + formatUTC(e.StartTime))
+		enc.line("DTEND:" + formatUTC(e.EndTime))
+		enc.line("SUMMARY:" + escapeText(e.Title))
+		if e.Description != nil {
+			enc.line("DESCRIPTION:" + escapeText(*e.Description))
+		}
+		if e.Location != nil {
+			enc.line("LOCATION:" + escapeText(*e.Location))
+		}
+		if e.MeetingLink != nil {
+			enc.line("URL:" + *e.MeetingLink)
+		}
+		for _, a := range e.Attendees {
+			if a.Email == nil {
+				continue
+			}
+			params := ";CN=" + quoteParam(a.Name)
+			if status, ok := e.Response(a); ok {
+				params += ";PARTSTAT=" + partStats[status]
+			}
+			enc.line("ATTENDEE" + params + ":mailto:" + *a.Email)
+		}
+		enc.line("END:VEVENT")
+	}
+
+	enc.line("END:VCALENDAR")
+	if enc.err != nil {
+		return enc.err
+	}
+	return enc.w.Flush()
+}
+
+// encoder writes folded CRLF-terminated content lines, keeping the first error.
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes a content line, folding it at 75 octets without splitting UTF-8 sequences.
+func (e *encoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+	limit := maxOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		_, e.err = e.w.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space
+		limit = maxOctets - 1
+	}
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s + "\r\n")
+	}
+}
+
+// formatUTC formats a time as a UTC DATE-TIME value.
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// textEscaper escapes TEXT values.
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeText escapes a TEXT value.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// quoteParam quotes a parameter value, dropping characters it cannot contain.
+func quoteParam(s string) string {
+	s = strings.NewReplacer(`"`, "", "\r", "", "\n", " ").Replace(s)
+	return `"` + s + `"`
+}
@@ -0,0 +1,102 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+)
+
+// Occurrence is a single instance of an event.
+type Occurrence struct {
+	// ID is the event UID, suffixed with the instance start for recurring events.
+	ID    string
+	Event Event // Start and End are those of this instance
+}
+
+// occurrenceID returns the ID of a recurring event's instance.
+func occurrenceID(uid string, start time.Time) string {
+	return uid + "/" + start.UTC().Format("20060102T150405Z")
+}
+
+// Expand turns parsed events into occurrences. Recurring events are expanded
+// for instances starting in [from, to), honoring EXDATE and RECURRENCE-ID
+// overrides; single events are kept regardless of the window. Cancelled
+// events and instances are dropped. Occurrences are returned by start time.
+func Expand(events []Event, from, to time.Time) []Occurrence {
+	masters := make(map[string]Event)
+	overrides := make(map[string][]Event)
+	var order []string
+
+	for _, e := range events {
+		if e.RecurrenceID != nil {
+			overrides[e.UID] = append(overrides[e.UID], e)
+			continue
+		}
+		if _, ok := masters[e.UID]; !ok {
+			order = append(order, e.UID)
+		}
+		// A later copy with a higher SEQUENCE supersedes an earlier one
+		if prev, ok := masters[e.UID]; !ok || e.Sequence >= prev.Sequence {
+			masters[e.UID] = e
+		}
+	}
+
+	var out []Occurrence
+	for _, uid := range order {
+		master := masters[uid]
+		if master.Cancelled() {
+			continue
+		}
+		if master.RRule == nil {
+			out = append(out, Occurrence{ID: uid, Event: master})
+			continue
+		}
+
+		byStart := make(map[int64]Event, len(overrides[uid]))
+		for _, o := range overrides[uid] {
+			byStart[o.RecurrenceID.Unix()] = o
+		}
+
+		duration := master.End.Sub(master.Start)
+		for _, start := range master.RRule.Occurrences(master.Start, from, to) {
+			if excluded(master.ExDates, start) {
+				continue
+			}
+
+			instance := master
+			instance.RRule = nil
+			instance.ExDates = nil
+			instance.Start = start
+			instance.End = start.Add(duration)
+
+			if o, ok := byStart[start.Unix()]; ok {
+				delete(byStart, start.Unix())
+				if o.Cancelled() {
+					continue
+				}
+				instance = o
+			}
+			out = append(out, Occurrence{ID: occurrenceID(uid, start), Event: instance})
+		}
+
+		// Overrides moved into the window from an instance outside it
+		for _, o := range byStart {
+			if o.Cancelled() || o.Start.Before(from) || !o.Start.Before(to) {
+				continue
+			}
+			out = append(out, Occurrence{ID: occurrenceID(uid, *o.RecurrenceID), Event: o})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Event.Start.Before(out[j].Event.Start) })
+	return out
+}
+
+// excluded reports whether t is one of the excluded dates.
+func excluded(exDates []time.Time, t time.Time) bool {
+	for _, ex := range exDates {
+		if ex.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,393 @@
+// Package calendar parses and writes iCalendar (RFC 5545) data and expands
+// recurring events into individual occurrences.
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a parsed VEVENT.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Status       string // CONFIRMED, TENTATIVE or CANCELLED
+	Sequence     int
+	Organizer    *Attendee
+	Attendees    []Attendee
+	RRule        *RRule
+	ExDates      []time.Time
+	RecurrenceID *time.Time // Set on overrides of a single occurrence
+
+	duration *time.Duration // DURATION, applied once DTSTART is known
+}
+
+// Attendee is an event organizer or attendee.
+type Attendee struct {
+	Name   string
+	Email  string
+	Status string // PARTSTAT: NEEDS-ACTION, ACCEPTED, DECLINED, TENTATIVE
+}
+
+// Cancelled reports whether the event was cancelled.
+func (e Event) Cancelled() bool {
+	return e.Status == "CANCELLED"
+}
+
+// property is a single content line: NAME;PARAM=VALUE:value.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// meetingLinkProperties are vendor properties that carry a video call link.
+var meetingLinkProperties = []string{
+	"X-GOOGLE-CONFERENCE",
+	"X-MICROSOFT-SKYPETEAMSMEETINGURL",
+	"X-MICROSOFT-ONLINEMEETINGCONFLINK",
+}
+
+// maxLineLength bounds a single unfolded content line.
+const maxLineLength = 1 << 20
+
+// Parse reads all VEVENTs from an iCalendar stream.
+// Times with a TZID are resolved through the IANA database; unknown zones and
+// floating times are read as UTC.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	var stack []string
+	var links map[string]string
+
+	for i, line := range lines {
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.value))
+			if strings.EqualFold(prop.value, "VEVENT") && len(stack) == 2 {
+				current = &Event{}
+				links = make(map[string]string)
+			}
+			continue
+		case "END":
+			if len(stack) == 0 || !strings.EqualFold(stack[len(stack)-1], prop.value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+1, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+			if strings.EqualFold(prop.value, "VEVENT") && current != nil {
+				if current.URL == "" {
+					for _, name := range meetingLinkProperties {
+						if link := links[name]; link != "" {
+							current.URL = link
+							break
+						}
+					}
+				}
+				if err := current.finish(); err != nil {
+					return nil, fmt.Errorf("line %d: %w", i+1, err)
+				}
+				events = append(events, *current)
+				current = nil
+			}
+			continue
+		}
+
+		// Only properties directly inside a VEVENT matter; skip VALARM and friends
+		if current == nil || len(stack) != 2 {
+			continue
+		}
+		if err := current.set(prop, links); err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", i+1, prop.name, err)
+		}
+	}
+
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("unterminated %s", stack[len(stack)-1])
+	}
+	return events, nil
+}
+
+// set applies a single property to the event.
+func (e *Event) set(prop property, links map[string]string) error {
+	var err error
+	switch prop.name {
+	case "UID":
+		e.UID = prop.value
+	case "SUMMARY":
+		e.Summary = unescapeText(prop.value)
+	case "DESCRIPTION":
+		e.Description = unescapeText(prop.value)
+	case "LOCATION":
+		e.Location = unescapeText(prop.value)
+	case "URL":
+		e.URL = prop.value
+	case "STATUS":
+		e.Status = strings.ToUpper(prop.value)
+	case "SEQUENCE":
+		e.Sequence, err = strconv.Atoi(prop.value)
+	case "DTSTART":
+		e.Start, e.AllDay, err = parseDateTime(prop)
+	case "DTEND":
+		e.End, _, err = parseDateTime(prop)
+	case "DURATION":
+		var d time.Duration
+		d, err = parseDuration(prop.value)
+		e.duration = &d
+	case "RRULE":
+		e.RRule, err = ParseRRule(prop.value)
+	case "EXDATE":
+		for _, v := range strings.Split(prop.value, ",") {
+			t, _, perr := parseDateTime(property{params: prop.params, value: v})
+			if perr != nil {
+				return perr
+			}
+			e.ExDates = append(e.ExDates, t)
+		}
+	case "RECURRENCE-ID":
+		var t time.Time
+		t, _, err = parseDateTime(prop)
+		e.RecurrenceID = &t
+	case "ORGANIZER":
+		organizer := parseAttendee(prop)
+		e.Organizer = &organizer
+	case "ATTENDEE":
+		e.Attendees = append(e.Attendees, parseAttendee(prop))
+	default:
+		for _, name := range meetingLinkProperties {
+			if prop.name == name {
+				links[name] = prop.value
+			}
+		}
+	}
+	return err
+}
+
+// finish validates the event and fills in a default end time.
+func (e *Event) finish() error {
+	if e.UID == "" {
+		return fmt.Errorf("VEVENT without UID")
+	}
+	if e.Start.IsZero() {
+		return fmt.Errorf("VEVENT %s without DTSTART", e.UID)
+	}
+
+	switch {
+	case e.duration != nil:
+		e.End = e.Start.Add(*e.duration)
+	case e.End.IsZero() && e.AllDay:
+		// RFC 5545: a date lasts one day, a date-time has no duration
+		e.End = e.Start.AddDate(0, 0, 1)
+	case e.End.IsZero():
+		e.End = e.Start
+	}
+
+	if e.End.Before(e.Start) {
+		return fmt.Errorf("VEVENT %s ends before it starts", e.UID)
+	}
+	return nil
+}
+
+// unfold joins folded content lines and normalizes line endings.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineLength)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseProperty splits a content line into name, parameters and value.
+// Parameter values may be quoted and contain ':' or ';'.
+func parseProperty(line string) (property, error) {
+	prop := property{params: make(map[string]string)}
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return prop, fmt.Errorf("malformed content line")
+	}
+	prop.name = strings.ToUpper(line[:i])
+
+	rest := line[i:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			return prop, fmt.Errorf("malformed parameter in %s", prop.name)
+		}
+		key := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return prop, fmt.Errorf("unterminated quoted parameter in %s", prop.name)
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return prop, fmt.Errorf("malformed parameter in %s", prop.name)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		prop.params[key] = value
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return prop, fmt.Errorf("missing value in %s", prop.name)
+	}
+	prop.value = rest[1:]
+	return prop, nil
+}
+
+// parseDateTime parses a DATE or DATE-TIME value, honoring TZID.
+// Returns the time and whether it was a DATE.
+func parseDateTime(prop property) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if prop.params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, time.UTC)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	loc := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// parseDuration parses an RFC 5545 duration such as PT1H30M, P1D or -P1W.
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	s = s[1:]
+
+	var total time.Duration
+	inTime := false
+	num := 0
+	digits := false
+	for _, r := range s {
+		switch {
+		case r == 'T':
+			inTime = true
+			continue
+		case r >= '0' && r <= '9':
+			num = num*10 + int(r-'0')
+			digits = true
+			continue
+		}
+		if !digits {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		n := time.Duration(num)
+		switch {
+		case r == 'W' && !inTime:
+			total += n * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			total += n * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += n * time.Hour
+		case r == 'M' && inTime:
+			total += n * time.Minute
+		case r == 'S' && inTime:
+			total += n * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		num, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return sign * total, nil
+}
+
+// parseAttendee reads an ORGANIZER or ATTENDEE property.
+func parseAttendee(prop property) Attendee {
+	email := prop.value
+	if len(email) >= 7 && strings.EqualFold(email[:7], "mailto:") {
+		email = email[7:]
+	}
+	return Attendee{
+		Name:   unescapeText(prop.params["CN"]),
+		Email:  strings.ToLower(strings.TrimSpace(email)),
+		Status: strings.ToUpper(prop.params["PARTSTAT"]),
+	}
+}
+
+// unescapeText undoes TEXT value escaping.
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
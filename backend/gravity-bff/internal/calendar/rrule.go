@@ -0,0 +1,360 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is an RRULE FREQ value.
+type Frequency string
+
+// Supported recurrence frequencies.
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// WeekdayNum is a BYDAY entry, e.g. MO, 1MO (first Monday) or -1FR (last Friday).
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int // 0 means every such weekday in the period
+}
+
+// RRule is a parsed recurrence rule. BYSETPOS, BYWEEKNO, BYYEARDAY and
+// sub-daily frequencies are not supported.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int        // 0 means unbounded
+	Until      *time.Time // Inclusive
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+// maxPeriods bounds how many periods an expansion walks past the window start,
+// so that rules whose filters never match (e.g. BYMONTHDAY=31 with BYMONTH=2)
+// terminate.
+const maxPeriods = 5000
+
+// weekdays maps RRULE day codes to weekdays.
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseRRule parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+func ParseRRule(value string) (*RRule, error) {
+	rule := &RRule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(val))
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && rule.Interval < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err == nil && rule.Count < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "UNTIL":
+			var until time.Time
+			until, _, err = parseDateTime(property{value: val})
+			if err == nil && len(val) == 8 {
+				// A date bound includes the whole day
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				wd, perr := parseWeekdayNum(d)
+				if perr != nil {
+					return nil, perr
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(val, ",") {
+				n, perr := strconv.Atoi(d)
+				if perr != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q", d)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, m := range strings.Split(val, ",") {
+				n, perr := strconv.Atoi(m)
+				if perr != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("invalid BYMONTH %q", m)
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(n))
+			}
+		case "WKST":
+			// Weeks always start on Monday here; WKST only matters for
+			// WEEKLY rules with INTERVAL > 1 and BYDAY spanning Sunday.
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", key, val, err)
+		}
+	}
+
+	switch rule.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return nil, fmt.Errorf("unsupported FREQ %q", rule.Freq)
+	}
+	return rule, nil
+}
+
+// parseWeekdayNum parses a BYDAY entry.
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	wd, ok := weekdays[s[len(s)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	n := 0
+	if prefix := s[:len(s)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+		}
+	}
+	return WeekdayNum{Weekday: wd, N: n}, nil
+}
+
+// Occurrences returns the start times of the rule's occurrences that begin in
+// [from, to). As in RFC 5545, the series start is always the first occurrence and
+// COUNT is applied from it, not from the window. Wall-clock time is kept across
+// DST changes.
+func (r *RRule) Occurrences(start, from, to time.Time) []time.Time {
+	var out []time.Time
+	emitted := 0
+
+	emit := func(t time.Time) bool {
+		if (r.Until != nil && t.After(*r.Until)) || (r.Count > 0 && emitted >= r.Count) || !t.Before(to) {
+			return false
+		}
+		emitted++
+		if !t.Before(from) {
+			out = append(out, t)
+		}
+		return true
+	}
+
+	if !emit(start) {
+		return out
+	}
+	// Without COUNT nothing before the window matters, so the walk starts near it
+	first := 0
+	if r.Count == 0 {
+		first = r.periodAt(start, from)
+	}
+	for period := first; period < first+maxPeriods; period++ {
+		for _, t := range r.candidates(start, period) {
+			if !t.After(start) {
+				continue
+			}
+			if !emit(t) {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+// periodAt returns the index of the period of the series that contains t, or
+// of an earlier one for weekly rules whose week straddles it.
+func (r *RRule) periodAt(start, t time.Time) int {
+	if !t.After(start) {
+		return 0
+	}
+	t = t.In(start.Location())
+
+	var n int
+	switch r.Freq {
+	case Daily, Weekly:
+		days := int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).
+			Sub(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24)
+		if r.Freq == Weekly {
+			days /= 7
+		}
+		n = days / r.Interval
+	case Monthly:
+		n = ((t.Year()-start.Year())*12 + int(t.Month()-start.Month())) / r.Interval
+	case Yearly:
+		n = (t.Year() - start.Year()) / r.Interval
+	}
+	return n
+}
+
+// candidates returns the sorted occurrence candidates in the n-th period of the series.
+func (r *RRule) candidates(start time.Time, n int) []time.Time {
+	step := n * r.Interval
+	loc := start.Location()
+	hour, min, sec := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, 0, loc)
+	}
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		days = []time.Time{at(start.Year(), start.Month(), start.Day()+step)}
+	case Weekly:
+		// Monday of the period's week
+		offset := (int(start.Weekday()) + 6) % 7
+		monday := at(start.Year(), start.Month(), start.Day()-offset+7*step)
+		if len(r.ByDay) == 0 {
+			days = []time.Time{monday.AddDate(0, 0, offset)}
+			break
+		}
+		for _, wd := range r.ByDay {
+			days = append(days, monday.AddDate(0, 0, (int(wd.Weekday)+6)%7))
+		}
+	case Monthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+		days = r.monthDays(first.Year(), first.Month(), start.Day(), at)
+	case Yearly:
+		year := start.Year() + step
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{start.Month()}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(year, m, start.Day(), at)...)
+		}
+	}
+
+	out := days[:0]
+	for _, d := range days {
+		if r.matches(d) {
+			out = append(out, d)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return dedupe(out)
+}
+
+// monthDays expands BYMONTHDAY and BYDAY within a month. Without either, the
+// series' day of month is used, and months too short for it are skipped.
+func (r *RRule) monthDays(year int, month time.Month, defaultDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	var days []time.Time
+
+	for _, d := range r.ByMonthDay {
+		if d < 0 {
+			d = last + d + 1
+		}
+		if d >= 1 && d <= last {
+			days = append(days, at(year, month, d))
+		}
+	}
+
+	for _, wd := range r.ByDay {
+		var matching []int
+		for d := 1; d <= last; d++ {
+			if time.Date(year, month, d, 0, 0, 0, 0, time.UTC).Weekday() == wd.Weekday {
+				matching = append(matching, d)
+			}
+		}
+		switch {
+		case wd.N == 0:
+			for _, d := range matching {
+				days = append(days, at(year, month, d))
+			}
+		case wd.N > 0 && wd.N <= len(matching):
+			days = append(days, at(year, month, matching[wd.N-1]))
+		case wd.N < 0 && -wd.N <= len(matching):
+			days = append(days, at(year, month, matching[len(matching)+wd.N]))
+		}
+	}
+
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 && defaultDay <= last {
+		days = append(days, at(year, month, defaultDay))
+	}
+	return days
+}
+
+// matches applies the BY* filters that limit rather than expand for the rule's frequency.
+func (r *RRule) matches(t time.Time) bool {
+	if len(r.ByMonth) > 0 && r.Freq != Yearly && !containsMonth(r.ByMonth, t.Month()) {
+		return false
+	}
+	if r.Freq == Daily {
+		if len(r.ByDay) > 0 && !containsWeekday(r.ByDay, t.Weekday()) {
+			return false
+		}
+		if len(r.ByMonthDay) > 0 && !containsMonthDay(r.ByMonthDay, t) {
+			return false
+		}
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 && !containsMonthDay(r.ByMonthDay, t) {
+		return false
+	}
+	// With both BYMONTHDAY and BYDAY in a monthly or yearly rule, a day must satisfy both
+	if (r.Freq == Monthly || r.Freq == Yearly) && len(r.ByMonthDay) > 0 && len(r.ByDay) > 0 {
+		return containsMonthDay(r.ByMonthDay, t) && containsWeekday(r.ByDay, t.Weekday())
+	}
+	return true
+}
+
+func containsMonth(months []time.Month, m time.Month) bool {
+	for _, month := range months {
+		if month == m {
+			return true
+		}
+	}
+	return false
+}
+
+func containsWeekday(days []WeekdayNum, wd time.Weekday) bool {
+	for _, d := range days {
+		if d.Weekday == wd {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMonthDay(days []int, t time.Time) bool {
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range days {
+		if d == t.Day() || (d < 0 && last+d+1 == t.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupe removes equal neighbours from a sorted list.
+func dedupe(times []time.Time) []time.Time {
+	out := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package calendar
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidToken means a feed token is malformed or was not issued with the current secret.
+var ErrInvalidToken = errors.New("invalid feed token")
+
+// FeedTokens issues and verifies the tokens that authenticate calendar feed URLs.
+// Calendar clients cannot send bearer tokens, so the feed URL carries a token
+// binding it to a user. Rotating the secret revokes every issued token.
+type FeedTokens struct {
+	secret []byte
+}
+
+// NewFeedTokens creates a token issuer with an HMAC secret.
+func NewFeedTokens(secret string) *FeedTokens {
+	return &FeedTokens{secret: []byte(secret)}
+}
+
+// Token returns the feed token for a user: base64url(userID) "." base64url(HMAC).
+func (f *FeedTokens) Token(userID string) string {
+	return encode([]byte(userID)) + "." + encode(f.sign(userID))
+}
+
+// Verify checks a feed token and returns the user it was issued for.
+func (f *FeedTokens) Verify(token string) (string, error) {
+	rawUser, rawSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	userID, err := base64.RawURLEncoding.DecodeString(rawUser)
+	if err != nil || len(userID) == 0 {
+		return "", ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(rawSig)
+	if err != nil || !hmac.Equal(sig, f.sign(string(userID))) {
+		return "", ErrInvalidToken
+	}
+	return string(userID), nil
+}
+
+// sign returns the HMAC of a user ID, scoped to calendar feeds.
+func (f *FeedTokens) sign(userID string) []byte {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write([]byte("calendar-feed:" + userID))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
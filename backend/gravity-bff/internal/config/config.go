@@ -4,6 +4,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -15,6 +16,14 @@ type Config struct {
 	Database DatabaseConfig
 	Redis    RedisConfig
 	Cache    CacheConfig
+	Events   EventsConfig
+	Ingest   IngestConfig
+	Calendar CalendarConfig
+	Scoring  ScoringConfig
+	Snooze   SnoozeConfig
+	FollowUp FollowUpConfig
+	Trash    TrashConfig
+	Insights InsightsConfig
 }
 
 // ServerConfig holds HTTP server configuration.
@@ -53,6 +62,82 @@ type CacheConfig struct {
 	DefaultTTL time.Duration
 	StreamTTL  time.Duration
 	ItemTTL    time.Duration
+	// CountsTTL bounds how long incrementally updated stream counts are
+	// trusted before they are recomputed from the database.
+	CountsTTL time.Duration
+	// BriefingTTL bounds how long a day's briefing is reused. Briefings are
+	// also dropped whenever the user's stream pages are invalidated.
+	BriefingTTL time.Duration
+}
+
+// EventsConfig holds live update (SSE) configuration.
+type EventsConfig struct {
+	HistorySize       int64
+	HistoryTTL        time.Duration
+	HeartbeatInterval time.Duration
+}
+
+// IngestConfig holds webhook ingestion configuration.
+type IngestConfig struct {
+	// Secrets maps a source type to the HMAC secret its webhooks are signed with.
+	// Only sources with a secret accept deliveries.
+	Secrets map[string]string
+}
+
+// CalendarConfig holds calendar import and feed configuration.
+type CalendarConfig struct {
+	// FeedSecret signs calendar feed tokens. Feeds are disabled when empty.
+	FeedSecret string
+	// FeedPast and FeedFuture bound the events included in a feed.
+	FeedPast   time.Duration
+	FeedFuture time.Duration
+	// RecurrenceHorizon bounds how far ahead recurring events are expanded on import.
+	RecurrenceHorizon time.Duration
+}
+
+// ScoringConfig holds priority scoring configuration.
+type ScoringConfig struct {
+	// Interval is how often stored scores are recomputed. Recency decays over
+	// time, so items are rescored once they were last scored an interval ago.
+	Interval time.Duration
+	// BatchSize is the number of items scored per query.
+	BatchSize int
+}
+
+// SnoozeConfig holds snoozed item configuration.
+type SnoozeConfig struct {
+	// WakeInterval is how often due snoozed items are woken up.
+	WakeInterval time.Duration
+	// BatchSize is the number of items woken per query.
+	BatchSize int
+}
+
+// FollowUpConfig holds follow-up reminder configuration.
+type FollowUpConfig struct {
+	// Interval is how often items whose follow-up is due are resurfaced.
+	Interval time.Duration
+	// BatchSize is the number of items resurfaced per query.
+	BatchSize int
+}
+
+// TrashConfig holds trashed item configuration.
+type TrashConfig struct {
+	// Retention is how long trashed items are kept before they are purged.
+	Retention time.Duration
+	// PurgeInterval is how often expired trash is purged.
+	PurgeInterval time.Duration
+	// BatchSize is the number of items purged per query.
+	BatchSize int
+}
+
+// InsightsConfig holds AI insight generation configuration.
+type InsightsConfig struct {
+	// Provider names the insights backend; "rules" needs no network access.
+	Provider string
+	// Interval is how often items queued after ingest get their insights.
+	Interval time.Duration
+	// BatchSize is the number of queued items processed per run.
+	BatchSize int
 }
 
 // ConnectionString returns the PostgreSQL connection string.
@@ -117,15 +202,67 @@ func Load() (*Config, error) {
 			PoolSize: v.GetInt("REDIS_POOL_SIZE"),
 		},
 		Cache: CacheConfig{
-			DefaultTTL: v.GetDuration("CACHE_DEFAULT_TTL"),
-			StreamTTL:  v.GetDuration("CACHE_STREAM_TTL"),
-			ItemTTL:    v.GetDuration("CACHE_ITEM_TTL"),
+			DefaultTTL:  v.GetDuration("CACHE_DEFAULT_TTL"),
+			StreamTTL:   v.GetDuration("CACHE_STREAM_TTL"),
+			ItemTTL:     v.GetDuration("CACHE_ITEM_TTL"),
+			CountsTTL:   v.GetDuration("CACHE_COUNTS_TTL"),
+			BriefingTTL: v.GetDuration("CACHE_BRIEFING_TTL"),
+		},
+		Events: EventsConfig{
+			HistorySize:       v.GetInt64("EVENTS_HISTORY_SIZE"),
+			HistoryTTL:        v.GetDuration("EVENTS_HISTORY_TTL"),
+			HeartbeatInterval: v.GetDuration("EVENTS_HEARTBEAT_INTERVAL"),
+		},
+		Ingest: IngestConfig{
+			Secrets: parsePairs(v.GetString("INGEST_SECRETS")),
+		},
+		Calendar: CalendarConfig{
+			FeedSecret:        v.GetString("CALENDAR_FEED_SECRET"),
+			FeedPast:          v.GetDuration("CALENDAR_FEED_PAST"),
+			FeedFuture:        v.GetDuration("CALENDAR_FEED_FUTURE"),
+			RecurrenceHorizon: v.GetDuration("CALENDAR_RECURRENCE_HORIZON"),
+		},
+		Scoring: ScoringConfig{
+			Interval:  v.GetDuration("SCORING_INTERVAL"),
+			BatchSize: v.GetInt("SCORING_BATCH_SIZE"),
+		},
+		Snooze: SnoozeConfig{
+			WakeInterval: v.GetDuration("SNOOZE_WAKE_INTERVAL"),
+			BatchSize:    v.GetInt("SNOOZE_BATCH_SIZE"),
+		},
+		FollowUp: FollowUpConfig{
+			Interval:  v.GetDuration("FOLLOW_UP_INTERVAL"),
+			BatchSize: v.GetInt("FOLLOW_UP_BATCH_SIZE"),
+		},
+		Trash: TrashConfig{
+			Retention:     v.GetDuration("TRASH_RETENTION"),
+			PurgeInterval: v.GetDuration("TRASH_PURGE_INTERVAL"),
+			BatchSize:     v.GetInt("TRASH_PURGE_BATCH_SIZE"),
+		},
+		Insights: InsightsConfig{
+			Provider:  v.GetString("INSIGHTS_PROVIDER"),
+			Interval:  v.GetDuration("INSIGHTS_INTERVAL"),
+			BatchSize: v.GetInt("INSIGHTS_BATCH_SIZE"),
 		},
 	}
 
 	return cfg, nil
 }
 
+// parsePairs parses a comma-separated list of key=value pairs.
+// Malformed entries are skipped.
+func parsePairs(raw string) map[string]string {
+	pairs := make(map[string]string)
+	for _, entry := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || key == "" || value == "" {
+			continue
+		}
+		pairs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return pairs
+}
+
 // setDefaults sets sensible default values for all configuration options.
 func setDefaults(v *viper.Viper) {
 	// Server defaults
@@ -157,4 +294,39 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("CACHE_DEFAULT_TTL", "5m")
 	v.SetDefault("CACHE_STREAM_TTL", "2m")
 	v.SetDefault("CACHE_ITEM_TTL", "5m")
+	v.SetDefault("CACHE_COUNTS_TTL", "10m")
+	v.SetDefault("CACHE_BRIEFING_TTL", "5m")
+
+	// Events defaults - history bounds how far back Last-Event-ID can resume
+	v.SetDefault("EVENTS_HISTORY_SIZE", 1000)
+	v.SetDefault("EVENTS_HISTORY_TTL", "24h")
+	v.SetDefault("EVENTS_HEARTBEAT_INTERVAL", "15s")
+
+	// Calendar defaults - feeds cover the last 30 days and the next year
+	v.SetDefault("CALENDAR_FEED_SECRET", "")
+	v.SetDefault("CALENDAR_FEED_PAST", "720h")
+	v.SetDefault("CALENDAR_FEED_FUTURE", "8760h")
+	v.SetDefault("CALENDAR_RECURRENCE_HORIZON", "2160h")
+
+	// Scoring defaults
+	v.SetDefault("SCORING_INTERVAL", "15m")
+	v.SetDefault("SCORING_BATCH_SIZE", 500)
+
+	// Snooze defaults
+	v.SetDefault("SNOOZE_WAKE_INTERVAL", "1m")
+	v.SetDefault("SNOOZE_BATCH_SIZE", 500)
+
+	// Follow-up defaults
+	v.SetDefault("FOLLOW_UP_INTERVAL", "5m")
+	v.SetDefault("FOLLOW_UP_BATCH_SIZE", 500)
+
+	// Trash defaults
+	v.SetDefault("TRASH_RETENTION", "720h") // 30 days
+	v.SetDefault("TRASH_PURGE_INTERVAL", "1h")
+	v.SetDefault("TRASH_PURGE_BATCH_SIZE", 500)
+
+	// Insights defaults
+	v.SetDefault("INSIGHTS_PROVIDER", "rules")
+	v.SetDefault("INSIGHTS_INTERVAL", "15s")
+	v.SetDefault("INSIGHTS_BATCH_SIZE", 50)
 }
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// BriefingSectionLimit is the maximum number of items in each section of a briefing.
+const BriefingSectionLimit = 20
+
+// BriefingEvent is one of the calendar events on a briefing's day.
+type BriefingEvent struct {
+	ItemID    string    `json:"itemId"`
+	MessageID string    `json:"messageId"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"startTime"` // In the briefing's time zone
+	EndTime   time.Time `json:"endTime"`
+	Conflicts *string   `json:"conflicts,omitempty"` // Describes the events it overlaps, if any
+}
+
+// BriefingResponse summarizes what needs a user's attention on a day.
+type BriefingResponse struct {
+	Date         string          `json:"date"`         // YYYY-MM-DD
+	TimeZone     string          `json:"timeZone"`     // IANA name the day is taken in
+	Events       []BriefingEvent `json:"events"`       // Events overlapping the day, by start time
+	HighPriority []PriorityItem  `json:"highPriority"` // Unread high priority items, pinned first
+	WakingToday  []PriorityItem  `json:"wakingToday"`  // Snoozed items waking before the day ends, by wake time
+	NeedsReply   []PriorityItem  `json:"needsReply"`   // Conversations awaiting the user's reply, pinned first
+	GeneratedAt  time.Time       `json:"generatedAt"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// CalendarFeedResponse describes a user's subscribable calendar feed.
+type CalendarFeedResponse struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// EventSlot is when one of a user's calendar events takes place.
+type EventSlot struct {
+	MessageID string
+	ItemID    string
+	EventID   string
+	Title     string
+	StartTime time.Time
+	EndTime   time.Time
+	Conflicts *AIInsight // The stored insight listing the events it overlaps, if any
+}
+
+// ConflictInsightID returns the ID of the insight on an event's message that
+// lists the events it overlaps. Regenerating a message's insights keeps it.
+func ConflictInsightID(messageID string) string {
+	return messageID + "-conflicts"
+}
+
+// BusyPeriod is a span of time taken by one or more events.
+type BusyPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// FreeBusyResponse lists when a user is busy within a time range.
+type FreeBusyResponse struct {
+	From time.Time    `json:"from"`
+	To   time.Time    `json:"to"`
+	Busy []BusyPeriod `json:"busy"` // Merged and clipped to [from, to), in order
+}
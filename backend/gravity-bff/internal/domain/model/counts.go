@@ -0,0 +1,91 @@
+package model
+
+// StreamCounts holds the item counts of a slice of the default stream.
+type StreamCounts struct {
+	Total  int `json:"total"`
+	Unread int `json:"unread"`
+	High   int `json:"high"`
+}
+
+// IsZero reports whether every count is zero.
+func (c StreamCounts) IsZero() bool {
+	return c.Total == 0 && c.Unread == 0 && c.High == 0
+}
+
+// CountsResponse represents the response for the stream counts endpoint.
+// Only items in the default stream are counted: archived, trashed and
+// snoozed items are not. Sources and labels without items are omitted.
+type CountsResponse struct {
+	StreamCounts
+	Sources map[SourceType]StreamCounts `json:"sources"`
+	Labels  map[string]StreamCounts     `json:"labels"`
+}
+
+// NewCountsResponse creates an empty counts response.
+func NewCountsResponse() *CountsResponse {
+	return &CountsResponse{
+		Sources: map[SourceType]StreamCounts{},
+		Labels:  map[string]StreamCounts{},
+	}
+}
+
+// CountedItem is the part of an item's state that the stream counts depend on.
+type CountedItem struct {
+	Source   SourceType
+	Unread   bool
+	High     bool
+	LabelIDs []string
+}
+
+// CountChange records how a mutation changed an item's counted state.
+// Before or After is nil when the item was not, or is no longer, in the default stream.
+type CountChange struct {
+	ItemID string
+	Before *CountedItem
+	After  *CountedItem
+}
+
+// Add adds n to every count the item contributes to. Use n = -1 to remove it.
+func (c *CountsResponse) Add(item CountedItem, n int) {
+	c.StreamCounts = item.add(c.StreamCounts, n)
+	c.Sources[item.Source] = item.add(c.Sources[item.Source], n)
+	for _, labelID := range item.LabelIDs {
+		c.Labels[labelID] = item.add(c.Labels[labelID], n)
+	}
+}
+
+// Apply adds the difference a change made to the counts.
+func (c *CountsResponse) Apply(change CountChange) {
+	if change.Before != nil {
+		c.Add(*change.Before, -1)
+	}
+	if change.After != nil {
+		c.Add(*change.After, 1)
+	}
+}
+
+// Compact removes the sources and labels whose counts are all zero.
+func (c *CountsResponse) Compact() {
+	for source, counts := range c.Sources {
+		if counts.IsZero() {
+			delete(c.Sources, source)
+		}
+	}
+	for labelID, counts := range c.Labels {
+		if counts.IsZero() {
+			delete(c.Labels, labelID)
+		}
+	}
+}
+
+// add returns counts with n added for the item.
+func (item CountedItem) add(counts StreamCounts, n int) StreamCounts {
+	counts.Total += n
+	if item.Unread {
+		counts.Unread += n
+	}
+	if item.High {
+		counts.High += n
+	}
+	return counts
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+)
+
+// Contact identifies a person in a source system.
+// Contacts are matched to users by email, or by ExternalID when there is no email.
+type Contact struct {
+	Name       string  `json:"name"`
+	Email      *string `json:"email,omitempty"`
+	AvatarURL  *string `json:"avatar,omitempty"`
+	ExternalID *string `json:"externalId,omitempty"` // Source-qualified, e.g. "slack:U024BE7LH"
+}
+
+// IngestMessage is a normalized message received from a source connector.
+type IngestMessage struct {
+	ExternalID      string         `json:"externalId"`
+	From            *Contact       `json:"from,omitempty"`
+	SenderType      SenderType     `json:"sender"`
+	Content         string         `json:"content"`
+	Timestamp       time.Time      `json:"timestamp"`
+	ContentType     ContentType    `json:"type"`
+	EventDetails    *CalendarEvent `json:"eventDetails,omitempty"`
+	SocialContent   *SocialContent `json:"socialContent,omitempty"`
+	Attachments     []Attachment   `json:"attachments,omitempty"`
+	FullContentHTML *string        `json:"fullContent,omitempty"`
+}
+
+// IngestItem is a normalized priority item received from a source connector.
+// Items are upserted by (UserID, Source, ExternalID).
+type IngestItem struct {
+	UserID       string          `json:"userId"` // Owner, as a Clerk user ID
+	Source       SourceType      `json:"source"`
+	ExternalID   string          `json:"externalId"`
+	Title        string          `json:"title"`
+	Priority     Priority        `json:"priority,omitempty"`
+	IsUnread     bool            `json:"unread"`
+	Snippet      *string         `json:"snippet,omitempty"`
+	Timestamp    time.Time       `json:"timestamp"`
+	Participants []Contact       `json:"participants,omitempty"`
+	Messages     []IngestMessage `json:"messages,omitempty"`
+}
+
+// IngestResult reports the outcome of upserting an ingested item.
+type IngestResult struct {
+	ItemID      string   `json:"itemId"`
+	ExternalID  string   `json:"externalId"`
+	Created     bool     `json:"created"`
+	NewMessages []string `json:"newMessageIds"`
+
+	// CountedBefore is the item's counted state before the upsert;
+	// nil if it was created or not in the default stream.
+	CountedBefore *CountedItem `json:"-"`
+}
+
+// IngestResponse represents the response for a webhook delivery.
+type IngestResponse struct {
+	Results []IngestResult `json:"results"`
+}
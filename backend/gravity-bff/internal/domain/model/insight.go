@@ -0,0 +1,33 @@
+package model
+
+// InsightThread is the part of a priority item that insights are generated from.
+type InsightThread struct {
+	ItemID   string
+	UserID   string
+	Title    string
+	Source   SourceType
+	Messages []Message // The latest messages, oldest first
+}
+
+// Latest returns the thread's latest message, or nil if it has none.
+func (t *InsightThread) Latest() *Message {
+	if len(t.Messages) == 0 {
+		return nil
+	}
+	return &t.Messages[len(t.Messages)-1]
+}
+
+// InsightsResponse represents the insights stored on an item's latest message.
+type InsightsResponse struct {
+	MessageID string      `json:"messageId,omitempty"` // Empty if the item has no messages
+	Data      []AIInsight `json:"data"`
+}
+
+// DraftRequest identifies a draft reply insight and, for refinements, how to change it.
+type DraftRequest struct {
+	UserID      string `json:"-"`                     // Extracted from auth token
+	ItemID      string `json:"-"`                     // The item ID from URL path
+	MessageID   string `json:"-"`                     // The message ID from URL path
+	InsightID   string `json:"-"`                     // The insight ID from URL path
+	Instruction string `json:"instruction,omitempty"` // How to refine the draft, e.g. "shorter"
+}
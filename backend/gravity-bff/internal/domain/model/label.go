@@ -0,0 +1,25 @@
+package model
+
+// Label is a user-defined tag for organizing priority items.
+type Label struct {
+	ID     string  `json:"id"`
+	UserID string  `json:"-"`
+	Name   string  `json:"name"`
+	Color  *string `json:"color,omitempty"` // Hex color, e.g. #4f46e5
+}
+
+// LabelRequest represents a request to create or replace a label.
+type LabelRequest struct {
+	Name  string  `json:"name"`
+	Color *string `json:"color"` // Optional
+}
+
+// LabelsResponse represents a list of labels.
+type LabelsResponse struct {
+	Data []Label `json:"data"`
+}
+
+// ItemLabelsRequest represents a request to apply labels to a priority item.
+type ItemLabelsRequest struct {
+	LabelIDs []string `json:"labelIds"`
+}
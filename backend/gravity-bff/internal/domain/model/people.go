@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// PersonRecentItems is the number of recent items shown on a person's profile.
+const PersonRecentItems = 10
+
+// Person is someone the user interacts with: a participant in their items.
+type Person struct {
+	User
+	ItemCount       int       `json:"itemCount"`       // The user's items they take part in, excluding trashed items
+	LastInteraction time.Time `json:"lastInteraction"` // Latest activity among those items
+	VIP             bool      `json:"vip"`
+	Rank            float64   `json:"-"` // Ranking by frequency and recency, for pagination
+}
+
+// PeopleRequest represents the query parameters for listing the user's people.
+type PeopleRequest struct {
+	UserID  string  `json:"-"`       // Extracted from auth token
+	VIPOnly bool    `json:"vipOnly"` // Only people marked as VIP
+	Limit   int     `json:"limit"`
+	Cursor  *string `json:"cursor,omitempty"`
+}
+
+// PeopleResponse is a page of the user's people, most frequent and recent first.
+type PeopleResponse struct {
+	Data       []Person `json:"data"`
+	NextCursor *string  `json:"nextCursor"`
+}
+
+// PersonResponse is a person's profile: who they are and the latest items they take part in.
+type PersonResponse struct {
+	Person
+	RecentItems []PriorityItem `json:"recentItems"` // Newest first, from every source
+}
@@ -1,25 +1,123 @@
 package model
 
-// StreamFilter represents filter options for the stream endpoint.
-type StreamFilter string
+import (
+	"sort"
+	"time"
+)
+
+// FilterPreset is a named shortcut for a commonly used stream filter.
+type FilterPreset string
 
 const (
-	FilterAll    StreamFilter = "all"
-	FilterHigh   StreamFilter = "high"
-	FilterUnread StreamFilter = "unread"
+	FilterAll           FilterPreset = "all"
+	FilterHigh          FilterPreset = "high"
+	FilterUnread        FilterPreset = "unread"
+	FilterSnoozed       FilterPreset = "snoozed"
+	FilterArchived      FilterPreset = "archived"
+	FilterTrash         FilterPreset = "trash"
+	FilterAwaitingReply FilterPreset = "awaiting_reply" // The user wrote last
+	FilterNeedsMyReply  FilterPreset = "needs_my_reply" // Someone else wrote last
 )
 
+// StreamFilter describes which priority items a stream request returns.
+// Every set field must match; list fields match any of their values.
+// Snoozed, archived and trashed items are only returned, exclusively, when
+// Snoozed, Archived or Trashed is set; trashed items take precedence.
+// LastSender matches conversations whose latest non-system message is from that sender.
+type StreamFilter struct {
+	Sources        []SourceType `json:"sources,omitempty"`
+	Priorities     []Priority   `json:"priorities,omitempty"`
+	Unread         *bool        `json:"unread,omitempty"`
+	Since          *time.Time   `json:"since,omitempty"` // Inclusive lower bound on item timestamp
+	Until          *time.Time   `json:"until,omitempty"` // Exclusive upper bound on item timestamp
+	ParticipantID  *string      `json:"participantId,omitempty"`
+	HasAttachments *bool        `json:"hasAttachments,omitempty"`
+	Labels         []string     `json:"labels,omitempty"` // Label IDs
+	Snoozed        bool         `json:"snoozed,omitempty"`
+	Archived       bool         `json:"archived,omitempty"`
+	Trashed        bool         `json:"trashed,omitempty"`
+	WakesBefore    *time.Time   `json:"wakesBefore,omitempty"` // Exclusive upper bound on snoozedUntil
+	LastSender     SenderType   `json:"lastSender,omitempty"`
+}
+
+// Normalize returns a canonical copy of the filter: list values are sorted and
+// de-duplicated and times are converted to UTC, so equivalent filters compare
+// and serialize identically.
+func (f StreamFilter) Normalize() StreamFilter {
+	out := f
+	out.Sources = sortedUnique(f.Sources)
+	out.Priorities = sortedUnique(f.Priorities)
+	out.Labels = sortedUnique(f.Labels)
+	if f.Since != nil {
+		since := f.Since.UTC()
+		out.Since = &since
+	}
+	if f.Until != nil {
+		until := f.Until.UTC()
+		out.Until = &until
+	}
+	if f.WakesBefore != nil {
+		wakesBefore := f.WakesBefore.UTC()
+		out.WakesBefore = &wakesBefore
+	}
+	return out
+}
+
+// sortedUnique returns the sorted, de-duplicated values, or nil if there are none.
+func sortedUnique[T ~string](values []T) []T {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]T, 0, len(values))
+	seen := make(map[T]struct{}, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
 // StreamRequest represents the query parameters for fetching the stream.
 type StreamRequest struct {
-	UserID string       `json:"-"`              // Extracted from auth token
-	Filter StreamFilter `json:"filter"`         // all, high, unread
-	Limit  int          `json:"limit"`          // Max items to return (default: 20, max: 100)
-	Cursor *string      `json:"cursor"`         // Pagination cursor
+	UserID string       `json:"-"`      // Extracted from auth token
+	Filter StreamFilter `json:"filter"` // Composable item filter
+	Sort   StreamSort   `json:"sort"`   // Item order (default: recent)
+	Limit  int          `json:"limit"`  // Max items to return (default: 20, max: 100)
+	Cursor *string      `json:"cursor"` // Pagination cursor
 }
 
+// StreamSort selects the order of the stream.
+type StreamSort string
+
+const (
+	// SortRecent orders items by latest activity.
+	SortRecent StreamSort = "recent"
+	// SortScore orders items by priority score, then latest activity.
+	SortScore StreamSort = "score"
+)
+
 // StreamResponse represents the paginated response for the stream endpoint.
 type StreamResponse struct {
-	Data       []PriorityItem `json:"data"`
+	Pinned     []PriorityItem `json:"pinned,omitempty"` // First page only, in pin order
+	Data       []PriorityItem `json:"data"`             // Excludes pinned items
+	NextCursor *string        `json:"nextCursor"`
+}
+
+// SearchRequest represents the query parameters for searching the stream.
+type SearchRequest struct {
+	UserID string  `json:"-"`      // Extracted from auth token
+	Query  string  `json:"q"`      // Free-text search query
+	Limit  int     `json:"limit"`  // Max results to return (default: 20, max: 100)
+	Cursor *string `json:"cursor"` // Pagination cursor
+}
+
+// SearchResponse represents the paginated, ranked response for a stream search.
+type SearchResponse struct {
+	Data       []SearchResult `json:"data"`
 	NextCursor *string        `json:"nextCursor"`
 }
 
@@ -29,6 +127,133 @@ type StreamItemRequest struct {
 	ItemID string `json:"itemId"` // The item ID from URL path
 }
 
+// ItemMessagesLimit is the number of latest messages included in an item's detail view.
+const ItemMessagesLimit = 20
+
+// MessagesRequest represents the query parameters for a page of an item's messages.
+type MessagesRequest struct {
+	UserID string  `json:"-"`      // Extracted from auth token
+	ItemID string  `json:"itemId"` // The item ID from URL path
+	Before *string `json:"before"` // Pagination cursor; nil for the latest messages
+	Limit  int     `json:"limit"`  // Max messages to return (default: 50, max: 200)
+}
+
+// MessagesResponse represents a page of an item's messages, oldest first.
+type MessagesResponse struct {
+	Data       []Message `json:"data"`
+	NextCursor *string   `json:"nextCursor"` // Pass as before for older messages
+}
+
+// ReadStateRequest represents a request to change the read state of items.
+type ReadStateRequest struct {
+	UserID  string   `json:"-"`       // Extracted from auth token
+	ItemIDs []string `json:"itemIds"` // Items to update
+	Unread  bool     `json:"-"`       // Target state, derived from the route
+}
+
+// ReadStateResponse reports which items had their read state changed.
+type ReadStateResponse struct {
+	ItemIDs []string `json:"itemIds"`
+	Unread  bool     `json:"unread"`
+}
+
+// ItemState is where an item lives: the stream, the archive or the trash.
+type ItemState string
+
+const (
+	ItemActive   ItemState = "active"
+	ItemArchived ItemState = "archived"
+	ItemTrashed  ItemState = "trashed"
+)
+
+// ItemStateRequest represents a request to archive, trash or restore items.
+type ItemStateRequest struct {
+	UserID  string    `json:"-"`       // Extracted from auth token
+	ItemIDs []string  `json:"itemIds"` // Items to update
+	State   ItemState `json:"-"`       // Target state, derived from the route
+}
+
+// ItemStateResponse reports which items were moved to a state.
+type ItemStateResponse struct {
+	ItemIDs []string  `json:"itemIds"`
+	State   ItemState `json:"state"`
+}
+
+// PinOrderRequest represents a request to reorder a user's pinned items.
+type PinOrderRequest struct {
+	ItemIDs []string `json:"itemIds"` // Every pinned item, in the new order
+}
+
+// PinsResponse lists a user's pinned items in pin order.
+type PinsResponse struct {
+	ItemIDs []string `json:"itemIds"`
+}
+
+// SnoozeRequest represents a request to hide an item from the stream until a time.
+type SnoozeRequest struct {
+	UserID string     `json:"-"`     // Extracted from auth token
+	ItemID string     `json:"-"`     // The item ID from URL path
+	Until  *time.Time `json:"until"` // When the item wakes up (RFC 3339)
+}
+
+// SnoozeResponse reports an item's snooze state. SnoozedUntil is nil once unsnoozed.
+type SnoozeResponse struct {
+	ItemID       string     `json:"itemId"`
+	SnoozedUntil *time.Time `json:"snoozedUntil"`
+}
+
+// FollowUpRequest represents a request to resurface an item if nobody replies within some days.
+type FollowUpRequest struct {
+	UserID string `json:"-"`    // Extracted from auth token
+	ItemID string `json:"-"`    // The item ID from URL path
+	Days   int    `json:"days"` // Days to wait for a reply
+}
+
+// FollowUpResponse reports an item's follow-up. FollowUpAt is nil once cleared.
+type FollowUpResponse struct {
+	ItemID        string     `json:"itemId"`
+	FollowUpAt    *time.Time `json:"followUpAt"`
+	AwaitingReply bool       `json:"awaitingReply"` // Whether the user wrote last
+}
+
+// ItemRef identifies an item and its owner.
+type ItemRef struct {
+	ItemID string
+	UserID string
+}
+
+// SendMessageRequest represents a reply sent into a priority item's thread.
+type SendMessageRequest struct {
+	UserID  string `json:"-"`       // Extracted from auth token
+	ItemID  string `json:"-"`       // The item ID from URL path
+	Content string `json:"content"` // Message body
+}
+
+// RescheduleEventRequest moves the calendar event in a message to new times.
+type RescheduleEventRequest struct {
+	UserID    string     `json:"-"`         // Extracted from auth token
+	ItemID    string     `json:"-"`         // The item ID from URL path
+	MessageID string     `json:"-"`         // The message ID from URL path
+	StartTime *time.Time `json:"startTime"` // New start time
+	EndTime   *time.Time `json:"endTime"`   // New end time, after the start
+}
+
+// RSVPRequest records an attendee's response to the calendar event in a message.
+type RSVPRequest struct {
+	UserID    string     `json:"-"`        // Extracted from auth token
+	ItemID    string     `json:"-"`        // The item ID from URL path
+	MessageID string     `json:"-"`        // The message ID from URL path
+	Attendee  string     `json:"attendee"` // The attendee's user ID or email
+	Status    RSVPStatus `json:"status"`   // accepted, declined or tentative
+}
+
+// EventUpdateResponse represents a changed calendar event and the system
+// message appended to its thread to record the change.
+type EventUpdateResponse struct {
+	Event   CalendarEvent `json:"event"`
+	Message Message       `json:"message"`
+}
+
 // HealthResponse represents the health check response.
 type HealthResponse struct {
 	Status    string `json:"status"`
@@ -64,4 +289,5 @@ const (
 	ErrCodeNotFound         = "resource_not_found"
 	ErrCodeInternalError    = "internal_error"
 	ErrCodeValidationFailed = "validation_failed"
+	ErrCodeConflict         = "conflict"
 )
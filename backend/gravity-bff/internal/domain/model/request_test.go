@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -35,10 +36,32 @@ func TestErrorResponse_JSON(t *testing.T) {
 	assert.Equal(t, "Resource not found", errorObj["message"])
 }
 
-func TestStreamFilter_Constants(t *testing.T) {
-	assert.Equal(t, StreamFilter("all"), FilterAll)
-	assert.Equal(t, StreamFilter("high"), FilterHigh)
-	assert.Equal(t, StreamFilter("unread"), FilterUnread)
+func TestFilterPreset_Constants(t *testing.T) {
+	assert.Equal(t, FilterPreset("all"), FilterAll)
+	assert.Equal(t, FilterPreset("high"), FilterHigh)
+	assert.Equal(t, FilterPreset("unread"), FilterUnread)
+}
+
+func TestStreamFilter_Normalize(t *testing.T) {
+	// Arrange
+	since := time.Date(2026, 1, 5, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	filter := StreamFilter{
+		Sources:    []SourceType{SourceSlack, SourceEmail, SourceSlack},
+		Priorities: []Priority{},
+		Since:      &since,
+	}
+
+	// Act
+	result := filter.Normalize()
+
+	// Assert
+	assert.Equal(t, []SourceType{SourceEmail, SourceSlack}, result.Sources)
+	assert.Nil(t, result.Priorities)
+	assert.Equal(t, time.UTC, result.Since.Location())
+	assert.True(t, since.Equal(*result.Since))
+
+	// Original is left untouched
+	assert.Equal(t, []SourceType{SourceSlack, SourceEmail, SourceSlack}, filter.Sources)
 }
 
 func TestErrorCode_Constants(t *testing.T) {
@@ -55,7 +78,7 @@ func TestStreamRequest_Defaults(t *testing.T) {
 	req := StreamRequest{}
 
 	assert.Equal(t, "", req.UserID)
-	assert.Equal(t, StreamFilter(""), req.Filter)
+	assert.Equal(t, StreamFilter{}, req.Filter)
 	assert.Equal(t, 0, req.Limit)
 	assert.Nil(t, req.Cursor)
 }
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"time"
+)
+
+// RuleMatch selects whether all or any of a rule's conditions must hold.
+type RuleMatch string
+
+const (
+	MatchAll RuleMatch = "all"
+	MatchAny RuleMatch = "any"
+)
+
+// RuleField names an item or message field a condition tests.
+// Message fields match when any of the item's messages satisfies the condition.
+type RuleField string
+
+const (
+	FieldSource         RuleField = "source"
+	FieldTitle          RuleField = "title"
+	FieldPriority       RuleField = "priority"
+	FieldUnread         RuleField = "unread"
+	FieldSnippet        RuleField = "snippet"
+	FieldHasAttachments RuleField = "hasAttachments"
+	FieldMessageSender  RuleField = "message.sender"
+	FieldMessageType    RuleField = "message.type"
+	FieldMessageContent RuleField = "message.content"
+	FieldMessageFrom    RuleField = "message.from.name"
+	FieldMessageEmail   RuleField = "message.from.email"
+)
+
+// RuleOp is a condition operator.
+type RuleOp string
+
+const (
+	OpEq          RuleOp = "eq"
+	OpNeq         RuleOp = "neq"
+	OpIn          RuleOp = "in"
+	OpContains    RuleOp = "contains"
+	OpNotContains RuleOp = "not_contains"
+	OpStartsWith  RuleOp = "starts_with"
+	OpEndsWith    RuleOp = "ends_with"
+	OpMatches     RuleOp = "matches"
+)
+
+// RuleActionType is an action applied to matching items.
+type RuleActionType string
+
+const (
+	ActionSetPriority RuleActionType = "set_priority"
+	ActionMarkRead    RuleActionType = "mark_read"
+	ActionMarkUnread  RuleActionType = "mark_unread"
+)
+
+// RuleCondition tests one field. Value is a string, a boolean for boolean
+// fields, or a list of strings for the in operator.
+type RuleCondition struct {
+	Field RuleField   `json:"field"`
+	Op    RuleOp      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+// RuleAction changes a matching item.
+type RuleAction struct {
+	Type     RuleActionType `json:"type"`
+	Priority Priority       `json:"priority,omitempty"` // For set_priority
+}
+
+// Rule is a user-defined triage rule: items matching its conditions get its actions.
+type Rule struct {
+	ID         string          `json:"id"`
+	UserID     string          `json:"-"`
+	Name       string          `json:"name"`
+	Enabled    bool            `json:"enabled"`
+	Position   int             `json:"position"` // Rules apply in ascending order; later rules win
+	Match      RuleMatch       `json:"match"`
+	Conditions []RuleCondition `json:"conditions"`
+	Actions    []RuleAction    `json:"actions"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	UpdatedAt  time.Time       `json:"updatedAt"`
+}
+
+// RuleEffect is the combined result of the rules matching an item.
+// Nil fields are left unchanged.
+type RuleEffect struct {
+	RuleIDs  []string  // Matching rules, in order
+	Priority *Priority // Priority override
+	Unread   *bool
+}
+
+// IsEmpty reports whether the effect changes nothing.
+func (e RuleEffect) IsEmpty() bool {
+	return e.Priority == nil && e.Unread == nil
+}
+
+// RuleRequest is the body for creating, replacing or dry-running a rule.
+type RuleRequest struct {
+	Name       string          `json:"name"`
+	Enabled    *bool           `json:"enabled"`  // Default: true
+	Position   *int            `json:"position"` // Default: after the user's other rules
+	Match      RuleMatch       `json:"match"`    // Default: all
+	Conditions []RuleCondition `json:"conditions"`
+	Actions    []RuleAction    `json:"actions"`
+}
+
+// RulesResponse lists a user's rules.
+type RulesResponse struct {
+	Data []Rule `json:"data"`
+}
+
+// RuleDryRunResponse reports the existing items a rule would match.
+type RuleDryRunResponse struct {
+	Matches   []PriorityItem `json:"matches"`
+	Scanned   int            `json:"scanned"`   // Items evaluated, most recent first
+	Truncated bool           `json:"truncated"` // More items exist than were scanned
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// ScoreSignals are the inputs used to score a priority item.
+type ScoreSignals struct {
+	ItemID           string
+	UserID           string
+	Source           SourceType
+	IsUnread         bool
+	LastActivity     time.Time  // The item's timestamp
+	SenderReplies    int        // Items shared with the latest sender in which the owner has replied
+	RecentContent    []string   // Latest messages from others, newest first
+	OwnerNames       []string   // Names and email addresses the owner is known by
+	NextEvent        *time.Time // Earliest upcoming event start among the item's messages
+	VIP              bool       // A person the owner marked as VIP takes part in the item
+	Score            float64    // Currently stored score
+	Priority         Priority   // Currently stored priority
+	PriorityOverride *Priority  // Priority set by a triage rule, which replaces the score's bucket
+}
+
+// ItemScore is a computed score for a priority item.
+type ItemScore struct {
+	ItemID           string
+	UserID           string
+	Score            float64
+	Priority         Priority
+	PreviousPriority Priority // Stored priority that this score replaces
+}
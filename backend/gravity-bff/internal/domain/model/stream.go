@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 )
 
@@ -31,6 +32,34 @@ type CalendarEvent struct {
 	Location    *string   `json:"location,omitempty"`
 	MeetingLink *string   `json:"meetingLink,omitempty"`
 	Description *string   `json:"description,omitempty"`
+	RSVPs       []RSVP    `json:"rsvps,omitempty"` // Attendee responses, in the order they first responded
+}
+
+// RSVP records an attendee's response to a calendar event.
+type RSVP struct {
+	Attendee    string     `json:"attendee"` // The attendee's key, see AttendeeKey
+	Status      RSVPStatus `json:"status"`
+	RespondedAt time.Time  `json:"respondedAt"`
+}
+
+// AttendeeKey identifies an attendee of an event: by user ID, or by lower-case
+// email for attendees without one, such as those of imported events.
+func AttendeeKey(attendee User) string {
+	if attendee.ID != "" || attendee.Email == nil {
+		return attendee.ID
+	}
+	return strings.ToLower(*attendee.Email)
+}
+
+// Response returns an attendee's response to the event, if they responded.
+func (e *CalendarEvent) Response(attendee User) (RSVPStatus, bool) {
+	key := AttendeeKey(attendee)
+	for _, rsvp := range e.RSVPs {
+		if rsvp.Attendee == key {
+			return rsvp.Status, true
+		}
+	}
+	return "", false
 }
 
 // SocialStats represents engagement statistics for social content.
@@ -56,11 +85,19 @@ type SocialContent struct {
 
 // AIInsight represents an AI-generated insight or suggestion.
 type AIInsight struct {
-	ID      string      `json:"id"`
-	Type    InsightType `json:"type"`
-	Label   string      `json:"label"`
-	Content string      `json:"content"`
-	IsDraft bool        `json:"isDraft"`
+	ID            string         `json:"id"`
+	Type          InsightType    `json:"type"`
+	Label         string         `json:"label"`
+	Content       string         `json:"content"`
+	IsDraft       bool           `json:"isDraft"`
+	Versions      []DraftVersion `json:"versions,omitempty"`      // Every version of a refined draft, oldest first
+	SentMessageID string         `json:"sentMessageId,omitempty"` // The message a draft was sent as
+}
+
+// DraftVersion is one version of a draft reply.
+type DraftVersion struct {
+	Content     string `json:"content"`
+	Instruction string `json:"instruction,omitempty"` // The refinement that produced it; empty if generated
 }
 
 // Message represents a single message in a conversation thread.
@@ -80,15 +117,26 @@ type Message struct {
 
 // PriorityItem represents a single item in the unified priority stream.
 type PriorityItem struct {
-	ID           string     `json:"id" db:"id"`
-	Title        string     `json:"title" db:"title"`
-	Source       SourceType `json:"source" db:"source"`
-	Priority     Priority   `json:"priority" db:"priority"`
-	IsUnread     bool       `json:"unread" db:"is_unread"`
-	Snippet      *string    `json:"snippet,omitempty" db:"snippet"`
-	Timestamp    time.Time  `json:"timestamp" db:"item_timestamp"`
-	Participants []User     `json:"participants"`
-	Messages     []Message  `json:"messages,omitempty"` // Only included in detail view
+	ID           string      `json:"id" db:"id"`
+	Title        string      `json:"title" db:"title"`
+	Source       SourceType  `json:"source" db:"source"`
+	Priority     Priority    `json:"priority" db:"priority"`
+	Score        float64     `json:"score" db:"score"` // 0-100; Priority is its bucket unless a rule sets it
+	IsUnread     bool        `json:"unread" db:"is_unread"`
+	Snippet      *string     `json:"snippet,omitempty" db:"snippet"`
+	Timestamp    time.Time   `json:"timestamp" db:"item_timestamp"`
+	SnoozedUntil *time.Time  `json:"snoozedUntil,omitempty" db:"snoozed_until"` // Hidden from the stream until then
+	ArchivedAt   *time.Time  `json:"archivedAt,omitempty" db:"archived_at"`
+	DeletedAt    *time.Time  `json:"deletedAt,omitempty" db:"deleted_at"`        // In the trash since then
+	Pinned       bool        `json:"pinned"`                                     // Shown in the stream's pinned section
+	LastSender   *SenderType `json:"lastSender,omitempty" db:"last_sender_type"` // Who wrote the latest non-system message
+	FollowUpAt   *time.Time  `json:"followUpAt,omitempty" db:"follow_up_at"`     // Resurfaces then unless someone else replies
+	Participants []User      `json:"participants"`
+	Labels       []Label     `json:"labels"`
+	Messages     []Message   `json:"messages,omitempty"` // Only included in detail view: the latest ItemMessagesLimit, oldest first
+
+	// Pass as before to GET /v2/stream/:itemId/messages for older messages; nil if there are none
+	MessagesCursor *string `json:"messagesCursor,omitempty"`
 }
 
 // PriorityItemWithMessages is the full detail view of a priority item.
@@ -96,3 +144,19 @@ type PriorityItemWithMessages struct {
 	PriorityItem
 	Messages []Message `json:"messages"`
 }
+
+// SearchHighlights contains matched fragments with terms wrapped in <mark> tags.
+// A field is only present when the query matched it.
+type SearchHighlights struct {
+	Title     *string `json:"title,omitempty"`
+	Snippet   *string `json:"snippet,omitempty"`
+	Message   *string `json:"message,omitempty"`
+	MessageID *string `json:"messageId,omitempty"`
+}
+
+// SearchResult is a priority item matched by a search query.
+type SearchResult struct {
+	PriorityItem
+	Rank       float64          `json:"rank"`
+	Highlights SearchHighlights `json:"highlights"`
+}
@@ -0,0 +1,27 @@
+package model
+
+// SyncRequest represents a request for the changes to a user's items.
+type SyncRequest struct {
+	UserID string `json:"-"`     // Extracted from auth token
+	Since  string `json:"since"` // Sync token from the previous response; empty for a full sync
+	Limit  int    `json:"limit"` // Max changes to return (default: 100, max: 500)
+}
+
+// SyncResponse represents the changes to a user's items since a sync token.
+// Items are reported in their current state, once, however often they changed.
+// When HasMore is set the client should call again with Token right away.
+type SyncResponse struct {
+	Created []PriorityItem `json:"created"`
+	Updated []PriorityItem `json:"updated"`
+	Deleted []string       `json:"deleted"` // IDs of permanently deleted items
+	Token   string         `json:"token"`   // Pass as since on the next sync
+	HasMore bool           `json:"hasMore"`
+}
+
+// ItemChange is an entry in a user's change feed.
+type ItemChange struct {
+	Seq     int64 // Change sequence number, increasing per user
+	ItemID  string
+	Created bool          // The item was created after the requested sequence number
+	Item    *PriorityItem // Current state; nil if the item was deleted
+}
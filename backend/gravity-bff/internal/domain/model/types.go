@@ -17,6 +17,28 @@ const (
 	SourceTwitter  SourceType = "twitter"
 )
 
+// SourceTypes lists every known source type.
+var SourceTypes = []SourceType{
+	SourceEmail, SourceWhatsApp, SourceSlack, SourceTeams, SourceCalendar,
+	SourceTask, SourceYouTube, SourceLinkedIn, SourceTwitter,
+}
+
+// ConversationSources lists the sources whose items are conversations the user
+// can reply to, as opposed to events, tasks and feeds.
+var ConversationSources = []SourceType{
+	SourceEmail, SourceWhatsApp, SourceSlack, SourceTeams, SourceLinkedIn, SourceTwitter,
+}
+
+// IsValid reports whether the source type is one of the known sources.
+func (s SourceType) IsValid() bool {
+	for _, known := range SourceTypes {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
 // Priority represents the urgency level of a priority item.
 type Priority string
 
@@ -26,6 +48,19 @@ const (
 	PriorityLow    Priority = "low"
 )
 
+// Priorities lists every priority level, highest first.
+var Priorities = []Priority{PriorityHigh, PriorityMedium, PriorityLow}
+
+// IsValid reports whether the priority is one of the known levels.
+func (p Priority) IsValid() bool {
+	for _, known := range Priorities {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
 // SenderType represents who sent a message.
 type SenderType string
 
@@ -35,6 +70,19 @@ const (
 	SenderSystem SenderType = "system"
 )
 
+// SenderTypes lists every sender type.
+var SenderTypes = []SenderType{SenderUser, SenderOther, SenderSystem}
+
+// IsValid reports whether the sender type is one of the known senders.
+func (s SenderType) IsValid() bool {
+	for _, known := range SenderTypes {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
 // ContentType represents the type of message content.
 type ContentType string
 
@@ -44,6 +92,19 @@ const (
 	ContentSocial ContentType = "social"
 )
 
+// ContentTypes lists every message content type.
+var ContentTypes = []ContentType{ContentText, ContentEvent, ContentSocial}
+
+// IsValid reports whether the content type is one of the known types.
+func (c ContentType) IsValid() bool {
+	for _, known := range ContentTypes {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
 // InsightType represents the type of AI insight.
 type InsightType string
 
@@ -61,3 +122,25 @@ const (
 	PlatformLinkedIn SocialPlatform = "linkedin"
 	PlatformTwitter  SocialPlatform = "twitter"
 )
+
+// RSVPStatus represents an attendee's response to a calendar event.
+type RSVPStatus string
+
+const (
+	RSVPAccepted  RSVPStatus = "accepted"
+	RSVPDeclined  RSVPStatus = "declined"
+	RSVPTentative RSVPStatus = "tentative"
+)
+
+// RSVPStatuses lists every RSVP status.
+var RSVPStatuses = []RSVPStatus{RSVPAccepted, RSVPDeclined, RSVPTentative}
+
+// IsValid reports whether the status is one of the known responses.
+func (s RSVPStatus) IsValid() bool {
+	for _, known := range RSVPStatuses {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// CalendarRepository defines the interface for reading a user's calendar events.
+type CalendarRepository interface {
+	// GetEvents retrieves the calendar events in the messages of a user's items that
+	// are not in the trash and overlap [from, to), ordered by start time. An event
+	// appearing in several messages is returned once, using its most recent version.
+	GetEvents(ctx context.Context, userID string, from, to time.Time) ([]model.CalendarEvent, error)
+
+	// GetEventSlots retrieves when the events of a user's items that are not in
+	// the trash take place, for events that overlap [from, to), ordered by start
+	// time. An event appearing in several messages is returned once, using its
+	// most recent version.
+	GetEventSlots(ctx context.Context, userID string, from, to time.Time) ([]model.EventSlot, error)
+
+	// SetConflictInsight stores the conflict insight of an event's message,
+	// replacing the previous one. A nil insight removes it.
+	SetConflictInsight(ctx context.Context, messageID string, insight *model.AIInsight) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// IngestRepository defines the interface for writing connector data.
+type IngestRepository interface {
+	// UpsertItem creates or updates a priority item by (user, source, external ID),
+	// upserting its participants by email or external ID and its messages by external ID.
+	// Replaying the same item is a no-op apart from refreshed content.
+	// The result carries the item's counted state from before the upsert.
+	UpsertItem(ctx context.Context, item model.IngestItem) (*model.IngestResult, error)
+
+	// GetCountedItems retrieves the counted state of the given items.
+	// Items that are not in the default stream are absent from the result.
+	GetCountedItems(ctx context.Context, itemIDs []string) (map[string]model.CountedItem, error)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// InsightRepository defines the interface for the insight queue and stored insights.
+type InsightRepository interface {
+	// QueueItems queues items for insight generation. Items already in the queue move to its back.
+	QueueItems(ctx context.Context, itemIDs []string) error
+
+	// DequeueItems removes up to limit items from the front of the queue and returns their IDs.
+	// Concurrent callers receive different items.
+	DequeueItems(ctx context.Context, limit int) ([]string, error)
+
+	// GetThread retrieves an item's title, source, owner and latest messages
+	// (up to model.ItemMessagesLimit, oldest first). Returns nil if not found.
+	GetThread(ctx context.Context, itemID string) (*model.InsightThread, error)
+
+	// SaveInsights replaces the insights stored on a message. Its calendar
+	// conflict insight (model.ConflictInsightID) is kept.
+	SaveInsights(ctx context.Context, messageID string, insights []model.AIInsight) error
+
+	// GetMessageInsights retrieves the insights stored on a message of an item.
+	// Returns nil if the item has no such message.
+	GetMessageInsights(ctx context.Context, itemID, messageID string) ([]model.AIInsight, error)
+
+	// ReplaceInsight replaces one insight stored on a message with next, provided
+	// its ID, content and draft flag still match prev. Returns false otherwise,
+	// so concurrent changes to the same insight are not lost.
+	ReplaceInsight(ctx context.Context, messageID string, prev, next model.AIInsight) (bool, error)
+}
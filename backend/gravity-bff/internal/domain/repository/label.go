@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// ErrLabelExists is returned when a user already has a label with the same name.
+var ErrLabelExists = errors.New("a label with this name already exists")
+
+// LabelRepository defines the interface for label storage.
+type LabelRepository interface {
+	// ListLabels retrieves a user's labels ordered by name.
+	ListLabels(ctx context.Context, userID string) ([]model.Label, error)
+
+	// GetLabel retrieves a label owned by the user. Returns nil if it does not exist.
+	GetLabel(ctx context.Context, userID, labelID string) (*model.Label, error)
+
+	// CreateLabel stores a new label and returns it with its ID.
+	// Returns ErrLabelExists if the name is taken.
+	CreateLabel(ctx context.Context, label model.Label) (*model.Label, error)
+
+	// UpdateLabel replaces a label owned by label.UserID. Returns nil if it does not exist.
+	// Returns ErrLabelExists if the name is taken.
+	UpdateLabel(ctx context.Context, label model.Label) (*model.Label, error)
+
+	// DeleteLabel deletes a label owned by the user and removes it from all items.
+	// Returns false if it does not exist.
+	DeleteLabel(ctx context.Context, userID, labelID string) (bool, error)
+
+	// ListLabeledItemIDs retrieves the IDs of the items a label is applied to.
+	ListLabeledItemIDs(ctx context.Context, userID, labelID string) ([]string, error)
+
+	// AddItemLabels applies labels owned by the user to an item owned by the user.
+	// Labels already on the item are left as they are. Returns false if the item does not exist.
+	AddItemLabels(ctx context.Context, userID, itemID string, labelIDs []string) (bool, error)
+
+	// RemoveItemLabel removes a label from an item owned by the user.
+	// Returns false if the item does not exist or does not have the label.
+	RemoveItemLabel(ctx context.Context, userID, itemID, labelID string) (bool, error)
+
+	// GetItemLabels retrieves the labels applied to an item, ordered by name.
+	GetItemLabels(ctx context.Context, itemID string) ([]model.Label, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// RuleRepository defines the interface for triage rule storage.
+type RuleRepository interface {
+	// ListRules retrieves a user's rules in the order they apply.
+	ListRules(ctx context.Context, userID string) ([]model.Rule, error)
+
+	// GetRule retrieves a rule owned by the user. Returns nil if it does not exist.
+	GetRule(ctx context.Context, userID, ruleID string) (*model.Rule, error)
+
+	// CreateRule stores a new rule and returns it with its ID and timestamps.
+	CreateRule(ctx context.Context, rule model.Rule) (*model.Rule, error)
+
+	// UpdateRule replaces a rule owned by rule.UserID. Returns nil if it does not exist.
+	UpdateRule(ctx context.Context, rule model.Rule) (*model.Rule, error)
+
+	// DeleteRule deletes a rule owned by the user. Returns false if it does not exist.
+	DeleteRule(ctx context.Context, userID, ruleID string) (bool, error)
+
+	// NextRulePosition returns the position after the user's last rule.
+	NextRulePosition(ctx context.Context, userID string) (int, error)
+
+	// ApplyRuleEffect applies a rule effect to an item owned by the user.
+	// A priority in the effect becomes the item's priority override.
+	ApplyRuleEffect(ctx context.Context, userID, itemID string, effect model.RuleEffect) error
+
+	// ListRuleCandidates retrieves up to limit of the user's most recent items,
+	// with their messages, for evaluating rules against existing items.
+	ListRuleCandidates(ctx context.Context, userID string, limit int) ([]model.PriorityItem, error)
+}
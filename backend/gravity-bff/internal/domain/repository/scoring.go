@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// ScoringRepository defines the interface for reading score signals and storing scores.
+type ScoringRepository interface {
+	// ListItemsToScore returns the IDs of up to limit items that were never scored
+	// or were last scored before the given time, least recently scored first.
+	ListItemsToScore(ctx context.Context, scoredBefore time.Time, limit int) ([]string, error)
+
+	// GetScoreSignals gathers the scoring inputs for the given items as of now.
+	// Unknown item IDs are skipped.
+	GetScoreSignals(ctx context.Context, itemIDs []string, now time.Time) ([]model.ScoreSignals, error)
+
+	// UpdateScores stores computed scores and their priority buckets and marks the items as scored.
+	UpdateScores(ctx context.Context, scores []model.ItemScore) error
+}
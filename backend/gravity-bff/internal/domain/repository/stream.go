@@ -4,10 +4,15 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/mabidoli/gravity-bff/internal/domain/model"
 )
 
+// EventChange changes a calendar event in place and returns the message
+// recording the change, with the item snippet to show for it.
+type EventChange func(event *model.CalendarEvent) (note model.Message, snippet string, err error)
+
 // StreamRepository defines the interface for priority stream data access.
 type StreamRepository interface {
 	// GetStream retrieves a paginated list of priority items for a user.
@@ -15,15 +20,98 @@ type StreamRepository interface {
 	// Returns items, next cursor (nil if no more items), and any error.
 	GetStream(ctx context.Context, req model.StreamRequest) ([]model.PriorityItem, *string, error)
 
-	// GetStreamItemByID retrieves a single priority item with all its messages.
-	// Returns the full item details including messages, or an error if not found.
+	// SearchStream runs a full-text search over a user's items and their messages.
+	// Results are ordered by rank, then recency.
+	// Returns results, next cursor (nil if no more results), and any error.
+	SearchStream(ctx context.Context, req model.SearchRequest) ([]model.SearchResult, *string, error)
+
+	// GetStreamItemByID retrieves a single priority item with its latest messages.
+	// Returns the item details including up to model.ItemMessagesLimit messages
+	// and the cursor for older ones, or nil if not found.
 	GetStreamItemByID(ctx context.Context, userID, itemID string) (*model.PriorityItem, error)
 
 	// GetParticipantsByItemID retrieves all participants for a priority item.
 	GetParticipantsByItemID(ctx context.Context, itemID string) ([]model.User, error)
 
-	// GetMessagesByItemID retrieves all messages for a priority item.
-	GetMessagesByItemID(ctx context.Context, itemID string) ([]model.Message, error)
+	// GetParticipantsByItemIDs retrieves the participants of several priority
+	// items in one query, keyed by item ID. Items without participants are absent.
+	GetParticipantsByItemIDs(ctx context.Context, itemIDs []string) (map[string][]model.User, error)
+
+	// GetMessages retrieves a page of an item's messages, going back in time from
+	// the before cursor. Messages within the page are ordered oldest first.
+	// Returns messages, next cursor (nil if no older messages), and any error;
+	// messages are nil if the item does not exist or belongs to another user.
+	GetMessages(ctx context.Context, req model.MessagesRequest) ([]model.Message, *string, error)
+
+	// SetReadState marks the given items as read or unread for a user.
+	// Returns a count change for each item that exists and belongs to the user.
+	SetReadState(ctx context.Context, userID string, itemIDs []string, unread bool) ([]model.CountChange, error)
+
+	// GetCounts counts the items in a user's default stream, in total and by source and label.
+	GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error)
+
+	// GetChanges retrieves up to limit entries of the user's change feed after
+	// the given change sequence number, in sequence order. Each changed item
+	// appears once, at its latest change.
+	GetChanges(ctx context.Context, userID string, since int64, limit int) ([]model.ItemChange, error)
+
+	// CreateMessage appends a message to a priority item owned by the user and
+	// moves the item's timestamp and snippet forward to match it.
+	// Returns the stored message, or nil if the item does not exist.
+	CreateMessage(ctx context.Context, userID, itemID string, msg model.Message, snippet string) (*model.Message, error)
+
+	// UpdateEvent changes the calendar event in a message of a user's item and
+	// appends the message that change returns, as CreateMessage does, in one
+	// transaction. change runs while the message is locked, so concurrent changes
+	// to an event apply one after the other; if it fails nothing is stored and its
+	// error is returned as is. The times and RSVPs it changes are kept when the
+	// message is redelivered. Returns nil if the item has no such message with an event.
+	UpdateEvent(ctx context.Context, userID, itemID, messageID string, change EventChange) (*model.EventUpdateResponse, error)
+
+	// SetSnooze snoozes an item owned by the user until the given time, or
+	// unsnoozes it when until is nil. Returns false if the item does not exist.
+	SetSnooze(ctx context.Context, userID, itemID string, until *time.Time) (bool, error)
+
+	// WakeSnoozed unsnoozes up to limit items whose snooze expired at or before now,
+	// across all users, marking them unread and moving them to the top of the stream.
+	// Returns the woken items.
+	WakeSnoozed(ctx context.Context, now time.Time, limit int) ([]model.ItemRef, error)
+
+	// SetFollowUp sets when an item owned by the user resurfaces unless someone
+	// else replies, or clears it when at is nil. A follow-up is only set while the
+	// user wrote last; otherwise the item is left unchanged. Returns nil if the
+	// item does not exist.
+	SetFollowUp(ctx context.Context, userID, itemID string, at *time.Time) (*model.FollowUpResponse, error)
+
+	// ResurfaceFollowUps clears up to limit follow-ups due at or before now, across
+	// all users, returning their items to the stream unread and at the top, out of
+	// the archive and any snooze. Returns the resurfaced items.
+	ResurfaceFollowUps(ctx context.Context, now time.Time, limit int) ([]model.ItemRef, error)
+
+	// SetItemState archives, trashes or restores the given items for a user.
+	// Archiving or trashing an item also clears its snooze; trashing clears its follow-up.
+	// Returns the IDs of the items that exist and belong to the user.
+	SetItemState(ctx context.Context, userID string, itemIDs []string, state model.ItemState) ([]string, error)
+
+	// GetPinnedItems retrieves the user's pinned items matching the filter, in pin order.
+	GetPinnedItems(ctx context.Context, userID string, filter model.StreamFilter) ([]model.PriorityItem, error)
+
+	// ListPinnedItemIDs retrieves the IDs of all of the user's pinned items, in pin order.
+	ListPinnedItemIDs(ctx context.Context, userID string) ([]string, error)
+
+	// PinItem pins an item owned by the user after their other pinned items.
+	// A pinned item keeps its position. Returns false if the item does not exist.
+	PinItem(ctx context.Context, userID, itemID string) (bool, error)
+
+	// UnpinItem unpins an item owned by the user. Returns false if the item does not exist.
+	UnpinItem(ctx context.Context, userID, itemID string) (bool, error)
+
+	// SetPinOrder orders the user's pinned items as listed.
+	SetPinOrder(ctx context.Context, userID string, itemIDs []string) error
+
+	// PurgeTrash permanently deletes up to limit items trashed before the given
+	// time, across all users, with their messages. Returns the deleted items.
+	PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) ([]model.ItemRef, error)
 }
 
 // UserRepository defines the interface for user data access.
@@ -33,4 +121,18 @@ type UserRepository interface {
 
 	// GetUsersByIDs retrieves multiple users by their IDs.
 	GetUsersByIDs(ctx context.Context, userIDs []string) ([]model.User, error)
+
+	// ListPeople retrieves a page of the people who take part in the user's
+	// non-trashed items, ranked by how many items they share and how recently,
+	// with a cursor for the next page. The user's own record is left out.
+	ListPeople(ctx context.Context, req model.PeopleRequest) ([]model.Person, *string, error)
+
+	// GetPerson retrieves one of the user's people. Returns nil if they take
+	// part in none of the user's non-trashed items.
+	GetPerson(ctx context.Context, userID, personID string) (*model.Person, error)
+
+	// SetVIP marks or unmarks one of the user's people as a VIP and returns the
+	// IDs of the user's non-trashed items they take part in. Returns nil if they
+	// take part in none.
+	SetVIP(ctx context.Context, userID, personID string, vip bool) ([]string, error)
 }
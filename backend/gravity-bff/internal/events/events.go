@@ -0,0 +1,78 @@
+// Package events provides per-user live update events for the stream.
+// Events are fanned out across BFF replicas through Redis pub/sub and kept
+// in a short per-user history so clients can resume after a disconnect.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Type identifies the kind of change an event describes.
+type Type string
+
+const (
+	ItemCreated    Type = "item.created"
+	ItemUpdated    Type = "item.updated"
+	MessageCreated Type = "message.created"
+	ItemRead       Type = "item.read"
+	ItemSnoozed    Type = "item.snoozed"
+	ItemWoken      Type = "item.woken"
+	ItemFollowUp   Type = "item.follow_up"
+	ItemResurfaced Type = "item.resurfaced"
+	ItemArchived   Type = "item.archived"
+	ItemTrashed    Type = "item.trashed"
+	ItemRestored   Type = "item.restored"
+	ItemPinned     Type = "item.pinned"
+	ItemUnpinned   Type = "item.unpinned"
+	PinsReordered  Type = "pins.reordered"
+)
+
+// Event is a change notification delivered to a user's connected clients.
+type Event struct {
+	ID        string          `json:"id,omitempty"` // Assigned when published
+	Type      Type            `json:"type"`
+	ItemID    string          `json:"itemId,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// New creates an event for an item, encoding data as the event payload.
+func New(eventType Type, itemID string, data interface{}) (Event, error) {
+	evt := Event{
+		Type:      eventType,
+		ItemID:    itemID,
+		Timestamp: time.Now().UTC(),
+	}
+	if data != nil {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return Event{}, err
+		}
+		evt.Data = payload
+	}
+	return evt, nil
+}
+
+// Publisher publishes events to a user's subscribers.
+type Publisher interface {
+	// Publish delivers an event to every subscriber of the user.
+	Publish(ctx context.Context, userID string, evt Event) error
+}
+
+// Subscriber delivers a user's events as they are published.
+type Subscriber interface {
+	// Subscribe returns a channel of events for the user. If lastEventID is set,
+	// events published after it are replayed first. The channel is closed when
+	// ctx is cancelled.
+	Subscribe(ctx context.Context, userID, lastEventID string) (<-chan Event, error)
+}
+
+// NopPublisher discards all events.
+type NopPublisher struct{}
+
+// Publish discards the event.
+func (NopPublisher) Publish(ctx context.Context, userID string, evt Event) error {
+	return nil
+}
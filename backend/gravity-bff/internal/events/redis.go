@@ -0,0 +1,213 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Key prefixes for event storage and fan-out.
+const (
+	historyKeyPrefix = "events:"
+	channelPrefix    = "events:live:"
+)
+
+// subscriberBuffer is the number of events buffered per subscriber.
+const subscriberBuffer = 64
+
+// streamIDPattern matches Redis stream entry IDs, which double as event IDs.
+var streamIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// publishScript appends the event to the user's history and fans it out in one step,
+// so subscribers always receive the ID the event was stored under.
+var publishScript = redis.NewScript(`
+local id = redis.call('XADD', KEYS[1], 'MAXLEN', '~', ARGV[1], '*', 'event', ARGV[2])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+redis.call('PUBLISH', KEYS[2], id .. '\n' .. ARGV[2])
+return id
+`)
+
+// RedisBroker implements Publisher and Subscriber using Redis streams and pub/sub.
+type RedisBroker struct {
+	client      *redis.Client
+	historySize int64
+	historyTTL  time.Duration
+}
+
+// NewRedisBroker creates a broker that keeps up to historySize events per user for historyTTL.
+func NewRedisBroker(client *redis.Client, historySize int64, historyTTL time.Duration) *RedisBroker {
+	return &RedisBroker{
+		client:      client,
+		historySize: historySize,
+		historyTTL:  historyTTL,
+	}
+}
+
+// historyKey returns the Redis stream holding a user's recent events.
+func historyKey(userID string) string {
+	return historyKeyPrefix + userID
+}
+
+// channelKey returns the pub/sub channel for a user's live events.
+func channelKey(userID string) string {
+	return channelPrefix + userID
+}
+
+// Publish stores the event in the user's history and notifies all replicas.
+func (b *RedisBroker) Publish(ctx context.Context, userID string, evt Event) error {
+	evt.ID = ""
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	keys := []string{historyKey(userID), channelKey(userID)}
+	if err := publishScript.Run(ctx, b.client, keys, b.historySize, b.historyTTL.Milliseconds(), string(payload)).Err(); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe returns a channel of the user's events, replaying any missed since lastEventID.
+func (b *RedisBroker) Subscribe(ctx context.Context, userID, lastEventID string) (<-chan Event, error) {
+	pubsub := b.client.Subscribe(ctx, channelKey(userID))
+
+	// Wait for the subscription to be confirmed before replaying history,
+	// so no event can fall between the replay and the live feed.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	var missed []Event
+	if streamIDPattern.MatchString(lastEventID) {
+		var err error
+		missed, err = b.history(ctx, userID, lastEventID)
+		if err != nil {
+			pubsub.Close()
+			return nil, err
+		}
+	}
+
+	out := make(chan Event, subscriberBuffer)
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+
+		lastID := lastEventID
+		send := func(evt Event) bool {
+			// Skip anything already delivered through the replay
+			if lastID != "" && compareIDs(evt.ID, lastID) <= 0 {
+				return true
+			}
+			select {
+			case out <- evt:
+				lastID = evt.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, evt := range missed {
+			if !send(evt) {
+				return
+			}
+		}
+
+		live := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-live:
+				if !ok {
+					return
+				}
+				evt, err := decodeLiveMessage(msg.Payload)
+				if err != nil {
+					continue
+				}
+				if !send(evt) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// history returns the user's stored events published after lastEventID.
+func (b *RedisBroker) history(ctx context.Context, userID, lastEventID string) ([]Event, error) {
+	entries, err := b.client.XRange(ctx, historyKey(userID), "("+lastEventID, "+").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event history: %w", err)
+	}
+
+	events := make([]Event, 0, len(entries))
+	for _, entry := range entries {
+		raw, ok := entry.Values["event"].(string)
+		if !ok {
+			continue
+		}
+		var evt Event
+		if err := json.Unmarshal([]byte(raw), &evt); err != nil {
+			continue
+		}
+		evt.ID = entry.ID
+		events = append(events, evt)
+	}
+
+	return events, nil
+}
+
+// decodeLiveMessage parses a pub/sub payload of the form "<id>\n<event json>".
+func decodeLiveMessage(payload string) (Event, error) {
+	id, raw, ok := strings.Cut(payload, "\n")
+	if !ok {
+		return Event{}, fmt.Errorf("malformed event payload")
+	}
+
+	var evt Event
+	if err := json.Unmarshal([]byte(raw), &evt); err != nil {
+		return Event{}, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	evt.ID = id
+
+	return evt, nil
+}
+
+// compareIDs orders two Redis stream IDs ("<ms>-<seq>").
+// Returns -1, 0 or 1 like strings.Compare.
+func compareIDs(a, b string) int {
+	aMs, aSeq := splitID(a)
+	bMs, bSeq := splitID(b)
+	switch {
+	case aMs < bMs:
+		return -1
+	case aMs > bMs:
+		return 1
+	case aSeq < bSeq:
+		return -1
+	case aSeq > bSeq:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// splitID splits a stream ID into its millisecond and sequence parts.
+func splitID(id string) (uint64, uint64) {
+	msPart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(msPart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
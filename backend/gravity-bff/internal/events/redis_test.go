@@ -0,0 +1,59 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected int
+	}{
+		{"equal", "1700000000000-0", "1700000000000-0", 0},
+		{"earlier millisecond", "1699999999999-5", "1700000000000-0", -1},
+		{"later sequence", "1700000000000-10", "1700000000000-9", 1},
+		{"numeric not lexical", "999-0", "1000-0", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, compareIDs(tt.a, tt.b))
+		})
+	}
+}
+
+func TestDecodeLiveMessage(t *testing.T) {
+	// Act
+	evt, err := decodeLiveMessage("1700000000000-1\n" + `{"type":"item.read","itemId":"item-1","data":{"unread":false},"timestamp":"2026-01-01T00:00:00Z"}`)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "1700000000000-1", evt.ID)
+	assert.Equal(t, ItemRead, evt.Type)
+	assert.Equal(t, "item-1", evt.ItemID)
+	assert.JSONEq(t, `{"unread":false}`, string(evt.Data))
+}
+
+func TestDecodeLiveMessage_Malformed(t *testing.T) {
+	_, err := decodeLiveMessage("no-separator")
+	assert.Error(t, err)
+
+	_, err = decodeLiveMessage("1-0\nnot json")
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	// Act
+	evt, err := New(MessageCreated, "item-1", map[string]string{"id": "msg-1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, MessageCreated, evt.Type)
+	assert.Equal(t, "item-1", evt.ItemID)
+	assert.JSONEq(t, `{"id":"msg-1"}`, string(evt.Data))
+	assert.False(t, evt.Timestamp.IsZero())
+	assert.Empty(t, evt.ID)
+}
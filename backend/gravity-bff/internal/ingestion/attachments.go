@@ -0,0 +1,75 @@
+package ingestion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// AttachmentStore persists attachment contents received from a source.
+type AttachmentStore interface {
+	// Put stores the data for an attachment owned by userID and returns the
+	// attachment with its ID, size and URL filled in. Storing the same data
+	// twice must return the same ID.
+	Put(ctx context.Context, userID string, attachment model.Attachment, data []byte) (model.Attachment, error)
+}
+
+// Ensure the stores implement the AttachmentStore interface.
+var (
+	_ AttachmentStore = (*DirStore)(nil)
+	_ AttachmentStore = DiscardStore{}
+)
+
+// contentID returns a content-addressed ID for attachment data.
+func contentID(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// DirStore stores attachments as files under a local directory,
+// content-addressed per user so re-imports do not duplicate files.
+type DirStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewDirStore creates a store writing to dir. URLs are formed as baseURL/<userID>/<id>.
+func NewDirStore(dir, baseURL string) *DirStore {
+	return &DirStore{dir: dir, baseURL: baseURL}
+}
+
+// Put writes the data unless a file with the same content already exists.
+func (s *DirStore) Put(ctx context.Context, userID string, attachment model.Attachment, data []byte) (model.Attachment, error) {
+	attachment.ID = contentID(data)
+	attachment.SizeBytes = int64(len(data))
+
+	userDir := filepath.Join(s.dir, url.PathEscape(userID))
+	path := filepath.Join(userDir, attachment.ID)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.MkdirAll(userDir, 0o755); err != nil {
+			return attachment, fmt.Errorf("failed to create attachment directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return attachment, fmt.Errorf("failed to write attachment: %w", err)
+		}
+	}
+
+	attachment.URL = s.baseURL + "/" + url.PathEscape(userID) + "/" + attachment.ID
+	return attachment, nil
+}
+
+// DiscardStore keeps attachment metadata but drops the contents.
+type DiscardStore struct{}
+
+// Put returns the attachment with its ID and size set and no URL.
+func (DiscardStore) Put(ctx context.Context, userID string, attachment model.Attachment, data []byte) (model.Attachment, error) {
+	attachment.ID = contentID(data)
+	attachment.SizeBytes = int64(len(data))
+	return attachment, nil
+}
@@ -0,0 +1,85 @@
+// Package ingestion receives data from source platforms (email, Slack, calendar, ...)
+// and normalizes it into priority items. Each source is handled by a Connector that
+// authenticates webhook deliveries and parses their payloads.
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// Errors returned while handling a webhook delivery.
+var (
+	// ErrUnknownSource means no connector is registered for the source.
+	ErrUnknownSource = errors.New("unknown source")
+	// ErrInvalidSignature means the delivery could not be authenticated.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInvalidPayload means the delivery body could not be parsed.
+	ErrInvalidPayload = errors.New("invalid payload")
+)
+
+// SignatureHeader carries the HMAC signature of a webhook body.
+const SignatureHeader = "X-Gravity-Signature"
+
+// Connector authenticates and normalizes webhook deliveries for one source.
+type Connector interface {
+	// Source returns the source type this connector handles.
+	Source() model.SourceType
+
+	// Verify checks the signature of a raw webhook body.
+	// Returns ErrInvalidSignature if it does not match.
+	Verify(body []byte, signature string) error
+
+	// Parse normalizes a webhook body into items.
+	// Returns ErrInvalidPayload if the body is malformed.
+	Parse(body []byte) ([]model.IngestItem, error)
+}
+
+// Registry holds the connector for each source.
+type Registry struct {
+	mu         sync.RWMutex
+	connectors map[model.SourceType]Connector
+}
+
+// NewRegistry creates a registry with the given connectors.
+func NewRegistry(connectors ...Connector) *Registry {
+	r := &Registry{connectors: make(map[model.SourceType]Connector)}
+	for _, c := range connectors {
+		r.Register(c)
+	}
+	return r
+}
+
+// Register adds a connector, replacing any existing one for its source.
+func (r *Registry) Register(c Connector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.connectors[c.Source()] = c
+}
+
+// Get returns the connector for a source.
+func (r *Registry) Get(source model.SourceType) (Connector, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.connectors[source]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+	}
+	return c, nil
+}
+
+// Sources returns the registered sources in sorted order.
+func (r *Registry) Sources() []model.SourceType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sources := make([]model.SourceType, 0, len(r.connectors))
+	for s := range r.connectors {
+		sources = append(sources, s)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i] < sources[j] })
+	return sources
+}
@@ -0,0 +1,167 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+)
+
+// Options controls how email threads are converted into priority items.
+type Options struct {
+	// UserID owns the imported items.
+	UserID string
+	// OwnerEmail marks messages sent by the owner as their own.
+	OwnerEmail string
+	// Unread imports items as unread. Archives are usually already read.
+	Unread bool
+	// Attachments stores attachment contents. Defaults to ingestion.DiscardStore.
+	Attachments ingestion.AttachmentStore
+}
+
+// replyPrefixPattern matches reply and forward prefixes in a subject.
+var replyPrefixPattern = regexp.MustCompile(`(?i)^\s*((re|fw|fwd|aw|wg|sv|tr)(\[\d+\])?\s*:\s*)+`)
+
+// ReadAll parses an mbox archive or a single message.
+// Messages that cannot be parsed are skipped and reported in the returned errors.
+func ReadAll(r io.Reader) ([]*Message, []error, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	if !IsMbox(data) {
+		msg, err := Parse(bytes.NewReader(data))
+		if err != nil {
+			return nil, []error{err}, nil
+		}
+		return []*Message{msg}, nil, nil
+	}
+
+	var messages []*Message
+	var skipped []error
+	mbox := NewMboxReader(bytes.NewReader(data))
+	for i := 1; ; i++ {
+		raw, err := mbox.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		msg, err := Parse(bytes.NewReader(raw))
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("message %d: %w", i, err))
+			continue
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, skipped, nil
+}
+
+// ToItems converts threads into items for ingestion. Each thread becomes one
+// email item keyed by its root message ID; each message keeps its Message-ID
+// as external ID, so re-importing an archive is idempotent.
+func ToItems(ctx context.Context, threads []Thread, opts Options) ([]model.IngestItem, error) {
+	store := opts.Attachments
+	if store == nil {
+		store = ingestion.DiscardStore{}
+	}
+	owner := strings.ToLower(strings.TrimSpace(opts.OwnerEmail))
+
+	items := make([]model.IngestItem, 0, len(threads))
+	for _, thread := range threads {
+		first := thread.Messages[0]
+		last := thread.Messages[len(thread.Messages)-1]
+
+		item := model.IngestItem{
+			UserID:     opts.UserID,
+			Source:     model.SourceEmail,
+			ExternalID: thread.RootID,
+			Title:      Title(first.Subject),
+			IsUnread:   opts.Unread,
+			Timestamp:  last.Date,
+		}
+
+		participants := make(map[string]bool)
+		addParticipant := func(addr *mail.Address) {
+			if addr == nil || addr.Address == "" || addr.Address == owner || participants[addr.Address] {
+				return
+			}
+			participants[addr.Address] = true
+			item.Participants = append(item.Participants, contact(addr))
+		}
+
+		for _, msg := range thread.Messages {
+			converted := model.IngestMessage{
+				ExternalID:  msg.ID,
+				SenderType:  model.SenderOther,
+				Content:     msg.Text,
+				Timestamp:   msg.Date,
+				ContentType: model.ContentText,
+			}
+			if msg.From != nil {
+				from := contact(msg.From)
+				converted.From = &from
+				if msg.From.Address == owner {
+					converted.SenderType = model.SenderUser
+				}
+			}
+			if msg.HTML != "" {
+				htmlBody := msg.HTML
+				converted.FullContentHTML = &htmlBody
+			}
+
+			for _, part := range msg.Attachments {
+				stored, err := store.Put(ctx, opts.UserID, model.Attachment{
+					Name:     part.Filename,
+					MimeType: part.ContentType,
+				}, part.Data)
+				if err != nil {
+					return nil, fmt.Errorf("failed to store attachment %s of %s: %w", part.Filename, msg.ID, err)
+				}
+				converted.Attachments = append(converted.Attachments, stored)
+			}
+
+			addParticipant(msg.From)
+			for _, addr := range msg.To {
+				addParticipant(addr)
+			}
+			for _, addr := range msg.Cc {
+				addParticipant(addr)
+			}
+
+			item.Messages = append(item.Messages, converted)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// Title strips reply and forward prefixes from a subject.
+func Title(subject string) string {
+	title := strings.TrimSpace(replyPrefixPattern.ReplaceAllString(subject, ""))
+	if title == "" {
+		return "(no subject)"
+	}
+	return title
+}
+
+// contact converts a mail address into an ingestion contact.
+func contact(addr *mail.Address) model.Contact {
+	email := addr.Address
+	return model.Contact{
+		Name:  addr.Name,
+		Email: &email,
+	}
+}
@@ -0,0 +1,139 @@
+package email
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+func readTestArchive(t *testing.T) []*Message {
+	f, err := os.Open("testdata/thread.mbox")
+	require.NoError(t, err)
+	defer f.Close()
+
+	messages, skipped, err := ReadAll(f)
+	require.NoError(t, err)
+	require.Empty(t, skipped)
+	require.Equal(t, 3, len(messages))
+	return messages
+}
+
+func TestReadAll_Mbox(t *testing.T) {
+	messages := readTestArchive(t)
+
+	root := messages[0]
+	assert.Equal(t, "root@example.com", root.ID)
+	assert.Equal(t, "alice@example.com", root.From.Address)
+	assert.Equal(t, time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), root.Date)
+	assert.Equal(t, "Hi, agenda attached. Café at 10?\nFrom the team.", root.Text)
+	assert.Equal(t, "<p>Hi, agenda <b>attached</b>.</p>", root.HTML)
+	require.Equal(t, 1, len(root.Attachments))
+	assert.Equal(t, "agenda.pdf", root.Attachments[0].Filename)
+	assert.Equal(t, "application/pdf", root.Attachments[0].ContentType)
+	assert.Equal(t, "%PDF-1.4\n", string(root.Attachments[0].Data))
+
+	reply := messages[1]
+	assert.Equal(t, "root@example.com", reply.InReplyTo)
+	assert.Equal(t, []string{"root@example.com"}, reply.References)
+	assert.Equal(t, "Björn", reply.Cc[0].Name)
+
+	other := messages[2]
+	assert.Equal(t, "Résumé", other.Subject)
+	assert.Equal(t, "Café menu\nLine two", other.Text)
+}
+
+func TestParse_SingleMessageWithoutID(t *testing.T) {
+	raw := "From: a@example.com\r\nDate: Mon, 05 Jan 2026 09:00:00 +0000\r\nSubject: hi\r\n\r\nbody\r\n"
+
+	first, err := Parse(strings.NewReader(raw))
+	require.NoError(t, err)
+	second, err := Parse(strings.NewReader(raw))
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.True(t, strings.HasSuffix(first.ID, "@gravity.invalid"))
+	assert.Equal(t, "body", first.Text)
+}
+
+func TestParse_ReceivedDateFallback(t *testing.T) {
+	raw := "Received: from mx by host; Tue, 06 Jan 2026 08:00:00 +0100\r\nFrom: a@example.com\r\n\r\nbody\r\n"
+
+	msg, err := Parse(strings.NewReader(raw))
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 6, 7, 0, 0, 0, time.UTC), msg.Date)
+}
+
+func TestParse_NoDate(t *testing.T) {
+	_, err := Parse(strings.NewReader("From: a@example.com\r\n\r\nbody\r\n"))
+
+	assert.Error(t, err)
+}
+
+func TestThreadMessages(t *testing.T) {
+	messages := readTestArchive(t)
+
+	// A reply seen without its parent still threads under the original root
+	orphan := &Message{
+		ID:         "reply-2@example.com",
+		InReplyTo:  "reply-1@example.com",
+		References: []string{"root@example.com", "reply-1@example.com"},
+		Date:       time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC),
+	}
+	threads := ThreadMessages(append(messages, orphan, messages[1]))
+
+	require.Equal(t, 2, len(threads))
+	assert.Equal(t, "root@example.com", threads[0].RootID)
+	assert.Equal(t, 3, len(threads[0].Messages))
+	assert.Equal(t, "reply-2@example.com", threads[0].Messages[2].ID)
+	assert.Equal(t, "other@example.com", threads[1].RootID)
+
+	alone := ThreadMessages([]*Message{orphan})
+	assert.Equal(t, "root@example.com", alone[0].RootID)
+}
+
+func TestToItems(t *testing.T) {
+	threads := ThreadMessages(readTestArchive(t))
+
+	items, err := ToItems(context.Background(), threads, Options{UserID: "user_1", OwnerEmail: "Me@example.com"})
+
+	require.NoError(t, err)
+	require.Equal(t, 2, len(items))
+
+	item := items[0]
+	assert.Equal(t, model.SourceEmail, item.Source)
+	assert.Equal(t, "root@example.com", item.ExternalID)
+	assert.Equal(t, "Q1 planning", item.Title)
+	assert.Equal(t, time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC), item.Timestamp)
+	assert.False(t, item.IsUnread)
+
+	// The owner is not listed as a participant
+	var emails []string
+	for _, p := range item.Participants {
+		emails = append(emails, *p.Email)
+	}
+	assert.Equal(t, []string{"alice@example.com", "bjorn@example.com"}, emails)
+
+	require.Equal(t, 2, len(item.Messages))
+	assert.Equal(t, model.SenderOther, item.Messages[0].SenderType)
+	assert.Equal(t, model.SenderUser, item.Messages[1].SenderType)
+	assert.NotNil(t, item.Messages[0].FullContentHTML)
+	assert.Nil(t, item.Messages[1].FullContentHTML)
+	require.Equal(t, 1, len(item.Messages[0].Attachments))
+	assert.Equal(t, int64(9), item.Messages[0].Attachments[0].SizeBytes)
+	assert.NotEmpty(t, item.Messages[0].Attachments[0].ID)
+}
+
+func TestTitle(t *testing.T) {
+	assert.Equal(t, "Q1 planning", Title("Re: RE: Fwd: Q1 planning"))
+	assert.Equal(t, "Q1 planning", Title("AW[2]: Q1 planning"))
+	assert.Equal(t, "Reply guy", Title("Reply guy"))
+	assert.Equal(t, "(no subject)", Title("  "))
+}
@@ -0,0 +1,82 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// maxLineLength bounds a single line in an mbox file.
+const maxLineLength = 1 << 20
+
+// escapedFromPattern matches a body line quoted by mboxrd/mboxo writers.
+var escapedFromPattern = regexp.MustCompile(`^>+From `)
+
+// MboxReader splits an mbox archive into raw messages.
+// It reads the mboxrd and mboxo variants, undoing ">From " quoting.
+type MboxReader struct {
+	scanner *bufio.Scanner
+	started bool
+	pending bool
+}
+
+// NewMboxReader creates a reader over an mbox archive.
+func NewMboxReader(r io.Reader) *MboxReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineLength)
+	return &MboxReader{scanner: scanner}
+}
+
+// Next returns the next raw message, or io.EOF when the archive is exhausted.
+func (m *MboxReader) Next() ([]byte, error) {
+	var buf bytes.Buffer
+
+	for m.pending || m.scanner.Scan() {
+		m.pending = false
+		line := m.scanner.Bytes()
+
+		if bytes.HasPrefix(line, []byte("From ")) {
+			if !m.started {
+				m.started = true
+				continue
+			}
+			if buf.Len() > 0 {
+				// Keep the separator for the next call
+				m.pending = true
+				return trimTrailingNewline(buf.Bytes()), nil
+			}
+			continue
+		}
+		if !m.started {
+			return nil, fmt.Errorf("not an mbox archive: missing From separator")
+		}
+
+		if escapedFromPattern.Match(line) {
+			line = line[1:]
+		}
+		buf.Write(line)
+		buf.WriteString("\r\n")
+	}
+
+	if err := m.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mbox: %w", err)
+	}
+	if buf.Len() > 0 {
+		return trimTrailingNewline(buf.Bytes()), nil
+	}
+	return nil, io.EOF
+}
+
+// trimTrailingNewline drops the blank line mbox writers add between messages.
+func trimTrailingNewline(b []byte) []byte {
+	out := make([]byte, len(b))
+	copy(out, b)
+	return bytes.TrimRight(out, "\r\n")
+}
+
+// IsMbox reports whether data looks like an mbox archive rather than a single message.
+func IsMbox(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("From "))
+}
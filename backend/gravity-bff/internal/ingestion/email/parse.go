@@ -0,0 +1,316 @@
+// Package email parses RFC 5322 messages and mbox archives and threads them
+// into priority items for ingestion.
+package email
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// Message is a parsed email message.
+type Message struct {
+	ID          string // Message-ID without angle brackets
+	InReplyTo   string
+	References  []string
+	Subject     string
+	From        *mail.Address
+	To          []*mail.Address
+	Cc          []*mail.Address
+	Date        time.Time
+	Text        string // Plain text body, or the HTML body reduced to text
+	HTML        string // HTML body, if any
+	Attachments []Part
+}
+
+// Part is a non-body MIME part of a message.
+type Part struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// maxPartDepth bounds the nesting of multipart bodies.
+const maxPartDepth = 10
+
+// headerDecoder decodes RFC 2047 encoded words in any charset known to x/text.
+var headerDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// msgIDPattern matches a single <id> token in Message-ID style headers.
+var msgIDPattern = regexp.MustCompile(`<([^<>\s]+)>`)
+
+// Parse reads a single RFC 5322 message.
+func Parse(r io.Reader) (*Message, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+
+	msg := &Message{
+		ID:         firstMessageID(m.Header.Get("Message-ID")),
+		InReplyTo:  firstMessageID(m.Header.Get("In-Reply-To")),
+		References: messageIDs(m.Header.Get("References")),
+		Subject:    decodeHeader(m.Header.Get("Subject")),
+		From:       firstAddress(m.Header, "From"),
+		To:         addressList(m.Header, "To"),
+		Cc:         addressList(m.Header, "Cc"),
+	}
+
+	// Messages without an ID get a stable one derived from their content,
+	// so importing the same archive twice stays idempotent.
+	if msg.ID == "" {
+		sum := sha256.Sum256(raw)
+		msg.ID = hex.EncodeToString(sum[:16]) + "@gravity.invalid"
+	}
+
+	msg.Date, err = m.Header.Date()
+	if err != nil {
+		msg.Date, err = receivedDate(m.Header)
+		if err != nil {
+			return nil, fmt.Errorf("message %s has no usable date", msg.ID)
+		}
+	}
+	msg.Date = msg.Date.UTC()
+
+	if err := msg.readPart(m.Header, m.Body, 0); err != nil {
+		return nil, fmt.Errorf("failed to read body of %s: %w", msg.ID, err)
+	}
+
+	if msg.Text == "" && msg.HTML != "" {
+		msg.Text = htmlToText(msg.HTML)
+	}
+
+	return msg, nil
+}
+
+// partHeader is satisfied by both message and MIME part headers.
+type partHeader interface {
+	Get(key string) string
+}
+
+// readPart walks a MIME part, collecting bodies and attachments.
+func (m *Message) readPart(header partHeader, body io.Reader, depth int) error {
+	if depth > maxPartDepth {
+		return fmt.Errorf("MIME nesting too deep")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := m.readPart(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransfer(body, header.Get("Content-Transfer-Encoding")))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := decodeHeader(dispParams["filename"])
+	if filename == "" {
+		filename = decodeHeader(params["name"])
+	}
+
+	isBody := disposition != "attachment" && filename == ""
+	switch {
+	case isBody && mediaType == "text/plain" && m.Text == "":
+		m.Text = textBody(data, params["charset"])
+	case isBody && mediaType == "text/html" && m.HTML == "":
+		m.HTML = textBody(data, params["charset"])
+	default:
+		if filename == "" {
+			filename = "attachment"
+			if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+				filename += exts[0]
+			}
+		}
+		m.Attachments = append(m.Attachments, Part{
+			Filename:    filename,
+			ContentType: mediaType,
+			Data:        data,
+		})
+	}
+
+	return nil
+}
+
+// textBody decodes a text part to UTF-8 with normalized line endings.
+func textBody(data []byte, charset string) string {
+	text := strings.ReplaceAll(decodeCharset(data, charset), "\r\n", "\n")
+	return strings.TrimSpace(text)
+}
+
+// decodeTransfer undoes a Content-Transfer-Encoding.
+func decodeTransfer(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &base64Cleaner{r: r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// base64Cleaner strips line breaks and whitespace that the base64 decoder rejects.
+type base64Cleaner struct {
+	r io.Reader
+}
+
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	out := p[:0]
+	for _, b := range p[:n] {
+		if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+			out = append(out, b)
+		}
+	}
+	return len(out), err
+}
+
+// charsetReader returns a UTF-8 reader for text in the given charset.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, err
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
+
+// decodeCharset converts text in the given charset to UTF-8.
+// Unknown charsets are passed through unchanged.
+func decodeCharset(data []byte, charset string) string {
+	if charset == "" || strings.EqualFold(charset, "utf-8") || strings.EqualFold(charset, "us-ascii") {
+		return string(data)
+	}
+	r, err := charsetReader(charset, bytes.NewReader(data))
+	if err != nil {
+		return string(data)
+	}
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return string(data)
+	}
+	return string(decoded)
+}
+
+// decodeHeader decodes RFC 2047 encoded words, falling back to the raw value.
+func decodeHeader(value string) string {
+	decoded, err := headerDecoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+// firstAddress parses the first address in a header.
+func firstAddress(h mail.Header, key string) *mail.Address {
+	addrs := addressList(h, key)
+	if len(addrs) == 0 {
+		return nil
+	}
+	return addrs[0]
+}
+
+// addressList parses an address header, skipping it if malformed.
+func addressList(h mail.Header, key string) []*mail.Address {
+	if h.Get(key) == "" {
+		return nil
+	}
+	parser := mail.AddressParser{WordDecoder: headerDecoder}
+	addrs, err := parser.ParseList(h.Get(key))
+	if err != nil {
+		return nil
+	}
+	for _, a := range addrs {
+		a.Address = strings.ToLower(a.Address)
+	}
+	return addrs
+}
+
+// messageIDs extracts all message IDs from a References-style header.
+func messageIDs(value string) []string {
+	matches := msgIDPattern.FindAllStringSubmatch(value, -1)
+	ids := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m[1])
+	}
+	return ids
+}
+
+// firstMessageID extracts the first message ID from a header, accepting a bare ID.
+func firstMessageID(value string) string {
+	if ids := messageIDs(value); len(ids) > 0 {
+		return ids[0]
+	}
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value, " \t<>") {
+		return ""
+	}
+	return value
+}
+
+// receivedDate returns the date of the topmost Received header.
+func receivedDate(h mail.Header) (time.Time, error) {
+	received := h.Get("Received")
+	i := strings.LastIndex(received, ";")
+	if i < 0 {
+		return time.Time{}, fmt.Errorf("no Received date")
+	}
+	return mail.ParseDate(strings.TrimSpace(received[i+1:]))
+}
+
+var (
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(script|style|head)[^>]*>.*?</(script|style|head)>`)
+	htmlBreakPattern = regexp.MustCompile(`(?i)<(br|/p|/div|/li|/tr|/h[1-6])[^>]*>`)
+	htmlTagPattern   = regexp.MustCompile(`<[^>]*>`)
+	blankLinePattern = regexp.MustCompile(`\n\s*\n\s*\n+`)
+)
+
+// htmlToText reduces an HTML body to readable plain text.
+func htmlToText(body string) string {
+	text := htmlDropPattern.ReplaceAllString(body, "")
+	text = htmlBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	text = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLinePattern.ReplaceAllString(text, "\n\n"))
+}
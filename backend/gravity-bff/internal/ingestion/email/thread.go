@@ -0,0 +1,92 @@
+package email
+
+import (
+	"sort"
+)
+
+// Thread is a conversation of messages linked by Message-ID, In-Reply-To and References.
+type Thread struct {
+	// RootID identifies the conversation. It is the first ID in the oldest
+	// message's References (or its In-Reply-To, or its own ID), so it stays the
+	// same when later imports contain more of the conversation.
+	RootID   string
+	Messages []*Message // Oldest first
+}
+
+// ThreadMessages groups messages into threads. Messages with the same
+// Message-ID are kept once. Threads are returned oldest first.
+func ThreadMessages(messages []*Message) []Thread {
+	parent := make(map[string]string)
+	var find func(id string) string
+	find = func(id string) string {
+		p, ok := parent[id]
+		if !ok || p == id {
+			parent[id] = id
+			return id
+		}
+		root := find(p)
+		parent[id] = root
+		return root
+	}
+	union := func(a, b string) {
+		if a == "" || b == "" {
+			return
+		}
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			parent[ra] = rb
+		}
+	}
+
+	seen := make(map[string]bool, len(messages))
+	unique := make([]*Message, 0, len(messages))
+	for _, msg := range messages {
+		if seen[msg.ID] {
+			continue
+		}
+		seen[msg.ID] = true
+		unique = append(unique, msg)
+
+		find(msg.ID)
+		union(msg.ID, msg.InReplyTo)
+		for _, ref := range msg.References {
+			union(msg.ID, ref)
+		}
+	}
+
+	groups := make(map[string][]*Message)
+	var order []string
+	for _, msg := range unique {
+		key := find(msg.ID)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], msg)
+	}
+
+	threads := make([]Thread, 0, len(groups))
+	for _, key := range order {
+		msgs := groups[key]
+		sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].Date.Before(msgs[j].Date) })
+		threads = append(threads, Thread{
+			RootID:   rootID(msgs[0]),
+			Messages: msgs,
+		})
+	}
+
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threads[i].Messages[0].Date.Before(threads[j].Messages[0].Date)
+	})
+	return threads
+}
+
+// rootID returns the ID of the conversation a message starts or continues.
+func rootID(msg *Message) string {
+	if len(msg.References) > 0 {
+		return msg.References[0]
+	}
+	if msg.InReplyTo != "" {
+		return msg.InReplyTo
+	}
+	return msg.ID
+}
@@ -0,0 +1,69 @@
+package ingestion
+
+import (
+	"sync"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// Ensure FakeConnector implements the Connector interface.
+var _ Connector = (*FakeConnector)(nil)
+
+// FakeConnector is an in-memory connector for tests and local development.
+// It returns a fixed set of items for every delivery and records the bodies it received.
+// A delivery is accepted if its signature equals Signature, or always if Signature is empty.
+type FakeConnector struct {
+	SourceType model.SourceType
+	Signature  string
+	Items      []model.IngestItem
+	Err        error
+
+	mu       sync.Mutex
+	received [][]byte
+}
+
+// NewFakeConnector creates a fake connector that returns items for every delivery.
+func NewFakeConnector(source model.SourceType, items ...model.IngestItem) *FakeConnector {
+	return &FakeConnector{
+		SourceType: source,
+		Items:      items,
+	}
+}
+
+// Source returns the source type this connector handles.
+func (c *FakeConnector) Source() model.SourceType {
+	return c.SourceType
+}
+
+// Verify accepts the delivery if the signature matches the configured one.
+func (c *FakeConnector) Verify(body []byte, signature string) error {
+	if c.Signature != "" && signature != c.Signature {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Parse records the body and returns the configured items or error.
+func (c *FakeConnector) Parse(body []byte) ([]model.IngestItem, error) {
+	c.mu.Lock()
+	c.received = append(c.received, append([]byte(nil), body...))
+	c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	items := make([]model.IngestItem, len(c.Items))
+	for i, item := range c.Items {
+		item.Source = c.SourceType
+		items[i] = item
+	}
+	return items, nil
+}
+
+// Received returns the bodies of all deliveries parsed so far.
+func (c *FakeConnector) Received() [][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]byte(nil), c.received...)
+}
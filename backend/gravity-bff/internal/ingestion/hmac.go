@@ -0,0 +1,38 @@
+package ingestion
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// signaturePrefix identifies the signing algorithm in a signature header value.
+const signaturePrefix = "sha256="
+
+// Sign returns the signature header value for a body: "sha256=<hex HMAC-SHA256>".
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyHMAC checks a "sha256=<hex>" signature against the body in constant time.
+func VerifyHMAC(secret, body []byte, signature string) error {
+	if len(secret) == 0 || !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	given, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(given, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
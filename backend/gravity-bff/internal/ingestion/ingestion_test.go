@@ -0,0 +1,76 @@
+package ingestion
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+func TestSignAndVerifyHMAC(t *testing.T) {
+	secret := []byte("s3cret")
+	body := []byte(`{"items":[]}`)
+
+	signature := Sign(secret, body)
+
+	assert.NoError(t, VerifyHMAC(secret, body, signature))
+	assert.ErrorIs(t, VerifyHMAC([]byte("other"), body, signature), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyHMAC(secret, []byte(`{"items":[{}]}`), signature), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyHMAC(secret, body, "sha1=abc"), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyHMAC(secret, body, "sha256=not-hex"), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyHMAC(nil, body, signature), ErrInvalidSignature)
+}
+
+func TestJSONConnector_Parse(t *testing.T) {
+	connector := NewJSONConnector(model.SourceSlack, "s3cret")
+
+	items, err := connector.Parse([]byte(`{"items":[{"userId":"user_1","source":"email","externalId":"C1/1700000000.000100","title":"#alerts","timestamp":"2026-01-05T09:00:00Z"}]}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, "C1/1700000000.000100", items[0].ExternalID)
+	// The connector's source wins over whatever the payload claims
+	assert.Equal(t, model.SourceSlack, items[0].Source)
+}
+
+func TestJSONConnector_ParseInvalid(t *testing.T) {
+	connector := NewJSONConnector(model.SourceSlack, "s3cret")
+
+	_, err := connector.Parse([]byte(`not json`))
+
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestRegistry(t *testing.T) {
+	slack := NewFakeConnector(model.SourceSlack)
+	email := NewFakeConnector(model.SourceEmail)
+	registry := NewRegistry(slack, email)
+
+	got, err := registry.Get(model.SourceSlack)
+	assert.NoError(t, err)
+	assert.Same(t, slack, got)
+
+	_, err = registry.Get(model.SourceTeams)
+	assert.ErrorIs(t, err, ErrUnknownSource)
+
+	assert.Equal(t, []model.SourceType{model.SourceEmail, model.SourceSlack}, registry.Sources())
+}
+
+func TestFakeConnector(t *testing.T) {
+	connector := NewFakeConnector(model.SourceTask, model.IngestItem{ExternalID: "task-1"})
+	connector.Signature = "ok"
+
+	assert.NoError(t, connector.Verify(nil, "ok"))
+	assert.ErrorIs(t, connector.Verify(nil, "bad"), ErrInvalidSignature)
+
+	items, err := connector.Parse([]byte("body"))
+	assert.NoError(t, err)
+	assert.Equal(t, model.SourceTask, items[0].Source)
+	assert.Equal(t, [][]byte{[]byte("body")}, connector.Received())
+
+	connector.Err = errors.New("boom")
+	_, err = connector.Parse(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,56 @@
+package ingestion
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// Ensure JSONConnector implements the Connector interface.
+var _ Connector = (*JSONConnector)(nil)
+
+// JSONPayload is the body of a webhook delivery in Gravity's normalized format.
+type JSONPayload struct {
+	Items []model.IngestItem `json:"items"`
+}
+
+// JSONConnector accepts deliveries already normalized into Gravity's item format,
+// e.g. from a relay that translates a platform's native events. It is the default
+// connector for any source without a native one.
+type JSONConnector struct {
+	source model.SourceType
+	secret []byte
+}
+
+// NewJSONConnector creates a connector for a source, authenticated with an HMAC secret.
+func NewJSONConnector(source model.SourceType, secret string) *JSONConnector {
+	return &JSONConnector{
+		source: source,
+		secret: []byte(secret),
+	}
+}
+
+// Source returns the source type this connector handles.
+func (c *JSONConnector) Source() model.SourceType {
+	return c.source
+}
+
+// Verify checks the HMAC-SHA256 signature of the body.
+func (c *JSONConnector) Verify(body []byte, signature string) error {
+	return VerifyHMAC(c.secret, body, signature)
+}
+
+// Parse decodes the normalized payload and stamps every item with the connector's source.
+func (c *JSONConnector) Parse(body []byte) ([]model.IngestItem, error) {
+	var payload JSONPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	for i := range payload.Items {
+		payload.Items[i].Source = c.source
+	}
+
+	return payload.Items, nil
+}
@@ -0,0 +1,104 @@
+// Package insights generates AI insights for conversation threads: a summary,
+// the action items asked of the owner and a suggested reply. Generation is
+// delegated to a Provider, so that LLM backends can be plugged in alongside
+// the deterministic rule-based provider that runs without network access.
+package insights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// ErrUnknownProvider means no provider is registered under the requested name.
+var ErrUnknownProvider = errors.New("unknown insights provider")
+
+// ErrUnsupportedInstruction means a provider cannot apply a draft refinement instruction.
+var ErrUnsupportedInstruction = errors.New("unsupported refinement instruction")
+
+// Provider generates insights for threads. Implementations must be safe for concurrent use.
+type Provider interface {
+	// Name identifies the provider in the INSIGHTS_PROVIDER setting and in logs.
+	Name() string
+
+	// Summarize returns a short summary of the thread.
+	Summarize(ctx context.Context, thread model.InsightThread) (string, error)
+
+	// SuggestReply returns a reply the owner could send, or "" if the thread needs no reply.
+	SuggestReply(ctx context.Context, thread model.InsightThread) (string, error)
+
+	// ExtractActionItems returns the tasks the thread asks of the owner, if any.
+	ExtractActionItems(ctx context.Context, thread model.InsightThread) ([]string, error)
+
+	// RefineReply rewrites a draft reply following the owner's instruction, such as
+	// "shorter" or "more formal". An empty instruction asks for a different reply
+	// in the same spirit. draft.Versions holds the earlier versions, if any.
+	// Returns ErrUnsupportedInstruction (wrapped) for instructions it cannot apply.
+	RefineReply(ctx context.Context, thread model.InsightThread, draft model.AIInsight, instruction string) (string, error)
+}
+
+// NewProvider returns the provider registered under name.
+// LLM backends are added here as they are implemented.
+func NewProvider(name string) (Provider, error) {
+	switch name {
+	case "", RuleBasedName:
+		return NewRuleBased(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+}
+
+// Generate produces the insights for a thread's latest message: a summary,
+// the action items if there are any and a draft reply if one is needed.
+// Insight IDs are derived from the message ID, so regenerating replaces them.
+// Returns no insights for a thread without messages.
+func Generate(ctx context.Context, p Provider, thread model.InsightThread) ([]model.AIInsight, error) {
+	latest := thread.Latest()
+	if latest == nil {
+		return []model.AIInsight{}, nil
+	}
+
+	summary, err := p.Summarize(ctx, thread)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize thread: %w", err)
+	}
+	actions, err := p.ExtractActionItems(ctx, thread)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract action items: %w", err)
+	}
+	reply, err := p.SuggestReply(ctx, thread)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest reply: %w", err)
+	}
+
+	insights := []model.AIInsight{}
+	if summary != "" {
+		insights = append(insights, model.AIInsight{
+			ID:      latest.ID + "-summary",
+			Type:    model.InsightAnalysis,
+			Label:   "Summary",
+			Content: summary,
+		})
+	}
+	if len(actions) > 0 {
+		insights = append(insights, model.AIInsight{
+			ID:      latest.ID + "-actions",
+			Type:    model.InsightSuggestion,
+			Label:   "Action items",
+			Content: "- " + strings.Join(actions, "\n- "),
+		})
+	}
+	if reply != "" {
+		insights = append(insights, model.AIInsight{
+			ID:      latest.ID + "-reply",
+			Type:    model.InsightDraft,
+			Label:   "Suggested reply",
+			Content: reply,
+			IsDraft: true,
+		})
+	}
+	return insights, nil
+}
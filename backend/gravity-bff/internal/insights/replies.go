@@ -0,0 +1,207 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// replyIntent is what a reply to a thread needs to convey.
+type replyIntent int
+
+const (
+	intentAcknowledge replyIntent = iota // Thank the sender for an update
+	intentCommit                         // Take on what the sender asked for
+	intentHold                           // Promise an answer to a question
+)
+
+// tone is the register a reply is written in.
+type tone int
+
+const (
+	toneDefault tone = iota
+	toneFormal
+	toneFriendly
+	toneShort
+)
+
+// toneInstructions maps refinement instructions to the tone they ask for, first match wins.
+var toneInstructions = []struct {
+	tone    tone
+	pattern *regexp.Regexp
+}{
+	{toneShort, regexp.MustCompile(`(?i)\b(short|brief|concise)`)},
+	{toneFormal, regexp.MustCompile(`(?i)\b(formal|professional|polite)`)},
+	{toneFriendly, regexp.MustCompile(`(?i)\b(friendly|casual|informal|warm)`)},
+}
+
+// replies lists the phrasings of each intent in each tone. Suggestions use the
+// first; regenerating moves on to the next one not used yet. {name} expands to
+// ", <first name>" and {dear} to "Dear <first name>", or to nothing and "Hello".
+var replies = map[replyIntent]map[tone][]string{
+	intentCommit: {
+		toneDefault: {
+			"Thanks{name}. I'll take care of it and let you know once it's done.",
+			"Thanks{name}. On it, I'll let you know as soon as it's done.",
+			"Thanks{name}. I'll handle this and keep you posted.",
+		},
+		toneFormal: {
+			"{dear},\n\nThank you for your message. I will take care of this and let you know once it is done.\n\nBest regards",
+			"{dear},\n\nThank you for reaching out. I will see to this and confirm once it is complete.\n\nKind regards",
+		},
+		toneFriendly: {
+			"Hey{name}! Sure thing, I'll sort it out and let you know 👍",
+			"Hi{name}! Happy to help, I'll get it done and ping you.",
+		},
+		toneShort: {
+			"On it, thanks!",
+			"Will do.",
+		},
+	},
+	intentHold: {
+		toneDefault: {
+			"Thanks{name}. Let me look into it and get back to you shortly.",
+			"Thanks{name}. Good question, I'll check and come back to you.",
+			"Thanks{name}. I'll find out and follow up soon.",
+		},
+		toneFormal: {
+			"{dear},\n\nThank you for your message. I will look into this and get back to you shortly.\n\nBest regards",
+			"{dear},\n\nThank you for your question. I will review it and respond as soon as possible.\n\nKind regards",
+		},
+		toneFriendly: {
+			"Hey{name}! Good question, let me check and get back to you 🙂",
+			"Hi{name}! Let me dig into that and circle back.",
+		},
+		toneShort: {
+			"Checking, will get back to you.",
+			"Let me check.",
+		},
+	},
+	intentAcknowledge: {
+		toneDefault: {
+			"Thanks{name}, noted.",
+			"Thanks{name}, got it.",
+			"Thanks{name}. Appreciate the update.",
+		},
+		toneFormal: {
+			"{dear},\n\nThank you for the update. I have noted it.\n\nBest regards",
+			"{dear},\n\nThank you for letting me know.\n\nKind regards",
+		},
+		toneFriendly: {
+			"Thanks{name}! Good to know 👍",
+			"Hey{name}, thanks for the heads up!",
+		},
+		toneShort: {
+			"Noted, thanks!",
+			"Got it.",
+		},
+	},
+}
+
+// SuggestReply drafts a short acknowledgement when someone else wrote last:
+// a commitment if they asked for something, a holding reply to a question,
+// and a plain thank-you otherwise.
+func (p *RuleBased) SuggestReply(ctx context.Context, thread model.InsightThread) (string, error) {
+	latest := thread.Latest()
+	if latest == nil || latest.SenderType == model.SenderUser {
+		return "", nil
+	}
+
+	intent, name, err := p.replyIntent(ctx, thread)
+	if err != nil {
+		return "", err
+	}
+	return renderReply(replies[intent][toneDefault][0], name), nil
+}
+
+// RefineReply rewrites a draft in the tone an instruction asks for: formal,
+// friendly or short. Without an instruction it returns the next phrasing in the
+// draft's current tone that the draft has not had yet, starting over once all were used.
+func (p *RuleBased) RefineReply(ctx context.Context, thread model.InsightThread, draft model.AIInsight, instruction string) (string, error) {
+	intent, name, err := p.replyIntent(ctx, thread)
+	if err != nil {
+		return "", err
+	}
+
+	if instruction != "" {
+		t, ok := toneOf(instruction)
+		if !ok {
+			return "", fmt.Errorf("%w: %q. Ask for a formal, friendly or short reply", ErrUnsupportedInstruction, instruction)
+		}
+		return renderReply(replies[intent][t][0], name), nil
+	}
+
+	current := toneDefault
+	used := map[string]bool{draft.Content: true}
+	for _, version := range draft.Versions {
+		if t, ok := toneOf(version.Instruction); ok {
+			current = t
+		}
+		used[version.Content] = true
+	}
+
+	phrasings := replies[intent][current]
+	for _, phrasing := range phrasings {
+		if reply := renderReply(phrasing, name); !used[reply] {
+			return reply, nil
+		}
+	}
+	for i, phrasing := range phrasings {
+		if renderReply(phrasing, name) == draft.Content {
+			return renderReply(phrasings[(i+1)%len(phrasings)], name), nil
+		}
+	}
+	return renderReply(phrasings[0], name), nil
+}
+
+// replyIntent returns what a reply to the thread's latest message needs to
+// convey, and the first name of its sender if known.
+func (p *RuleBased) replyIntent(ctx context.Context, thread model.InsightThread) (replyIntent, string, error) {
+	latest := thread.Latest()
+	if latest == nil {
+		return intentAcknowledge, "", nil
+	}
+
+	name := ""
+	if latest.SenderInfo != nil {
+		if fields := strings.Fields(latest.SenderInfo.Name); len(fields) > 0 {
+			name = fields[0]
+		}
+	}
+
+	actions, err := p.ExtractActionItems(ctx, thread)
+	if err != nil {
+		return intentAcknowledge, "", err
+	}
+
+	switch {
+	case len(actions) > 0:
+		return intentCommit, name, nil
+	case strings.Contains(latest.Content, "?"):
+		return intentHold, name, nil
+	default:
+		return intentAcknowledge, name, nil
+	}
+}
+
+// toneOf returns the tone an instruction asks for.
+func toneOf(instruction string) (tone, bool) {
+	for _, ti := range toneInstructions {
+		if ti.pattern.MatchString(instruction) {
+			return ti.tone, true
+		}
+	}
+	return toneDefault, false
+}
+
+// renderReply fills the sender's first name into a phrasing.
+func renderReply(phrasing, name string) string {
+	suffix, dear := "", "Hello"
+	if name != "" {
+		suffix, dear = ", "+name, "Dear "+name
+	}
+	return strings.NewReplacer("{name}", suffix, "{dear}", dear).Replace(phrasing)
+}
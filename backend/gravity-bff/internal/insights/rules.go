@@ -0,0 +1,153 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// RuleBasedName is the name of the rule-based provider.
+const RuleBasedName = "rules"
+
+const (
+	maxActionItems = 5
+	maxSentence    = 200 // Runes kept of a quoted sentence
+	maxNamedSender = 3   // Senders named in a summary before the rest are counted
+)
+
+// actionPattern matches sentences that ask something of the reader.
+var actionPattern = regexp.MustCompile(`(?i)^\[ \]|\b(please|pls|can you|could you|would you|will you|need you to|needs? to|make sure|don't forget|do not forget|remember to|action items?|todo|to-do|deadline|asap|by (today|tonight|tomorrow|monday|tuesday|wednesday|thursday|friday|eod|end of (the )?(day|week)))\b`)
+
+// sentenceEnd splits text into sentences and list entries.
+var sentenceEnd = regexp.MustCompile(`[.!?]+(\s+|$)|\n+`)
+
+// listMarker matches bullet and numbering prefixes of list entries.
+var listMarker = regexp.MustCompile(`^([-*•]|\d+[.)])\s+`)
+
+// RuleBased generates insights from keyword rules and thread structure alone.
+// It is deterministic and needs no network access.
+type RuleBased struct{}
+
+// NewRuleBased creates a rule-based provider.
+func NewRuleBased() *RuleBased {
+	return &RuleBased{}
+}
+
+// Name returns RuleBasedName.
+func (p *RuleBased) Name() string {
+	return RuleBasedName
+}
+
+// Summarize describes who wrote how many messages and quotes the first
+// sentence of the latest message.
+func (p *RuleBased) Summarize(_ context.Context, thread model.InsightThread) (string, error) {
+	latest := thread.Latest()
+	if latest == nil {
+		return "", nil
+	}
+
+	summary := fmt.Sprintf("%d %s", len(thread.Messages), plural(len(thread.Messages), "message", "messages"))
+	if names := senderNames(thread.Messages); len(names) > 0 {
+		summary += " from " + joinNames(names)
+	}
+	summary += "."
+
+	if first := firstSentence(latest.Content); first != "" {
+		summary += " Latest: " + first
+	}
+	return summary, nil
+}
+
+// ExtractActionItems returns the sentences that ask something of the owner in
+// the messages others sent since the owner last wrote, up to maxActionItems.
+func (p *RuleBased) ExtractActionItems(_ context.Context, thread model.InsightThread) ([]string, error) {
+	start := 0
+	for i, msg := range thread.Messages {
+		if msg.SenderType == model.SenderUser {
+			start = i + 1
+		}
+	}
+
+	seen := make(map[string]bool)
+	actions := []string{}
+	for _, msg := range thread.Messages[start:] {
+		for _, sentence := range sentences(msg.Content) {
+			key := strings.ToLower(sentence)
+			if seen[key] || !actionPattern.MatchString(sentence) {
+				continue
+			}
+			seen[key] = true
+			actions = append(actions, strings.TrimSpace(strings.TrimPrefix(sentence, "[ ]")))
+			if len(actions) == maxActionItems {
+				return actions, nil
+			}
+		}
+	}
+	return actions, nil
+}
+
+// sentences splits text into trimmed sentences and list entries, without list markers.
+func sentences(text string) []string {
+	var result []string
+	for _, part := range sentenceEnd.Split(text, -1) {
+		part = strings.TrimSpace(listMarker.ReplaceAllString(strings.TrimSpace(part), ""))
+		if part != "" {
+			result = append(result, truncate(part))
+		}
+	}
+	return result
+}
+
+// firstSentence returns the first sentence of text, or "" if there is none.
+func firstSentence(text string) string {
+	if all := sentences(text); len(all) > 0 {
+		return all[0]
+	}
+	return ""
+}
+
+// senderNames returns the names of the other senders in order of first appearance.
+func senderNames(messages []model.Message) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, msg := range messages {
+		if msg.SenderType == model.SenderUser || msg.SenderInfo == nil || msg.SenderInfo.Name == "" {
+			continue
+		}
+		if !seen[msg.SenderInfo.Name] {
+			seen[msg.SenderInfo.Name] = true
+			names = append(names, msg.SenderInfo.Name)
+		}
+	}
+	return names
+}
+
+// joinNames lists names in prose, counting those after the first maxNamedSender.
+func joinNames(names []string) string {
+	if len(names) > maxNamedSender {
+		rest := len(names) - maxNamedSender
+		names = append(names[:maxNamedSender:maxNamedSender], fmt.Sprintf("%d %s", rest, plural(rest, "other", "others")))
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// truncate shortens a sentence to maxSentence runes.
+func truncate(s string) string {
+	if runes := []rune(s); len(runes) > maxSentence {
+		return strings.TrimSpace(string(runes[:maxSentence-1])) + "…"
+	}
+	return s
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
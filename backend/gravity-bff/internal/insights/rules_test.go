@@ -0,0 +1,132 @@
+package insights
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+func message(id string, sender string, content string) model.Message {
+	msg := model.Message{ID: id, SenderType: model.SenderUser, Content: content}
+	if sender != "" {
+		msg.SenderType = model.SenderOther
+		msg.SenderInfo = &model.User{Name: sender}
+	}
+	return msg
+}
+
+func TestRuleBased_Summarize(t *testing.T) {
+	p := NewRuleBased()
+	thread := model.InsightThread{Messages: []model.Message{
+		message("m1", "Ana Lima", "Kicking off the launch plan."),
+		message("m2", "", "Sounds good."),
+		message("m3", "Ben Ode", "Draft attached! Let me know what you think."),
+	}}
+
+	summary, err := p.Summarize(context.Background(), thread)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3 messages from Ana Lima and Ben Ode. Latest: Draft attached", summary)
+}
+
+func TestRuleBased_ExtractActionItems(t *testing.T) {
+	p := NewRuleBased()
+	thread := model.InsightThread{Messages: []model.Message{
+		message("m1", "Ana Lima", "Please send the budget."),
+		message("m2", "", "Will do."),
+		message("m3", "Ana Lima", "Thanks! Can you also review the deck by Friday? Lunch was great.\n- [ ] Book the venue\n- please book the venue"),
+	}}
+
+	actions, err := p.ExtractActionItems(context.Background(), thread)
+
+	// Only asks made since the owner's last message count, once each
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Can you also review the deck by Friday", "Book the venue", "please book the venue"}, actions)
+}
+
+func TestRuleBased_SuggestReply(t *testing.T) {
+	p := NewRuleBased()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		messages []model.Message
+		want     string
+	}{
+		{"owner wrote last", []model.Message{message("m1", "Ana Lima", "Please call me"), message("m2", "", "Calling now")}, ""},
+		{"asked for something", []model.Message{message("m1", "Ana Lima", "Please call me")}, "Thanks, Ana. I'll take care of it and let you know once it's done."},
+		{"asked a question", []model.Message{message("m1", "Ana Lima", "How was the trip?")}, "Thanks, Ana. Let me look into it and get back to you shortly."},
+		{"informed", []model.Message{message("m1", "Ana Lima", "The build is green.")}, "Thanks, Ana, noted."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply, err := p.SuggestReply(ctx, model.InsightThread{Messages: tt.messages})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, reply)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	thread := model.InsightThread{Messages: []model.Message{
+		message("m1", "Ana Lima", "Could you sign the contract today?"),
+	}}
+
+	insights, err := Generate(context.Background(), NewRuleBased(), thread)
+
+	require.NoError(t, err)
+	require.Len(t, insights, 3)
+	assert.Equal(t, "m1-summary", insights[0].ID)
+	assert.Equal(t, model.InsightAnalysis, insights[0].Type)
+	assert.Equal(t, "- Could you sign the contract today", insights[1].Content)
+	assert.Equal(t, model.InsightDraft, insights[2].Type)
+	assert.True(t, insights[2].IsDraft)
+
+	// Deterministic: the same thread yields the same insights
+	again, err := Generate(context.Background(), NewRuleBased(), thread)
+	require.NoError(t, err)
+	assert.Equal(t, insights, again)
+
+	empty, err := Generate(context.Background(), NewRuleBased(), model.InsightThread{})
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestNewProvider(t *testing.T) {
+	p, err := NewProvider("rules")
+	assert.NoError(t, err)
+	assert.Equal(t, RuleBasedName, p.Name())
+
+	_, err = NewProvider("gpt-9")
+	assert.True(t, errors.Is(err, ErrUnknownProvider))
+}
+
+func TestRuleBased_RefineReply(t *testing.T) {
+	p := NewRuleBased()
+	ctx := context.Background()
+	thread := model.InsightThread{Messages: []model.Message{message("m1", "Ana Lima", "How was the trip?")}}
+	draft := model.AIInsight{Content: "Thanks, Ana. Let me look into it and get back to you shortly.", IsDraft: true}
+
+	short, err := p.RefineReply(ctx, thread, draft, "Make it shorter please")
+	assert.NoError(t, err)
+	assert.Equal(t, "Checking, will get back to you.", short)
+
+	// Regenerating skips the phrasings the draft already had
+	draft.Versions = []model.DraftVersion{
+		{Content: draft.Content},
+		{Content: "Thanks, Ana. Good question, I'll check and come back to you."},
+	}
+	draft.Content = draft.Versions[1].Content
+	regenerated, err := p.RefineReply(ctx, thread, draft, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Thanks, Ana. I'll find out and follow up soon.", regenerated)
+
+	_, err = p.RefineReply(ctx, thread, draft, "translate to French")
+	assert.ErrorIs(t, err, ErrUnsupportedInstruction)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// queryBuilder accumulates a SQL statement and its positional arguments.
+type queryBuilder struct {
+	sql  strings.Builder
+	args []interface{}
+}
+
+// newQueryBuilder starts a query with the given base statement.
+func newQueryBuilder(base string, args ...interface{}) *queryBuilder {
+	b := &queryBuilder{args: args}
+	b.sql.WriteString(base)
+	return b
+}
+
+// arg registers a positional argument and returns its placeholder.
+func (b *queryBuilder) arg(v interface{}) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// where appends a condition joined with AND.
+// Each %s in the condition is replaced by the placeholder of the matching argument.
+func (b *queryBuilder) where(condition string, args ...interface{}) {
+	placeholders := make([]interface{}, len(args))
+	for i, a := range args {
+		placeholders[i] = b.arg(a)
+	}
+	b.sql.WriteString(" AND ")
+	b.sql.WriteString(fmt.Sprintf(condition, placeholders...))
+}
+
+// append adds raw SQL to the statement.
+func (b *queryBuilder) append(sql string) {
+	b.sql.WriteString(sql)
+}
+
+// String returns the SQL statement.
+func (b *queryBuilder) String() string {
+	return b.sql.String()
+}
+
+// applyStreamFilter adds the filter's conditions to a query over priority_items aliased as p.
+func applyStreamFilter(b *queryBuilder, f model.StreamFilter) {
+	switch {
+	case f.Trashed:
+		b.append(" AND p.deleted_at IS NOT NULL")
+	case f.Archived:
+		b.append(" AND p.deleted_at IS NULL AND p.archived_at IS NOT NULL")
+	case f.Snoozed:
+		b.append(" AND p.deleted_at IS NULL AND p.archived_at IS NULL AND p.snoozed_until IS NOT NULL")
+	default:
+		b.append(" AND p.deleted_at IS NULL AND p.archived_at IS NULL AND p.snoozed_until IS NULL")
+	}
+
+	if len(f.Sources) > 0 {
+		sources := make([]string, len(f.Sources))
+		for i, s := range f.Sources {
+			sources[i] = string(s)
+		}
+		b.where("p.source = ANY(%s)", sources)
+	}
+
+	if len(f.Priorities) > 0 {
+		priorities := make([]string, len(f.Priorities))
+		for i, p := range f.Priorities {
+			priorities[i] = string(p)
+		}
+		b.where("p.priority = ANY(%s)", priorities)
+	}
+
+	if f.Unread != nil {
+		b.where("p.is_unread = %s", *f.Unread)
+	}
+
+	if f.Since != nil {
+		b.where("p.item_timestamp >= %s", *f.Since)
+	}
+
+	if f.Until != nil {
+		b.where("p.item_timestamp < %s", *f.Until)
+	}
+
+	if f.WakesBefore != nil {
+		b.where("p.snoozed_until < %s", *f.WakesBefore)
+	}
+
+	if f.LastSender != "" {
+		sources := make([]string, len(model.ConversationSources))
+		for i, s := range model.ConversationSources {
+			sources[i] = string(s)
+		}
+		b.where("p.last_sender_type = %s AND p.source = ANY(%s)", string(f.LastSender), sources)
+	}
+
+	if f.ParticipantID != nil {
+		b.where(`EXISTS (
+			SELECT 1 FROM priority_item_participants pip
+			WHERE pip.item_id = p.id AND pip.user_id = %s
+		)`, *f.ParticipantID)
+	}
+
+	if len(f.Labels) > 0 {
+		b.where(`EXISTS (
+			SELECT 1 FROM priority_item_labels pil
+			WHERE pil.item_id = p.id AND pil.label_id = ANY(%s::uuid[])
+		)`, f.Labels)
+	}
+
+	if f.HasAttachments != nil {
+		exists := `EXISTS (
+			SELECT 1 FROM messages m
+			WHERE m.item_id = p.id
+			  AND jsonb_typeof(m.attachments) = 'array'
+			  AND jsonb_array_length(m.attachments) > 0
+		)`
+		if *f.HasAttachments {
+			b.append(" AND " + exists)
+		} else {
+			b.append(" AND NOT " + exists)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+)
+
+// Ensure PgCalendarRepository implements the CalendarRepository interface.
+var _ repository.CalendarRepository = (*PgCalendarRepository)(nil)
+
+// PgCalendarRepository implements CalendarRepository using PostgreSQL.
+type PgCalendarRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPgCalendarRepository creates a new PostgreSQL calendar repository.
+func NewPgCalendarRepository(db *pgxpool.Pool) *PgCalendarRepository {
+	return &PgCalendarRepository{db: db}
+}
+
+// GetEvents retrieves the calendar events of a user's items that are not in the
+// trash and overlap [from, to). Versions are picked before the range is applied,
+// so that an event moved out of the range is not exported in its old place.
+func (r *PgCalendarRepository) GetEvents(ctx context.Context, userID string, from, to time.Time) ([]model.CalendarEvent, error) {
+	query := `
+		SELECT m.event_details
+		FROM (
+			SELECT DISTINCT ON (ce.event_id) ce.message_id, ce.item_id, ce.start_time, ce.end_time
+			FROM calendar_events ce
+			WHERE ce.user_id = $1
+			  AND ce.event_id IN (
+				SELECT event_id FROM calendar_events
+				WHERE user_id = $1 AND start_time < $3 AND end_time > $2
+			  )
+			ORDER BY ce.event_id, ce.message_timestamp DESC
+		) latest
+		JOIN messages m ON m.id = latest.message_id
+		JOIN priority_items p ON p.id = latest.item_id
+		WHERE p.deleted_at IS NULL
+		  AND latest.start_time < $3
+		  AND latest.end_time > $2
+		ORDER BY latest.start_time
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query calendar events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []model.CalendarEvent{}
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("failed to scan calendar event: %w", err)
+		}
+
+		var event model.CalendarEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			// Skip malformed event details rather than failing the whole feed
+			continue
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetEventSlots retrieves when the events of a user's items that are not in the trash take place.
+// Versions are picked before the range is applied, so that an event moved out
+// of the range is not returned in its old place.
+func (r *PgCalendarRepository) GetEventSlots(ctx context.Context, userID string, from, to time.Time) ([]model.EventSlot, error) {
+	query := `
+		SELECT latest.message_id, latest.item_id, latest.event_id, COALESCE(m.event_details->>'title', ''),
+		       latest.start_time, latest.end_time, m.ai_insights
+		FROM (
+			SELECT DISTINCT ON (ce.event_id) ce.message_id, ce.item_id, ce.event_id, ce.start_time, ce.end_time
+			FROM calendar_events ce
+			WHERE ce.user_id = $1
+			  AND ce.event_id IN (
+				SELECT event_id FROM calendar_events
+				WHERE user_id = $1 AND start_time < $3 AND end_time > $2
+			  )
+			ORDER BY ce.event_id, ce.message_timestamp DESC
+		) latest
+		JOIN messages m ON m.id = latest.message_id
+		JOIN priority_items p ON p.id = latest.item_id
+		WHERE p.deleted_at IS NULL
+		  AND latest.start_time < $3
+		  AND latest.end_time > $2
+		ORDER BY latest.start_time, latest.event_id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event slots: %w", err)
+	}
+	defer rows.Close()
+
+	slots := []model.EventSlot{}
+	for rows.Next() {
+		var slot model.EventSlot
+		var insightsData []byte
+		err := rows.Scan(
+			&slot.MessageID,
+			&slot.ItemID,
+			&slot.EventID,
+			&slot.Title,
+			&slot.StartTime,
+			&slot.EndTime,
+			&insightsData,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event slot: %w", err)
+		}
+
+		var insights []model.AIInsight
+		if len(insightsData) > 0 && json.Unmarshal(insightsData, &insights) == nil {
+			id := model.ConflictInsightID(slot.MessageID)
+			for i := range insights {
+				if insights[i].ID == id {
+					slot.Conflicts = &insights[i]
+					break
+				}
+			}
+		}
+		slots = append(slots, slot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return slots, nil
+}
+
+// SetConflictInsight stores the conflict insight of an event's message ahead of
+// its other insights, replacing the previous one. A nil insight removes it.
+func (r *PgCalendarRepository) SetConflictInsight(ctx context.Context, messageID string, insight *model.AIInsight) error {
+	var data []byte
+	if insight != nil {
+		var err error
+		if data, err = json.Marshal([]model.AIInsight{*insight}); err != nil {
+			return fmt.Errorf("failed to encode insight: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE messages
+		SET ai_insights = COALESCE($3::jsonb, '[]'::jsonb) || COALESCE((
+			SELECT jsonb_agg(t.elem ORDER BY t.ord)
+			FROM jsonb_array_elements(
+				CASE WHEN jsonb_typeof(ai_insights) = 'array' THEN ai_insights ELSE '[]'::jsonb END
+			) WITH ORDINALITY AS t(elem, ord)
+			WHERE t.elem->>'id' IS DISTINCT FROM $2
+		), '[]'::jsonb)
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, messageID, model.ConflictInsightID(messageID), data); err != nil {
+		return fmt.Errorf("failed to save conflict insight: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// countedColumns select what the stream counts know about an item aliased as p:
+// whether it is in the default stream, its source, read state, whether it is
+// high priority and its label IDs. They are scanned by scanCountedItem.
+const countedColumns = `p.deleted_at IS NULL AND p.archived_at IS NULL AND p.snoozed_until IS NULL,
+			   p.source, p.is_unread, p.priority = 'high',
+			   ARRAY(SELECT pil.label_id::text FROM priority_item_labels pil WHERE pil.item_id = p.id)`
+
+// GetCounts counts the items in a user's default stream, in total and by
+// source and label, in a single aggregate query.
+func (r *PgStreamRepository) GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error) {
+	// Items with several labels appear once per label after the join,
+	// hence the DISTINCT counts
+	b := newQueryBuilder(`
+		SELECT GROUPING(p.source) = 0, GROUPING(pil.label_id) = 0,
+			   COALESCE(p.source, ''), COALESCE(pil.label_id::text, ''),
+			   COUNT(DISTINCT p.id),
+			   COUNT(DISTINCT p.id) FILTER (WHERE p.is_unread),
+			   COUNT(DISTINCT p.id) FILTER (WHERE p.priority = 'high')
+		FROM priority_items p
+		LEFT JOIN priority_item_labels pil ON pil.item_id = p.id
+		WHERE p.user_id = $1`, userID)
+	applyStreamFilter(b, model.StreamFilter{})
+	b.append(" GROUP BY GROUPING SETS ((p.source), (pil.label_id), ())")
+
+	rows, err := r.db.Query(ctx, b.String(), b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := model.NewCountsResponse()
+	for rows.Next() {
+		var bySource, byLabel bool
+		var source, labelID string
+		var sc model.StreamCounts
+		if err := rows.Scan(&bySource, &byLabel, &source, &labelID, &sc.Total, &sc.Unread, &sc.High); err != nil {
+			return nil, fmt.Errorf("failed to scan counts: %w", err)
+		}
+
+		switch {
+		case bySource:
+			counts.Sources[model.SourceType(source)] = sc
+		case byLabel:
+			if labelID != "" { // Skip the group of unlabeled items
+				counts.Labels[labelID] = sc
+			}
+		default:
+			counts.StreamCounts = sc
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// scanCountedItem scans the countedColumns of a row, preceded by the given
+// destinations. Returns nil if the item is not in the default stream.
+func scanCountedItem(row pgx.Row, dest ...interface{}) (*model.CountedItem, error) {
+	var visible bool
+	var source string
+	var item model.CountedItem
+	dest = append(dest, &visible, &source, &item.Unread, &item.High, &item.LabelIDs)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+	item.Source = model.SourceType(source)
+	return &item, nil
+}
+
+// countedItems retrieves the counted state of the given items.
+// Items that are not in the default stream are absent from the result.
+func countedItems(ctx context.Context, db *pgxpool.Pool, itemIDs []string) (map[string]model.CountedItem, error) {
+	counted := make(map[string]model.CountedItem, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return counted, nil
+	}
+
+	query := `
+		SELECT p.id, ` + countedColumns + `
+		FROM priority_items p
+		WHERE p.id = ANY($1::uuid[])
+	`
+
+	rows, err := db.Query(ctx, query, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query counted items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		item, err := scanCountedItem(rows, &id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan counted item: %w", err)
+		}
+		if item != nil {
+			counted[id] = *item
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return counted, nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+)
+
+// Ensure PgIngestRepository implements the IngestRepository interface.
+var _ repository.IngestRepository = (*PgIngestRepository)(nil)
+
+// PgIngestRepository implements IngestRepository using PostgreSQL.
+type PgIngestRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPgIngestRepository creates a new PostgreSQL ingest repository.
+func NewPgIngestRepository(db *pgxpool.Pool) *PgIngestRepository {
+	return &PgIngestRepository{db: db}
+}
+
+// UpsertItem creates or updates a priority item with its participants and messages.
+func (r *PgIngestRepository) UpsertItem(ctx context.Context, item model.IngestItem) (*model.IngestResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Record the counted state of an existing item before it changes
+	before, err := scanCountedItem(tx.QueryRow(ctx, `
+		SELECT `+countedColumns+`
+		FROM priority_items p
+		WHERE p.user_id = $1 AND p.source = $2 AND p.external_id = $3
+		FOR UPDATE
+	`, item.UserID, string(item.Source), item.ExternalID))
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to get counted item: %w", err)
+	}
+
+	// Priority and read state belong to the user once the item exists,
+	// so a redelivery only refreshes the content.
+	itemQuery := `
+		INSERT INTO priority_items (user_id, source, external_id, title, priority, is_unread, snippet, item_timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, source, external_id) DO UPDATE SET
+			title = EXCLUDED.title,
+			snippet = COALESCE(EXCLUDED.snippet, priority_items.snippet),
+			item_timestamp = GREATEST(priority_items.item_timestamp, EXCLUDED.item_timestamp)
+		RETURNING id, (xmax = 0)
+	`
+
+	result := &model.IngestResult{
+		ExternalID:    item.ExternalID,
+		NewMessages:   []string{},
+		CountedBefore: before,
+	}
+	err = tx.QueryRow(ctx, itemQuery,
+		item.UserID,
+		string(item.Source),
+		item.ExternalID,
+		item.Title,
+		string(item.Priority),
+		item.IsUnread,
+		item.Snippet,
+		item.Timestamp,
+	).Scan(&result.ItemID, &result.Created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert item: %w", err)
+	}
+
+	for _, contact := range item.Participants {
+		userID, err := upsertContact(ctx, tx, contact)
+		if err != nil {
+			return nil, err
+		}
+		if userID == "" {
+			continue
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO priority_item_participants (item_id, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, result.ItemID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add participant: %w", err)
+		}
+	}
+
+	for _, msg := range item.Messages {
+		messageID, inserted, err := upsertMessage(ctx, tx, result.ItemID, msg)
+		if err != nil {
+			return nil, err
+		}
+		if inserted {
+			result.NewMessages = append(result.NewMessages, messageID)
+		}
+	}
+
+	// New activity on an existing item brings it back out of the archive,
+	// and as unread if the source says so. Trashed items stay in the trash.
+	if !result.Created && len(result.NewMessages) > 0 {
+		_, err = tx.Exec(ctx, `
+			UPDATE priority_items
+			SET archived_at = NULL, is_unread = is_unread OR $2
+			WHERE id = $1
+		`, result.ItemID, item.IsUnread)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resurface item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit item: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetCountedItems retrieves the counted state of the given items.
+func (r *PgIngestRepository) GetCountedItems(ctx context.Context, itemIDs []string) (map[string]model.CountedItem, error) {
+	return countedItems(ctx, r.db, itemIDs)
+}
+
+// upsertMessage creates or refreshes a message by its external ID.
+// Returns the message ID and whether it was newly inserted.
+func upsertMessage(ctx context.Context, tx pgx.Tx, itemID string, msg model.IngestMessage) (string, bool, error) {
+	var senderID *string
+	if msg.From != nil {
+		id, err := upsertContact(ctx, tx, *msg.From)
+		if err != nil {
+			return "", false, err
+		}
+		if id != "" {
+			senderID = &id
+		}
+	}
+
+	eventDetails, err := marshalJSONB(msg.EventDetails)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal event details: %w", err)
+	}
+	socialDetails, err := marshalJSONB(msg.SocialContent)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal social details: %w", err)
+	}
+	attachments, err := marshalJSONB(msg.Attachments)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal attachments: %w", err)
+	}
+
+	// AI insights are generated on our side and survive redelivery, as do
+	// reschedules and RSVPs, which are reapplied over the redelivered event.
+	query := `
+		INSERT INTO messages (
+			item_id, external_id, sender_id, sender_type, content_type, content,
+			full_content_html, message_timestamp, event_details, social_details, attachments
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (item_id, external_id) DO UPDATE SET
+			sender_id = EXCLUDED.sender_id,
+			content = EXCLUDED.content,
+			full_content_html = EXCLUDED.full_content_html,
+			event_details = EXCLUDED.event_details || COALESCE(messages.event_edits, '{}'::jsonb),
+			social_details = EXCLUDED.social_details,
+			attachments = EXCLUDED.attachments
+		RETURNING id, (xmax = 0)
+	`
+
+	var id string
+	var inserted bool
+	err = tx.QueryRow(ctx, query,
+		itemID,
+		msg.ExternalID,
+		senderID,
+		string(msg.SenderType),
+		string(msg.ContentType),
+		msg.Content,
+		msg.FullContentHTML,
+		msg.Timestamp,
+		eventDetails,
+		socialDetails,
+		attachments,
+	).Scan(&id, &inserted)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to upsert message: %w", err)
+	}
+
+	return id, inserted, nil
+}
+
+// upsertContact finds or creates the user for a contact, keyed by email or external ID.
+// Returns an empty ID for contacts with neither.
+func upsertContact(ctx context.Context, tx pgx.Tx, contact model.Contact) (string, error) {
+	name := strings.TrimSpace(contact.Name)
+
+	var query string
+	var args []interface{}
+	switch {
+	case contact.Email != nil && *contact.Email != "":
+		email := strings.ToLower(strings.TrimSpace(*contact.Email))
+		if name == "" {
+			name = email
+		}
+		query = `
+			INSERT INTO users (name, email, avatar_url)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (email) DO UPDATE SET
+				name = EXCLUDED.name,
+				avatar_url = COALESCE(EXCLUDED.avatar_url, users.avatar_url)
+			RETURNING id::text
+		`
+		args = []interface{}{name, email, contact.AvatarURL}
+	case contact.ExternalID != nil && *contact.ExternalID != "":
+		if name == "" {
+			name = *contact.ExternalID
+		}
+		query = `
+			INSERT INTO users (name, external_id, avatar_url)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (external_id) DO UPDATE SET
+				name = EXCLUDED.name,
+				avatar_url = COALESCE(EXCLUDED.avatar_url, users.avatar_url)
+			RETURNING id::text
+		`
+		args = []interface{}{name, *contact.ExternalID, contact.AvatarURL}
+	default:
+		return "", nil
+	}
+
+	var id string
+	if err := tx.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return "", fmt.Errorf("failed to upsert contact: %w", err)
+	}
+	return id, nil
+}
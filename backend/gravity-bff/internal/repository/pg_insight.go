@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+)
+
+// Ensure PgInsightRepository implements the InsightRepository interface.
+var _ repository.InsightRepository = (*PgInsightRepository)(nil)
+
+// PgInsightRepository implements InsightRepository using PostgreSQL.
+type PgInsightRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPgInsightRepository creates a new PostgreSQL insight repository.
+func NewPgInsightRepository(db *pgxpool.Pool) *PgInsightRepository {
+	return &PgInsightRepository{db: db}
+}
+
+// QueueItems queues items for insight generation.
+func (r *PgInsightRepository) QueueItems(ctx context.Context, itemIDs []string) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO insight_queue (item_id)
+		SELECT DISTINCT unnest($1::uuid[])
+		ON CONFLICT (item_id) DO UPDATE SET queued_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, itemIDs); err != nil {
+		return fmt.Errorf("failed to queue items for insights: %w", err)
+	}
+
+	return nil
+}
+
+// DequeueItems removes up to limit items from the front of the queue.
+// Rows locked by a concurrent dequeue are skipped.
+func (r *PgInsightRepository) DequeueItems(ctx context.Context, limit int) ([]string, error) {
+	query := `
+		DELETE FROM insight_queue
+		WHERE item_id IN (
+			SELECT item_id FROM insight_queue
+			ORDER BY queued_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING item_id::text
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dequeue items: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan item ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetThread retrieves an item with its latest messages for insight generation.
+func (r *PgInsightRepository) GetThread(ctx context.Context, itemID string) (*model.InsightThread, error) {
+	query := `
+		SELECT id, user_id, title, source
+		FROM priority_items
+		WHERE id = $1
+	`
+
+	var thread model.InsightThread
+	var source string
+	err := r.db.QueryRow(ctx, query, itemID).Scan(&thread.ItemID, &thread.UserID, &thread.Title, &source)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Item not found
+		}
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+	thread.Source = model.SourceType(source)
+
+	messages, _, err := queryMessages(ctx, r.db, itemID, nil, model.ItemMessagesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	thread.Messages = messages
+
+	return &thread, nil
+}
+
+// SaveInsights replaces the insights stored on a message, keeping its calendar
+// conflict insight, which is maintained on its own.
+func (r *PgInsightRepository) SaveInsights(ctx context.Context, messageID string, insights []model.AIInsight) error {
+	data, err := json.Marshal(insights)
+	if err != nil {
+		return fmt.Errorf("failed to encode insights: %w", err)
+	}
+
+	query := `
+		UPDATE messages
+		SET ai_insights = COALESCE((
+			SELECT jsonb_agg(t.elem)
+			FROM jsonb_array_elements(
+				CASE WHEN jsonb_typeof(ai_insights) = 'array' THEN ai_insights ELSE '[]'::jsonb END
+			) AS t(elem)
+			WHERE t.elem->>'id' = $3
+		), '[]'::jsonb) || $2::jsonb
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, messageID, data, model.ConflictInsightID(messageID)); err != nil {
+		return fmt.Errorf("failed to save insights: %w", err)
+	}
+
+	return nil
+}
+
+// GetMessageInsights retrieves the insights stored on a message of an item.
+func (r *PgInsightRepository) GetMessageInsights(ctx context.Context, itemID, messageID string) ([]model.AIInsight, error) {
+	query := `
+		SELECT ai_insights
+		FROM messages
+		WHERE id = $1 AND item_id = $2
+	`
+
+	var data []byte
+	err := r.db.QueryRow(ctx, query, messageID, itemID).Scan(&data)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Message not found
+		}
+		return nil, fmt.Errorf("failed to get message insights: %w", err)
+	}
+
+	insights := []model.AIInsight{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &insights); err != nil {
+			return nil, fmt.Errorf("failed to decode insights: %w", err)
+		}
+	}
+
+	return insights, nil
+}
+
+// ReplaceInsight replaces one insight stored on a message, keeping the order of the others.
+// prev is compared field by field rather than as JSON, so that insights stored
+// by other writers, with fields omitted, still match.
+func (r *PgInsightRepository) ReplaceInsight(ctx context.Context, messageID string, prev, next model.AIInsight) (bool, error) {
+	data, err := json.Marshal(next)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode insight: %w", err)
+	}
+
+	query := `
+		UPDATE messages
+		SET ai_insights = (
+			SELECT jsonb_agg(CASE WHEN t.elem->>'id' = $2 THEN $5::jsonb ELSE t.elem END ORDER BY t.ord)
+			FROM jsonb_array_elements(ai_insights) WITH ORDINALITY AS t(elem, ord)
+		)
+		WHERE id = $1
+		  AND CASE WHEN jsonb_typeof(ai_insights) = 'array' THEN EXISTS (
+			SELECT 1 FROM jsonb_array_elements(ai_insights) AS e(elem)
+			WHERE e.elem->>'id' = $2
+			  AND e.elem->>'content' = $3
+			  AND COALESCE((e.elem->>'isDraft')::boolean, false) = $4
+		  ) ELSE false END
+	`
+
+	tag, err := r.db.Exec(ctx, query, messageID, prev.ID, prev.Content, prev.IsDraft, data)
+	if err != nil {
+		return false, fmt.Errorf("failed to replace insight: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+)
+
+// Ensure PgLabelRepository implements the LabelRepository interface.
+var _ repository.LabelRepository = (*PgLabelRepository)(nil)
+
+// PgLabelRepository implements LabelRepository using PostgreSQL.
+type PgLabelRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPgLabelRepository creates a new PostgreSQL label repository.
+func NewPgLabelRepository(db *pgxpool.Pool) *PgLabelRepository {
+	return &PgLabelRepository{db: db}
+}
+
+// labelColumns are the columns scanned by scanLabel.
+const labelColumns = `id, user_id, name, color`
+
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation.
+const uniqueViolation = "23505"
+
+// ListLabels retrieves a user's labels ordered by name.
+func (r *PgLabelRepository) ListLabels(ctx context.Context, userID string) ([]model.Label, error) {
+	query := `
+		SELECT ` + labelColumns + `
+		FROM labels
+		WHERE user_id = $1
+		ORDER BY LOWER(name), id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query labels: %w", err)
+	}
+	defer rows.Close()
+
+	labels := []model.Label{}
+	for rows.Next() {
+		label, err := scanLabel(rows)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, *label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return labels, nil
+}
+
+// GetLabel retrieves a label owned by the user.
+func (r *PgLabelRepository) GetLabel(ctx context.Context, userID, labelID string) (*model.Label, error) {
+	query := `
+		SELECT ` + labelColumns + `
+		FROM labels
+		WHERE id = $1 AND user_id = $2
+	`
+
+	label, err := scanLabel(r.db.QueryRow(ctx, query, labelID, userID))
+	if err == pgx.ErrNoRows {
+		return nil, nil // Label not found
+	}
+	return label, err
+}
+
+// CreateLabel stores a new label.
+func (r *PgLabelRepository) CreateLabel(ctx context.Context, label model.Label) (*model.Label, error) {
+	query := `
+		INSERT INTO labels (user_id, name, color)
+		VALUES ($1, $2, $3)
+		RETURNING ` + labelColumns
+
+	created, err := scanLabel(r.db.QueryRow(ctx, query, label.UserID, label.Name, label.Color))
+	if isUniqueViolation(err) {
+		return nil, repository.ErrLabelExists
+	}
+	return created, err
+}
+
+// UpdateLabel replaces a label owned by label.UserID.
+func (r *PgLabelRepository) UpdateLabel(ctx context.Context, label model.Label) (*model.Label, error) {
+	query := `
+		UPDATE labels
+		SET name = $3, color = $4
+		WHERE id = $1 AND user_id = $2
+		RETURNING ` + labelColumns
+
+	updated, err := scanLabel(r.db.QueryRow(ctx, query, label.ID, label.UserID, label.Name, label.Color))
+	if err == pgx.ErrNoRows {
+		return nil, nil // Label not found
+	}
+	if isUniqueViolation(err) {
+		return nil, repository.ErrLabelExists
+	}
+	return updated, err
+}
+
+// DeleteLabel deletes a label owned by the user.
+// Its item links are removed by cascade.
+func (r *PgLabelRepository) DeleteLabel(ctx context.Context, userID, labelID string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM labels WHERE id = $1 AND user_id = $2`, labelID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete label: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListLabeledItemIDs retrieves the IDs of the items a label is applied to.
+func (r *PgLabelRepository) ListLabeledItemIDs(ctx context.Context, userID, labelID string) ([]string, error) {
+	query := `
+		SELECT pil.item_id
+		FROM priority_item_labels pil
+		JOIN labels l ON l.id = pil.label_id
+		WHERE pil.label_id = $1 AND l.user_id = $2
+	`
+
+	rows, err := r.db.Query(ctx, query, labelID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query labeled items: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan labeled item: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// AddItemLabels applies labels owned by the user to an item owned by the user.
+// Label IDs that the user does not own are ignored.
+func (r *PgLabelRepository) AddItemLabels(ctx context.Context, userID, itemID string, labelIDs []string) (bool, error) {
+	query := `
+		WITH item AS (
+			SELECT id FROM priority_items WHERE id = $1 AND user_id = $2
+		), added AS (
+			INSERT INTO priority_item_labels (item_id, label_id)
+			SELECT item.id, l.id
+			FROM item
+			JOIN labels l ON l.user_id = $2 AND l.id = ANY($3::uuid[])
+			ON CONFLICT DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM item)
+	`
+
+	var found bool
+	if err := r.db.QueryRow(ctx, query, itemID, userID, labelIDs).Scan(&found); err != nil {
+		return false, fmt.Errorf("failed to add item labels: %w", err)
+	}
+	return found, nil
+}
+
+// RemoveItemLabel removes a label from an item owned by the user.
+func (r *PgLabelRepository) RemoveItemLabel(ctx context.Context, userID, itemID, labelID string) (bool, error) {
+	query := `
+		DELETE FROM priority_item_labels pil
+		USING priority_items p
+		WHERE pil.item_id = p.id
+		  AND p.id = $1 AND p.user_id = $2
+		  AND pil.label_id = $3
+	`
+
+	tag, err := r.db.Exec(ctx, query, itemID, userID, labelID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove item label: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetItemLabels retrieves the labels applied to an item.
+func (r *PgLabelRepository) GetItemLabels(ctx context.Context, itemID string) ([]model.Label, error) {
+	byItem, err := labelsByItemIDs(ctx, r.db, []string{itemID})
+	if err != nil {
+		return nil, err
+	}
+	if labels, ok := byItem[itemID]; ok {
+		return labels, nil
+	}
+	return []model.Label{}, nil
+}
+
+// labelsByItemIDs retrieves the labels applied to each of the given items, ordered by name.
+// Items without labels are absent from the result.
+func labelsByItemIDs(ctx context.Context, db *pgxpool.Pool, itemIDs []string) (map[string][]model.Label, error) {
+	byItem := make(map[string][]model.Label)
+	if len(itemIDs) == 0 {
+		return byItem, nil
+	}
+
+	query := `
+		SELECT pil.item_id, l.id, l.user_id, l.name, l.color
+		FROM priority_item_labels pil
+		JOIN labels l ON l.id = pil.label_id
+		WHERE pil.item_id = ANY($1::uuid[])
+		ORDER BY LOWER(l.name), l.id
+	`
+
+	rows, err := db.Query(ctx, query, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query item labels: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID string
+		var label model.Label
+		if err := rows.Scan(&itemID, &label.ID, &label.UserID, &label.Name, &label.Color); err != nil {
+			return nil, fmt.Errorf("failed to scan item label: %w", err)
+		}
+		byItem[itemID] = append(byItem[itemID], label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return byItem, nil
+}
+
+// scanLabel scans a row selected with labelColumns.
+func scanLabel(row pgx.Row) (*model.Label, error) {
+	var label model.Label
+	err := row.Scan(&label.ID, &label.UserID, &label.Name, &label.Color)
+	if err == pgx.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan label: %w", err)
+	}
+	return &label, nil
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
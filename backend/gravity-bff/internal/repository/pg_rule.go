@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+)
+
+// Ensure PgRuleRepository implements the RuleRepository interface.
+var _ repository.RuleRepository = (*PgRuleRepository)(nil)
+
+// PgRuleRepository implements RuleRepository using PostgreSQL.
+type PgRuleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPgRuleRepository creates a new PostgreSQL rule repository.
+func NewPgRuleRepository(db *pgxpool.Pool) *PgRuleRepository {
+	return &PgRuleRepository{db: db}
+}
+
+// ruleColumns are the columns scanned by scanRule.
+const ruleColumns = `id, user_id, name, enabled, position, match, conditions, actions, created_at, updated_at`
+
+// ListRules retrieves a user's rules in the order they apply.
+func (r *PgRuleRepository) ListRules(ctx context.Context, userID string) ([]model.Rule, error) {
+	query := `
+		SELECT ` + ruleColumns + `
+		FROM rules
+		WHERE user_id = $1
+		ORDER BY position, created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []model.Rule{}
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return rules, nil
+}
+
+// GetRule retrieves a rule owned by the user.
+func (r *PgRuleRepository) GetRule(ctx context.Context, userID, ruleID string) (*model.Rule, error) {
+	query := `
+		SELECT ` + ruleColumns + `
+		FROM rules
+		WHERE id = $1 AND user_id = $2
+	`
+
+	rule, err := scanRule(r.db.QueryRow(ctx, query, ruleID, userID))
+	if err == pgx.ErrNoRows {
+		return nil, nil // Rule not found
+	}
+	return rule, err
+}
+
+// CreateRule stores a new rule.
+func (r *PgRuleRepository) CreateRule(ctx context.Context, rule model.Rule) (*model.Rule, error) {
+	conditions, actions, err := marshalRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO rules (user_id, name, enabled, position, match, conditions, actions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + ruleColumns
+
+	return scanRule(r.db.QueryRow(ctx, query,
+		rule.UserID,
+		rule.Name,
+		rule.Enabled,
+		rule.Position,
+		string(rule.Match),
+		conditions,
+		actions,
+	))
+}
+
+// UpdateRule replaces a rule owned by rule.UserID.
+func (r *PgRuleRepository) UpdateRule(ctx context.Context, rule model.Rule) (*model.Rule, error) {
+	conditions, actions, err := marshalRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE rules
+		SET name = $3, enabled = $4, position = $5, match = $6, conditions = $7, actions = $8
+		WHERE id = $1 AND user_id = $2
+		RETURNING ` + ruleColumns
+
+	updated, err := scanRule(r.db.QueryRow(ctx, query,
+		rule.ID,
+		rule.UserID,
+		rule.Name,
+		rule.Enabled,
+		rule.Position,
+		string(rule.Match),
+		conditions,
+		actions,
+	))
+	if err == pgx.ErrNoRows {
+		return nil, nil // Rule not found
+	}
+	return updated, err
+}
+
+// DeleteRule deletes a rule owned by the user.
+func (r *PgRuleRepository) DeleteRule(ctx context.Context, userID, ruleID string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rules WHERE id = $1 AND user_id = $2`, ruleID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// NextRulePosition returns the position after the user's last rule.
+func (r *PgRuleRepository) NextRulePosition(ctx context.Context, userID string) (int, error) {
+	var position int
+	err := r.db.QueryRow(ctx, `SELECT COALESCE(MAX(position) + 1, 0) FROM rules WHERE user_id = $1`, userID).Scan(&position)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next rule position: %w", err)
+	}
+	return position, nil
+}
+
+// ApplyRuleEffect applies a rule effect to an item owned by the user.
+func (r *PgRuleRepository) ApplyRuleEffect(ctx context.Context, userID, itemID string, effect model.RuleEffect) error {
+	if effect.IsEmpty() {
+		return nil
+	}
+
+	var priority *string
+	if effect.Priority != nil {
+		p := string(*effect.Priority)
+		priority = &p
+	}
+
+	query := `
+		UPDATE priority_items
+		SET priority_override = COALESCE($3, priority_override),
+			priority = COALESCE($3, priority),
+			is_unread = COALESCE($4, is_unread)
+		WHERE id = $1 AND user_id = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, itemID, userID, priority, effect.Unread); err != nil {
+		return fmt.Errorf("failed to apply rule effect: %w", err)
+	}
+	return nil
+}
+
+// ListRuleCandidates retrieves the user's most recent items with the message
+// fields rules can test. Bodies and JSON details other than attachments are skipped.
+func (r *PgRuleRepository) ListRuleCandidates(ctx context.Context, userID string, limit int) ([]model.PriorityItem, error) {
+	itemQuery := `
+		SELECT id, title, source, priority, score, is_unread, snippet, item_timestamp
+		FROM priority_items
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY item_timestamp DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, itemQuery, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule candidates: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]model.PriorityItem, 0, limit)
+	index := make(map[string]int, limit)
+	ids := make([]string, 0, limit)
+	for rows.Next() {
+		var item model.PriorityItem
+		var source, priority string
+		err := rows.Scan(&item.ID, &item.Title, &source, &priority, &item.Score, &item.IsUnread, &item.Snippet, &item.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rule candidate: %w", err)
+		}
+		item.Source = model.SourceType(source)
+		item.Priority = model.Priority(priority)
+		index[item.ID] = len(items)
+		ids = append(ids, item.ID)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	rows.Close()
+
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	messageQuery := `
+		SELECT m.item_id, m.sender_type, m.content_type, COALESCE(m.content, ''),
+			   COALESCE(jsonb_array_length(m.attachments), 0), u.name, u.email
+		FROM messages m
+		LEFT JOIN users u ON m.sender_id = u.id
+		WHERE m.item_id = ANY($1)
+		ORDER BY m.message_timestamp ASC
+	`
+
+	msgRows, err := r.db.Query(ctx, messageQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule candidate messages: %w", err)
+	}
+	defer msgRows.Close()
+
+	for msgRows.Next() {
+		var itemID, senderType, contentType string
+		var msg model.Message
+		var attachmentCount int
+		var name, email *string
+		err := msgRows.Scan(&itemID, &senderType, &contentType, &msg.Content, &attachmentCount, &name, &email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rule candidate message: %w", err)
+		}
+		msg.SenderType = model.SenderType(senderType)
+		msg.ContentType = model.ContentType(contentType)
+		if name != nil {
+			msg.SenderInfo = &model.User{Name: *name, Email: email}
+		}
+		// Rules only test whether attachments exist
+		if attachmentCount > 0 {
+			msg.Attachments = make([]model.Attachment, attachmentCount)
+		}
+
+		i := index[itemID]
+		items[i].Messages = append(items[i].Messages, msg)
+	}
+
+	if err := msgRows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return items, nil
+}
+
+// scanRule scans a row selected with ruleColumns.
+func scanRule(row pgx.Row) (*model.Rule, error) {
+	var rule model.Rule
+	var match string
+	var conditions, actions []byte
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Enabled,
+		&rule.Position,
+		&match,
+		&conditions,
+		&actions,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan rule: %w", err)
+	}
+
+	rule.Match = model.RuleMatch(match)
+	if err := json.Unmarshal(conditions, &rule.Conditions); err != nil {
+		return nil, fmt.Errorf("failed to decode rule conditions: %w", err)
+	}
+	if err := json.Unmarshal(actions, &rule.Actions); err != nil {
+		return nil, fmt.Errorf("failed to decode rule actions: %w", err)
+	}
+
+	return &rule, nil
+}
+
+// marshalRule encodes a rule's conditions and actions for their JSONB columns.
+func marshalRule(rule model.Rule) ([]byte, []byte, error) {
+	conditions, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal rule conditions: %w", err)
+	}
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal rule actions: %w", err)
+	}
+	return conditions, actions, nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+)
+
+// Ensure PgScoringRepository implements the ScoringRepository interface.
+var _ repository.ScoringRepository = (*PgScoringRepository)(nil)
+
+// recentContentLimit is the number of latest messages from others checked for mentions.
+const recentContentLimit = 5
+
+// PgScoringRepository implements ScoringRepository using PostgreSQL.
+type PgScoringRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPgScoringRepository creates a new PostgreSQL scoring repository.
+func NewPgScoringRepository(db *pgxpool.Pool) *PgScoringRepository {
+	return &PgScoringRepository{db: db}
+}
+
+// ListItemsToScore returns the IDs of items that are due for scoring.
+func (r *PgScoringRepository) ListItemsToScore(ctx context.Context, scoredBefore time.Time, limit int) ([]string, error) {
+	query := `
+		SELECT id
+		FROM priority_items
+		WHERE (scored_at IS NULL OR scored_at < $1) AND deleted_at IS NULL
+		ORDER BY scored_at NULLS FIRST
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, scoredBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query items to score: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0, limit)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan item id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetScoreSignals gathers the scoring inputs for the given items.
+func (r *PgScoringRepository) GetScoreSignals(ctx context.Context, itemIDs []string, now time.Time) ([]model.ScoreSignals, error) {
+	if len(itemIDs) == 0 {
+		return []model.ScoreSignals{}, nil
+	}
+
+	// The owner is known by their own user record and by the senders of
+	// their replies in the item. Sender importance counts the owner's other
+	// items with the latest sender that the owner has replied in.
+	query := `
+		SELECT p.id, p.user_id, p.source, p.is_unread, p.item_timestamp, p.score, p.priority, p.priority_override,
+			   COALESCE(replies.n, 0),
+			   COALESCE(recent.contents, '{}'),
+			   COALESCE(owner.names, '{}'),
+			   next_event.start_time,
+			   EXISTS (
+				SELECT 1
+				FROM priority_item_participants pip
+				JOIN vip_contacts v ON v.contact_id::text = pip.user_id
+				WHERE pip.item_id = p.id AND v.user_id = p.user_id
+			   )
+		FROM priority_items p
+		LEFT JOIN LATERAL (
+			SELECT m.sender_id
+			FROM messages m
+			WHERE m.item_id = p.id AND m.sender_type = 'other' AND m.sender_id IS NOT NULL
+			ORDER BY m.message_timestamp DESC
+			LIMIT 1
+		) latest ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT COUNT(DISTINCT o.id) AS n
+			FROM messages theirs
+			JOIN priority_items o ON o.id = theirs.item_id
+			WHERE theirs.sender_id = latest.sender_id
+			  AND o.user_id = p.user_id
+			  AND o.id <> p.id
+			  AND EXISTS (
+				SELECT 1 FROM messages mine
+				WHERE mine.item_id = o.id AND mine.sender_type = 'user'
+			  )
+		) replies ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT array_agg(r.content ORDER BY r.message_timestamp DESC) AS contents
+			FROM (
+				SELECT m.content, m.message_timestamp
+				FROM messages m
+				WHERE m.item_id = p.id AND m.sender_type = 'other' AND m.content IS NOT NULL
+				ORDER BY m.message_timestamp DESC
+				LIMIT $3
+			) r
+		) recent ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT array_agg(DISTINCT n.name) AS names
+			FROM (
+				SELECT unnest(ARRAY[u.name, u.email]) AS name
+				FROM users u
+				WHERE u.clerk_id = p.user_id
+				UNION
+				SELECT unnest(ARRAY[u.name, u.email])
+				FROM messages m
+				JOIN users u ON u.id = m.sender_id
+				WHERE m.item_id = p.id AND m.sender_type = 'user'
+			) n
+			WHERE n.name IS NOT NULL
+		) owner ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT MIN(ce.start_time) AS start_time
+			FROM calendar_events ce
+			WHERE ce.item_id = p.id
+			  AND ce.start_time >= $2
+		) next_event ON TRUE
+		WHERE p.id = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, itemIDs, now, recentContentLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query score signals: %w", err)
+	}
+	defer rows.Close()
+
+	signals := make([]model.ScoreSignals, 0, len(itemIDs))
+	for rows.Next() {
+		var sig model.ScoreSignals
+		var source, priority string
+		var override *string
+		err := rows.Scan(
+			&sig.ItemID,
+			&sig.UserID,
+			&source,
+			&sig.IsUnread,
+			&sig.LastActivity,
+			&sig.Score,
+			&priority,
+			&override,
+			&sig.SenderReplies,
+			&sig.RecentContent,
+			&sig.OwnerNames,
+			&sig.NextEvent,
+			&sig.VIP,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan score signals: %w", err)
+		}
+		sig.Source = model.SourceType(source)
+		sig.Priority = model.Priority(priority)
+		if override != nil {
+			p := model.Priority(*override)
+			sig.PriorityOverride = &p
+		}
+		signals = append(signals, sig)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return signals, nil
+}
+
+// UpdateScores stores computed scores in one statement.
+func (r *PgScoringRepository) UpdateScores(ctx context.Context, scores []model.ItemScore) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(scores))
+	values := make([]float64, len(scores))
+	priorities := make([]string, len(scores))
+	for i, s := range scores {
+		ids[i] = s.ItemID
+		values[i] = s.Score
+		priorities[i] = string(s.Priority)
+	}
+
+	query := `
+		UPDATE priority_items p
+		SET score = s.score, priority = s.priority, scored_at = NOW()
+		FROM unnest($1::uuid[], $2::float8[], $3::text[]) AS s(id, score, priority)
+		WHERE p.id = s.id
+	`
+
+	if _, err := r.db.Exec(ctx, query, ids, values, priorities); err != nil {
+		return fmt.Errorf("failed to update scores: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// headlineOptions configures ts_headline fragments for search results.
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter= … "
+
+// highlightMarker is present in a headline only when the query matched the text.
+const highlightMarker = "<mark>"
+
+// SearchStream runs a full-text search over a user's items and their messages.
+func (r *PgStreamRepository) SearchStream(ctx context.Context, req model.SearchRequest) ([]model.SearchResult, *string, error) {
+	// Candidates are items matching directly or through any of their messages;
+	// both branches are served by the GIN indexes from migration 0003.
+	query := `
+		WITH q AS (
+			SELECT websearch_to_tsquery('english', $2) AS query
+		),
+		candidates AS (
+			SELECT p.id
+			FROM priority_items p, q
+			WHERE p.user_id = $1 AND p.deleted_at IS NULL AND p.search_vector @@ q.query
+			UNION
+			SELECT m.item_id
+			FROM messages m
+			JOIN priority_items p ON p.id = m.item_id, q
+			WHERE p.user_id = $1 AND p.deleted_at IS NULL AND m.search_vector @@ q.query
+		),
+		ranked AS (
+			SELECT p.id, p.title, p.source, p.priority, p.score, p.is_unread, p.snippet, p.item_timestamp,
+				   p.snoozed_until, p.archived_at, p.deleted_at,
+				   GREATEST(ts_rank(p.search_vector, q.query), COALESCE(bm.rank, 0))::float8 AS rank,
+				   bm.id AS message_id, bm.body AS message_body,
+				   q.query
+			FROM candidates c
+			JOIN priority_items p ON p.id = c.id
+			CROSS JOIN q
+			LEFT JOIN LATERAL (
+				SELECT m.id,
+					   ts_rank(m.search_vector, q.query) AS rank,
+					   concat_ws(' ', m.content, regexp_replace(m.full_content_html, '<[^>]*>', ' ', 'g')) AS body
+				FROM messages m
+				WHERE m.item_id = p.id AND m.search_vector @@ q.query
+				ORDER BY rank DESC, m.message_timestamp DESC
+				LIMIT 1
+			) bm ON TRUE
+		)
+		SELECT id, title, source, priority, score, is_unread, snippet, item_timestamp, snoozed_until, archived_at, deleted_at, rank,
+			   ts_headline('english', title, query, $3),
+			   ts_headline('english', coalesce(snippet, ''), query, $3),
+			   message_id,
+			   CASE WHEN message_body IS NULL THEN NULL
+					ELSE ts_headline('english', message_body, query, $3) END
+		FROM ranked
+	`
+
+	args := []interface{}{req.UserID, req.Query, headlineOptions}
+	argPos := 4
+
+	// Apply cursor-based pagination over (rank, timestamp, id)
+	if req.Cursor != nil && *req.Cursor != "" {
+		c, err := decodeCursor(*req.Cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		if c.Rank == nil {
+			return nil, nil, fmt.Errorf("invalid cursor: missing rank")
+		}
+		query += fmt.Sprintf(" WHERE (rank, item_timestamp, id) < ($%d, $%d, $%d)", argPos, argPos+1, argPos+2)
+		args = append(args, *c.Rank, c.Timestamp, c.ID)
+		argPos += 3
+	}
+
+	query += " ORDER BY rank DESC, item_timestamp DESC, id DESC"
+
+	// Fetch one extra to determine if there are more results
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	query += fmt.Sprintf(" LIMIT $%d", argPos)
+	args = append(args, limit+1)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search stream: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]model.SearchResult, 0, limit)
+	for rows.Next() {
+		var result model.SearchResult
+		var source, priority string
+		var titleHL, snippetHL string
+		var messageID, messageHL *string
+
+		err := rows.Scan(
+			&result.ID,
+			&result.Title,
+			&source,
+			&priority,
+			&result.Score,
+			&result.IsUnread,
+			&result.Snippet,
+			&result.Timestamp,
+			&result.SnoozedUntil,
+			&result.ArchivedAt,
+			&result.DeletedAt,
+			&result.Rank,
+			&titleHL,
+			&snippetHL,
+			&messageID,
+			&messageHL,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+
+		result.Source = model.SourceType(source)
+		result.Priority = model.Priority(priority)
+		result.Highlights = model.SearchHighlights{
+			Title:   matchedHighlight(&titleHL),
+			Snippet: matchedHighlight(&snippetHL),
+			Message: matchedHighlight(messageHL),
+		}
+		if result.Highlights.Message != nil {
+			result.Highlights.MessageID = messageID
+		}
+
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	// Check if there are more results
+	var nextCursor *string
+	if len(results) > limit {
+		results = results[:limit]
+		last := results[len(results)-1]
+		encoded := encodeRankedCursor(last.Rank, last.Timestamp, last.ID)
+		nextCursor = &encoded
+	}
+
+	// Fetch participants and labels for all results at once
+	ids := make([]string, len(results))
+	for i := range results {
+		ids[i] = results[i].ID
+	}
+	participants, err := r.GetParticipantsByItemIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	labels, err := labelsByItemIDs(ctx, r.db, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range results {
+		results[i].Participants = participants[results[i].ID]
+		results[i].Labels = labels[results[i].ID]
+		if results[i].Labels == nil {
+			results[i].Labels = []model.Label{}
+		}
+	}
+
+	return results, nextCursor, nil
+}
+
+// matchedHighlight returns the headline only if the query matched the text.
+func matchedHighlight(headline *string) *string {
+	if headline == nil || !strings.Contains(*headline, highlightMarker) {
+		return nil
+	}
+	return headline
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -29,9 +30,12 @@ func NewPgStreamRepository(db *pgxpool.Pool) *PgStreamRepository {
 }
 
 // cursor represents pagination cursor data.
+// Rank is only set for ranked (search) results, Score for score-sorted streams.
 type cursor struct {
 	Timestamp time.Time `json:"t"`
 	ID        string    `json:"id"`
+	Rank      *float64  `json:"r,omitempty"`
+	Score     *float64  `json:"s,omitempty"`
 }
 
 // encodeCursor encodes a cursor for pagination.
@@ -41,6 +45,20 @@ func encodeCursor(timestamp time.Time, id string) string {
 	return base64.URLEncoding.EncodeToString(data)
 }
 
+// encodeRankedCursor encodes a cursor for pagination over ranked results.
+func encodeRankedCursor(rank float64, timestamp time.Time, id string) string {
+	c := cursor{Timestamp: timestamp, ID: id, Rank: &rank}
+	data, _ := json.Marshal(c)
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// encodeScoreCursor encodes a cursor for pagination over score-sorted items.
+func encodeScoreCursor(score float64, timestamp time.Time, id string) string {
+	c := cursor{Timestamp: timestamp, ID: id, Score: &score}
+	data, _ := json.Marshal(c)
+	return base64.URLEncoding.EncodeToString(data)
+}
+
 // decodeCursor decodes a pagination cursor.
 func decodeCursor(encoded string) (*cursor, error) {
 	data, err := base64.URLEncoding.DecodeString(encoded)
@@ -57,24 +75,14 @@ func decodeCursor(encoded string) (*cursor, error) {
 // GetStream retrieves a paginated list of priority items for a user.
 func (r *PgStreamRepository) GetStream(ctx context.Context, req model.StreamRequest) ([]model.PriorityItem, *string, error) {
 	// Build the query based on filter
-	baseQuery := `
-		SELECT id, title, source, priority, is_unread, snippet, item_timestamp
-		FROM priority_items
-		WHERE user_id = $1
-	`
+	q := newQueryBuilder(`
+		SELECT `+streamItemColumns+`
+		FROM priority_items p
+		WHERE p.user_id = $1 AND p.pin_position IS NULL
+	`, req.UserID)
 
-	args := []interface{}{req.UserID}
-	argPos := 2
-
-	// Apply filter
-	switch req.Filter {
-	case model.FilterHigh:
-		baseQuery += fmt.Sprintf(" AND priority = $%d", argPos)
-		args = append(args, string(model.PriorityHigh))
-		argPos++
-	case model.FilterUnread:
-		baseQuery += " AND is_unread = TRUE"
-	}
+	// Pinned items are returned separately by GetPinnedItems
+	applyStreamFilter(q, req.Filter)
 
 	// Apply cursor-based pagination
 	if req.Cursor != nil && *req.Cursor != "" {
@@ -82,13 +90,22 @@ func (r *PgStreamRepository) GetStream(ctx context.Context, req model.StreamRequ
 		if err != nil {
 			return nil, nil, fmt.Errorf("invalid cursor: %w", err)
 		}
-		baseQuery += fmt.Sprintf(" AND (item_timestamp, id) < ($%d, $%d)", argPos, argPos+1)
-		args = append(args, c.Timestamp, c.ID)
-		argPos += 2
+		if req.Sort == model.SortScore {
+			if c.Score == nil {
+				return nil, nil, fmt.Errorf("invalid cursor: missing score")
+			}
+			q.where("(p.score, p.item_timestamp, p.id) < (%s, %s, %s)", *c.Score, c.Timestamp, c.ID)
+		} else {
+			q.where("(p.item_timestamp, p.id) < (%s, %s)", c.Timestamp, c.ID)
+		}
 	}
 
-	// Order by timestamp descending, then by ID for consistent ordering
-	baseQuery += " ORDER BY item_timestamp DESC, id DESC"
+	// Order by timestamp (or score, then timestamp) descending, then by ID for consistent ordering
+	if req.Sort == model.SortScore {
+		q.append(" ORDER BY p.score DESC, p.item_timestamp DESC, p.id DESC")
+	} else {
+		q.append(" ORDER BY p.item_timestamp DESC, p.id DESC")
+	}
 
 	// Fetch one extra to determine if there are more items
 	limit := req.Limit
@@ -98,10 +115,9 @@ func (r *PgStreamRepository) GetStream(ctx context.Context, req model.StreamRequ
 	if limit > 100 {
 		limit = 100
 	}
-	baseQuery += fmt.Sprintf(" LIMIT $%d", argPos)
-	args = append(args, limit+1)
+	q.append(" LIMIT " + q.arg(limit+1))
 
-	rows, err := r.db.Query(ctx, baseQuery, args...)
+	rows, err := r.db.Query(ctx, q.String(), q.args...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to query stream: %w", err)
 	}
@@ -109,23 +125,11 @@ func (r *PgStreamRepository) GetStream(ctx context.Context, req model.StreamRequ
 
 	items := make([]model.PriorityItem, 0, limit)
 	for rows.Next() {
-		var item model.PriorityItem
-		var source, priority string
-		err := rows.Scan(
-			&item.ID,
-			&item.Title,
-			&source,
-			&priority,
-			&item.IsUnread,
-			&item.Snippet,
-			&item.Timestamp,
-		)
+		item, err := scanStreamItem(rows)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+			return nil, nil, err
 		}
-		item.Source = model.SourceType(source)
-		item.Priority = model.Priority(priority)
-		items = append(items, item)
+		items = append(items, *item)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -138,67 +142,182 @@ func (r *PgStreamRepository) GetStream(ctx context.Context, req model.StreamRequ
 		items = items[:limit]
 		lastItem := items[len(items)-1]
 		encoded := encodeCursor(lastItem.Timestamp, lastItem.ID)
+		if req.Sort == model.SortScore {
+			encoded = encodeScoreCursor(lastItem.Score, lastItem.Timestamp, lastItem.ID)
+		}
 		nextCursor = &encoded
 	}
 
-	// Fetch participants for each item
-	for i := range items {
-		participants, err := r.GetParticipantsByItemID(ctx, items[i].ID)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get participants: %w", err)
-		}
-		items[i].Participants = participants
+	// Fetch participants and labels for the whole page at once
+	if err := r.attachParticipants(ctx, items); err != nil {
+		return nil, nil, err
+	}
+	if err := r.attachLabels(ctx, items); err != nil {
+		return nil, nil, err
 	}
 
 	return items, nextCursor, nil
 }
 
-// GetStreamItemByID retrieves a single priority item with all its messages.
+// GetStreamItemByID retrieves a single priority item with its latest messages.
 func (r *PgStreamRepository) GetStreamItemByID(ctx context.Context, userID, itemID string) (*model.PriorityItem, error) {
 	query := `
-		SELECT id, title, source, priority, is_unread, snippet, item_timestamp
-		FROM priority_items
-		WHERE id = $1 AND user_id = $2
+		SELECT ` + streamItemColumns + `
+		FROM priority_items p
+		WHERE p.id = $1 AND p.user_id = $2
 	`
 
+	item, err := scanStreamItem(r.db.QueryRow(ctx, query, itemID, userID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Item not found
+		}
+		return nil, fmt.Errorf("failed to get stream item: %w", err)
+	}
+
+	// Fetch participants
+	participants, err := r.GetParticipantsByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	item.Participants = participants
+
+	// Fetch labels
+	labels, err := labelsByItemIDs(ctx, r.db, []string{itemID})
+	if err != nil {
+		return nil, err
+	}
+	item.Labels = labels[itemID]
+	if item.Labels == nil {
+		item.Labels = []model.Label{}
+	}
+
+	// Fetch the latest messages; older ones are paged through GetMessages
+	messages, messagesCursor, err := queryMessages(ctx, r.db, itemID, nil, model.ItemMessagesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	item.Messages = messages
+	item.MessagesCursor = messagesCursor
+
+	return item, nil
+}
+
+// streamItemColumns are the priority_items columns, aliased as p, scanned by scanStreamItem.
+const streamItemColumns = `p.id, p.title, p.source, p.priority, p.score, p.is_unread, p.snippet, p.item_timestamp,
+			   p.snoozed_until, p.archived_at, p.deleted_at, p.pin_position IS NOT NULL,
+			   p.last_sender_type, p.follow_up_at`
+
+// scanStreamItem scans a row selected with streamItemColumns, followed by the given destinations.
+func scanStreamItem(row pgx.Row, extra ...interface{}) (*model.PriorityItem, error) {
 	var item model.PriorityItem
 	var source, priority string
-
-	err := r.db.QueryRow(ctx, query, itemID, userID).Scan(
+	var lastSender *string
+	dest := []interface{}{
 		&item.ID,
 		&item.Title,
 		&source,
 		&priority,
+		&item.Score,
 		&item.IsUnread,
 		&item.Snippet,
 		&item.Timestamp,
-	)
+		&item.SnoozedUntil,
+		&item.ArchivedAt,
+		&item.DeletedAt,
+		&item.Pinned,
+		&lastSender,
+		&item.FollowUpAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err == pgx.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	item.Source = model.SourceType(source)
+	item.Priority = model.Priority(priority)
+	if lastSender != nil {
+		sender := model.SenderType(*lastSender)
+		item.LastSender = &sender
+	}
+	return &item, nil
+}
 
+// attachLabels sets the labels of each item, using an empty list for unlabeled items.
+func (r *PgStreamRepository) attachLabels(ctx context.Context, items []model.PriorityItem) error {
+	ids := make([]string, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
+
+	byItem, err := labelsByItemIDs(ctx, r.db, ids)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil // Item not found
+		return err
+	}
+
+	for i := range items {
+		items[i].Labels = byItem[items[i].ID]
+		if items[i].Labels == nil {
+			items[i].Labels = []model.Label{}
 		}
-		return nil, fmt.Errorf("failed to get stream item: %w", err)
 	}
+	return nil
+}
 
-	item.Source = model.SourceType(source)
-	item.Priority = model.Priority(priority)
+// attachParticipants sets the participants of each item with a single query.
+func (r *PgStreamRepository) attachParticipants(ctx context.Context, items []model.PriorityItem) error {
+	ids := make([]string, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
 
-	// Fetch participants
-	participants, err := r.GetParticipantsByItemID(ctx, itemID)
+	byItem, err := r.GetParticipantsByItemIDs(ctx, ids)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get participants: %w", err)
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	for i := range items {
+		items[i].Participants = byItem[items[i].ID]
+	}
+	return nil
+}
+
+// GetParticipantsByItemIDs retrieves the participants of several priority items in one query, keyed by item ID.
+func (r *PgStreamRepository) GetParticipantsByItemIDs(ctx context.Context, itemIDs []string) (map[string][]model.User, error) {
+	byItem := make(map[string][]model.User)
+	if len(itemIDs) == 0 {
+		return byItem, nil
 	}
-	item.Participants = participants
 
-	// Fetch messages
-	messages, err := r.GetMessagesByItemID(ctx, itemID)
+	query := `
+		SELECT pip.item_id, u.id, u.name, u.email, u.avatar_url
+		FROM users u
+		JOIN priority_item_participants pip ON u.id::text = pip.user_id
+		WHERE pip.item_id = ANY($1::uuid[])
+	`
+
+	rows, err := r.db.Query(ctx, query, itemIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get messages: %w", err)
+		return nil, fmt.Errorf("failed to query participants: %w", err)
 	}
-	item.Messages = messages
+	defer rows.Close()
 
-	return &item, nil
+	for rows.Next() {
+		var itemID string
+		var user model.User
+		if err := rows.Scan(&itemID, &user.ID, &user.Name, &user.Email, &user.AvatarURL); err != nil {
+			return nil, fmt.Errorf("failed to scan participant: %w", err)
+		}
+		byItem[itemID] = append(byItem[itemID], user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return byItem, nil
 }
 
 // GetParticipantsByItemID retrieves all participants for a priority item.
@@ -206,7 +325,7 @@ func (r *PgStreamRepository) GetParticipantsByItemID(ctx context.Context, itemID
 	query := `
 		SELECT u.id, u.name, u.email, u.avatar_url
 		FROM users u
-		JOIN priority_item_participants pip ON u.id = pip.user_id
+		JOIN priority_item_participants pip ON u.id::text = pip.user_id
 		WHERE pip.item_id = $1
 	`
 
@@ -233,9 +352,44 @@ func (r *PgStreamRepository) GetParticipantsByItemID(ctx context.Context, itemID
 	return participants, nil
 }
 
-// GetMessagesByItemID retrieves all messages for a priority item.
-func (r *PgStreamRepository) GetMessagesByItemID(ctx context.Context, itemID string) ([]model.Message, error) {
-	query := `
+// GetMessages retrieves a page of an item's messages, going back in time from the before cursor.
+func (r *PgStreamRepository) GetMessages(ctx context.Context, req model.MessagesRequest) ([]model.Message, *string, error) {
+	var owned bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM priority_items WHERE id = $1 AND user_id = $2)`,
+		req.ItemID, req.UserID,
+	).Scan(&owned)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check item: %w", err)
+	}
+	if !owned {
+		return nil, nil, nil // Item not found
+	}
+
+	var before *cursor
+	if req.Before != nil && *req.Before != "" {
+		c, err := decodeCursor(*req.Before)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		before = c
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	return queryMessages(ctx, r.db, req.ItemID, before, limit)
+}
+
+// queryMessages retrieves up to limit of an item's messages older than the
+// before cursor, or its latest messages if before is nil, oldest first.
+func queryMessages(ctx context.Context, db *pgxpool.Pool, itemID string, before *cursor, limit int) ([]model.Message, *string, error) {
+	q := newQueryBuilder(`
 		SELECT m.id, m.sender_id, m.sender_type, m.content_type, m.content,
 			   m.full_content_html, m.message_timestamp,
 			   m.event_details, m.social_details, m.attachments, m.ai_insights,
@@ -243,16 +397,21 @@ func (r *PgStreamRepository) GetMessagesByItemID(ctx context.Context, itemID str
 		FROM messages m
 		LEFT JOIN users u ON m.sender_id = u.id
 		WHERE m.item_id = $1
-		ORDER BY m.message_timestamp ASC
-	`
+	`, itemID)
+	if before != nil {
+		q.where("(m.message_timestamp, m.id) < (%s, %s)", before.Timestamp, before.ID)
+	}
 
-	rows, err := r.db.Query(ctx, query, itemID)
+	// Page backwards from the newest message, fetching one extra to determine if there are more
+	q.append(" ORDER BY m.message_timestamp DESC, m.id DESC LIMIT " + q.arg(limit+1))
+
+	rows, err := db.Query(ctx, q.String(), q.args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query messages: %w", err)
+		return nil, nil, fmt.Errorf("failed to query messages: %w", err)
 	}
 	defer rows.Close()
 
-	var messages []model.Message
+	messages := make([]model.Message, 0, limit)
 	for rows.Next() {
 		var msg model.Message
 		var senderType, contentType string
@@ -277,7 +436,7 @@ func (r *PgStreamRepository) GetMessagesByItemID(ctx context.Context, itemID str
 			&userAvatar,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan message: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan message: %w", err)
 		}
 
 		msg.SenderType = model.SenderType(senderType)
@@ -325,9 +484,587 @@ func (r *PgStreamRepository) GetMessagesByItemID(ctx context.Context, itemID str
 		messages = append(messages, msg)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	// Check if there are older messages
+	var nextCursor *string
+	if len(messages) > limit {
+		messages = messages[:limit]
+		oldest := messages[len(messages)-1]
+		encoded := encodeCursor(oldest.Timestamp, oldest.ID)
+		nextCursor = &encoded
+	}
+
+	// Return the page oldest first, like a thread reads
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+
+	return messages, nextCursor, nil
+}
+
+// SetReadState marks the given items as read or unread for a user and
+// reports how each change affects the stream counts.
+func (r *PgStreamRepository) SetReadState(ctx context.Context, userID string, itemIDs []string, unread bool) ([]model.CountChange, error) {
+	if len(itemIDs) == 0 {
+		return []model.CountChange{}, nil
+	}
+
+	// Lock the rows first so the previous read state is exact under concurrent updates
+	query := `
+		WITH target AS (
+			SELECT id, is_unread
+			FROM priority_items
+			WHERE user_id = $1 AND id = ANY($2)
+			FOR UPDATE
+		)
+		UPDATE priority_items p
+		SET is_unread = $3
+		FROM target
+		WHERE p.id = target.id
+		RETURNING p.id, target.is_unread, ` + countedColumns
+
+	rows, err := r.db.Query(ctx, query, userID, itemIDs, unread)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update items: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]model.CountChange, 0, len(itemIDs))
+	for rows.Next() {
+		var change model.CountChange
+		var wasUnread bool
+		after, err := scanCountedItem(rows, &change.ItemID, &wasUnread)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan updated item: %w", err)
+		}
+		if after != nil {
+			before := *after
+			before.Unread = wasUnread
+			change.Before, change.After = &before, after
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return changes, nil
+}
+
+// updatedIDs runs an UPDATE ... RETURNING id statement and collects the returned IDs.
+func (r *PgStreamRepository) updatedIDs(ctx context.Context, query string, expected int, args ...interface{}) ([]string, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update items: %w", err)
+	}
+	defer rows.Close()
+
+	updated := make([]string, 0, expected)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan updated item: %w", err)
+		}
+		updated = append(updated, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// CreateMessage appends a message to a priority item owned by the user.
+func (r *PgStreamRepository) CreateMessage(ctx context.Context, userID, itemID string, msg model.Message, snippet string) (*model.Message, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	stored, err := appendMessage(ctx, tx, userID, itemID, msg, snippet)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit message: %w", err)
+	}
+
+	return stored, nil
+}
+
+// UpdateEvent changes the calendar event in a message and appends the message recording the change.
+func (r *PgStreamRepository) UpdateEvent(ctx context.Context, userID, itemID, messageID string, change repository.EventChange) (*model.EventUpdateResponse, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	selectQuery := `
+		SELECT m.event_details
+		FROM messages m
+		JOIN priority_items p ON p.id = m.item_id
+		WHERE m.id = $1 AND m.item_id = $2 AND p.user_id = $3
+		  AND m.event_details IS NOT NULL
+		FOR UPDATE OF m
+	`
+
+	var raw []byte
+	err = tx.QueryRow(ctx, selectQuery, messageID, itemID, userID).Scan(&raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Message or event not found
+		}
+		return nil, fmt.Errorf("failed to get calendar event: %w", err)
+	}
+
+	var event model.CalendarEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode calendar event: %w", err)
+	}
+	before := event
+
+	note, snippet, err := change(&event)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode calendar event: %w", err)
+	}
+	edits, err := json.Marshal(eventEdits(before, event))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode calendar event edits: %w", err)
+	}
+
+	// Edits are kept apart so that ingest can reapply them over a redelivered event
+	updateQuery := `
+		UPDATE messages
+		SET event_details = $2, event_edits = COALESCE(event_edits, '{}'::jsonb) || $3
+		WHERE id = $1
+	`
+	if _, err := tx.Exec(ctx, updateQuery, messageID, data, edits); err != nil {
+		return nil, fmt.Errorf("failed to update calendar event: %w", err)
+	}
+
+	stored, err := appendMessage(ctx, tx, userID, itemID, note, snippet)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit calendar event: %w", err)
+	}
+
+	return &model.EventUpdateResponse{Event: event, Message: *stored}, nil
+}
+
+// eventEdits returns the event_details fields that a local change made to an
+// event, keyed by their JSON names.
+func eventEdits(before, after model.CalendarEvent) map[string]interface{} {
+	edits := map[string]interface{}{}
+	if !after.StartTime.Equal(before.StartTime) || !after.EndTime.Equal(before.EndTime) {
+		edits["startTime"] = after.StartTime
+		edits["endTime"] = after.EndTime
+	}
+	if !reflect.DeepEqual(after.RSVPs, before.RSVPs) {
+		edits["rsvps"] = after.RSVPs
+	}
+	return edits
+}
+
+// appendMessage inserts a message into an item owned by the user within tx and
+// moves the item's timestamp and snippet forward to match it.
+// Returns nil if the item does not exist.
+func appendMessage(ctx context.Context, tx pgx.Tx, userID, itemID string, msg model.Message, snippet string) (*model.Message, error) {
+	eventDetails, err := marshalJSONB(msg.EventDetails)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event details: %w", err)
+	}
+	socialDetails, err := marshalJSONB(msg.SocialContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal social details: %w", err)
+	}
+	attachments, err := marshalJSONB(msg.Attachments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal attachments: %w", err)
+	}
+	aiInsights, err := marshalJSONB(msg.AIInsights)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal AI insights: %w", err)
+	}
+
+	// Bump the item first; this also verifies ownership.
+	updateQuery := `
+		UPDATE priority_items
+		SET item_timestamp = GREATEST(item_timestamp, $3), snippet = $4
+		WHERE id = $1 AND user_id = $2
+		RETURNING id
+	`
+
+	var updatedID string
+	err = tx.QueryRow(ctx, updateQuery, itemID, userID, msg.Timestamp, snippet).Scan(&updatedID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Item not found
+		}
+		return nil, fmt.Errorf("failed to update stream item: %w", err)
+	}
+
+	var senderID *string
+	if msg.SenderInfo != nil {
+		senderID = &msg.SenderInfo.ID
+	}
+
+	insertQuery := `
+		INSERT INTO messages (
+			item_id, sender_id, sender_type, content_type, content,
+			full_content_html, message_timestamp,
+			event_details, social_details, attachments, ai_insights
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(ctx, insertQuery,
+		itemID,
+		senderID,
+		string(msg.SenderType),
+		string(msg.ContentType),
+		msg.Content,
+		msg.FullContentHTML,
+		msg.Timestamp,
+		eventDetails,
+		socialDetails,
+		attachments,
+		aiInsights,
+	).Scan(&msg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert message: %w", err)
+	}
+
+	return &msg, nil
+}
+
+// SetSnooze snoozes an item owned by the user until the given time, or unsnoozes it when until is nil.
+func (r *PgStreamRepository) SetSnooze(ctx context.Context, userID, itemID string, until *time.Time) (bool, error) {
+	query := `
+		UPDATE priority_items
+		SET snoozed_until = $3
+		WHERE id = $1 AND user_id = $2
+	`
+
+	tag, err := r.db.Exec(ctx, query, itemID, userID, until)
+	if err != nil {
+		return false, fmt.Errorf("failed to update snooze: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// WakeSnoozed unsnoozes up to limit due items, marking them unread and bumping their timestamp to now.
+// Rows locked by a concurrent wake are skipped rather than woken twice.
+func (r *PgStreamRepository) WakeSnoozed(ctx context.Context, now time.Time, limit int) ([]model.ItemRef, error) {
+	query := `
+		UPDATE priority_items p
+		SET snoozed_until = NULL, is_unread = TRUE, item_timestamp = GREATEST(p.item_timestamp, $1)
+		FROM (
+			SELECT id FROM priority_items
+			WHERE snoozed_until <= $1
+			ORDER BY snoozed_until
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		) due
+		WHERE p.id = due.id
+		RETURNING p.id, p.user_id
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wake snoozed items: %w", err)
+	}
+	defer rows.Close()
+
+	woken := make([]model.ItemRef, 0)
+	for rows.Next() {
+		var item model.ItemRef
+		if err := rows.Scan(&item.ItemID, &item.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan woken item: %w", err)
+		}
+		woken = append(woken, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return woken, nil
+}
+
+// SetFollowUp sets or clears an item's follow-up. A follow-up is only set while the user wrote last.
+func (r *PgStreamRepository) SetFollowUp(ctx context.Context, userID, itemID string, at *time.Time) (*model.FollowUpResponse, error) {
+	query := `
+		UPDATE priority_items
+		SET follow_up_at = CASE
+			WHEN $3::timestamptz IS NULL OR last_sender_type = 'user' THEN $3::timestamptz
+			ELSE follow_up_at
+		END
+		WHERE id = $1 AND user_id = $2
+		RETURNING follow_up_at, COALESCE(last_sender_type = 'user', FALSE)
+	`
+
+	response := model.FollowUpResponse{ItemID: itemID}
+	err := r.db.QueryRow(ctx, query, itemID, userID, at).Scan(&response.FollowUpAt, &response.AwaitingReply)
+	if err == pgx.ErrNoRows {
+		return nil, nil // Not found
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update follow-up: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ResurfaceFollowUps clears up to limit due follow-ups, marking their items unread,
+// bumping their timestamp to now and returning them to the stream. Items in the
+// trash are left there. Rows locked by a concurrent run are skipped rather than resurfaced twice.
+func (r *PgStreamRepository) ResurfaceFollowUps(ctx context.Context, now time.Time, limit int) ([]model.ItemRef, error) {
+	query := `
+		UPDATE priority_items p
+		SET follow_up_at = NULL, is_unread = TRUE, item_timestamp = GREATEST(p.item_timestamp, $1),
+			archived_at = NULL, snoozed_until = NULL
+		FROM (
+			SELECT id FROM priority_items
+			WHERE follow_up_at <= $1 AND deleted_at IS NULL
+			ORDER BY follow_up_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		) due
+		WHERE p.id = due.id
+		RETURNING p.id, p.user_id
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resurface follow-ups: %w", err)
+	}
+	defer rows.Close()
+
+	resurfaced := make([]model.ItemRef, 0)
+	for rows.Next() {
+		var item model.ItemRef
+		if err := rows.Scan(&item.ItemID, &item.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan resurfaced item: %w", err)
+		}
+		resurfaced = append(resurfaced, item)
+	}
+
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
-	return messages, nil
+	return resurfaced, nil
+}
+
+// SetItemState archives, trashes or restores the given items for a user.
+func (r *PgStreamRepository) SetItemState(ctx context.Context, userID string, itemIDs []string, state model.ItemState) ([]string, error) {
+	if len(itemIDs) == 0 {
+		return []string{}, nil
+	}
+
+	var set string
+	switch state {
+	case model.ItemArchived:
+		set = "archived_at = COALESCE(archived_at, NOW()), deleted_at = NULL, snoozed_until = NULL"
+	case model.ItemTrashed:
+		set = "deleted_at = COALESCE(deleted_at, NOW()), snoozed_until = NULL, follow_up_at = NULL"
+	case model.ItemActive:
+		set = "archived_at = NULL, deleted_at = NULL"
+	default:
+		return nil, fmt.Errorf("unknown item state: %s", state)
+	}
+
+	query := `
+		UPDATE priority_items
+		SET ` + set + `
+		WHERE user_id = $1 AND id = ANY($2)
+		RETURNING id
+	`
+
+	return r.updatedIDs(ctx, query, len(itemIDs), userID, itemIDs)
+}
+
+// PurgeTrash permanently deletes up to limit items trashed before the given time.
+// Messages and participants are removed by cascade.
+func (r *PgStreamRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) ([]model.ItemRef, error) {
+	query := `
+		DELETE FROM priority_items p
+		USING (
+			SELECT id FROM priority_items
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		) expired
+		WHERE p.id = expired.id
+		RETURNING p.id, p.user_id
+	`
+
+	rows, err := r.db.Query(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge trash: %w", err)
+	}
+	defer rows.Close()
+
+	purged := make([]model.ItemRef, 0)
+	for rows.Next() {
+		var item model.ItemRef
+		if err := rows.Scan(&item.ItemID, &item.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan purged item: %w", err)
+		}
+		purged = append(purged, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return purged, nil
+}
+
+// GetPinnedItems retrieves the user's pinned items matching the filter, in pin order.
+func (r *PgStreamRepository) GetPinnedItems(ctx context.Context, userID string, filter model.StreamFilter) ([]model.PriorityItem, error) {
+	q := newQueryBuilder(`
+		SELECT `+streamItemColumns+`
+		FROM priority_items p
+		WHERE p.user_id = $1 AND p.pin_position IS NOT NULL
+	`, userID)
+
+	applyStreamFilter(q, filter)
+	q.append(" ORDER BY p.pin_position, p.id")
+
+	rows, err := r.db.Query(ctx, q.String(), q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pinned items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []model.PriorityItem{}
+	for rows.Next() {
+		item, err := scanStreamItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	rows.Close()
+
+	if err := r.attachParticipants(ctx, items); err != nil {
+		return nil, err
+	}
+	if err := r.attachLabels(ctx, items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ListPinnedItemIDs retrieves the IDs of all of the user's pinned items, in pin order.
+func (r *PgStreamRepository) ListPinnedItemIDs(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		SELECT id FROM priority_items
+		WHERE user_id = $1 AND pin_position IS NOT NULL
+		ORDER BY pin_position, id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pinned items: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan pinned item: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// PinItem pins an item owned by the user after their other pinned items.
+func (r *PgStreamRepository) PinItem(ctx context.Context, userID, itemID string) (bool, error) {
+	query := `
+		UPDATE priority_items
+		SET pin_position = COALESCE(pin_position, (
+			SELECT COALESCE(MAX(pin_position) + 1, 0) FROM priority_items WHERE user_id = $2
+		))
+		WHERE id = $1 AND user_id = $2
+	`
+
+	tag, err := r.db.Exec(ctx, query, itemID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to pin item: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// UnpinItem unpins an item owned by the user.
+func (r *PgStreamRepository) UnpinItem(ctx context.Context, userID, itemID string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE priority_items SET pin_position = NULL WHERE id = $1 AND user_id = $2`, itemID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpin item: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetPinOrder orders the user's pinned items as listed.
+// Listed items that are not pinned stay unpinned.
+func (r *PgStreamRepository) SetPinOrder(ctx context.Context, userID string, itemIDs []string) error {
+	query := `
+		UPDATE priority_items p
+		SET pin_position = o.position - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, position)
+		WHERE p.id = o.id AND p.user_id = $1 AND p.pin_position IS NOT NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, itemIDs); err != nil {
+		return fmt.Errorf("failed to reorder pinned items: %w", err)
+	}
+	return nil
+}
+
+// marshalJSONB encodes an optional value for a JSONB column.
+// Nil pointers and empty slices are stored as SQL NULL.
+func marshalJSONB(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil, nil
+		}
+	case reflect.Slice:
+		if rv.Len() == 0 {
+			return nil, nil
+		}
+	}
+	return json.Marshal(v)
 }
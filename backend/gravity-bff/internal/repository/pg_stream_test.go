@@ -0,0 +1,126 @@
+//go:build integration
+
+// Run with: go test -tags=integration -run '^$' -bench . ./internal/repository/...
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+const benchUserID = "bench-user-1"
+
+// queryCounter is a pgx tracer that counts the queries sent to the database.
+type queryCounter struct {
+	queries atomic.Int64
+}
+
+func (c *queryCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	c.queries.Add(1)
+	return ctx
+}
+
+func (c *queryCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// BenchmarkGetStream_Queries pages through streams of growing page size and
+// reports the queries per page, which must not grow with the page size.
+func BenchmarkGetStream_Queries(b *testing.B) {
+	ctx := context.Background()
+	counter := &queryCounter{}
+	db := newBenchPool(b, counter)
+	seedBenchItems(b, db, 100)
+
+	repo := NewPgStreamRepository(db)
+	var baseline float64
+	for _, limit := range []int{10, 50, 100} {
+		b.Run(fmt.Sprintf("limit=%d", limit), func(b *testing.B) {
+			counter.queries.Store(0)
+			for i := 0; i < b.N; i++ {
+				items, _, err := repo.GetStream(ctx, model.StreamRequest{UserID: benchUserID, Limit: limit})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(items) != limit || len(items[0].Participants) != 1 {
+					b.Fatalf("got %d items, want %d with one participant each", len(items), limit)
+				}
+			}
+
+			perPage := float64(counter.queries.Load()) / float64(b.N)
+			b.ReportMetric(perPage, "queries/op")
+			if baseline == 0 {
+				baseline = perPage
+			} else if perPage != baseline {
+				b.Errorf("%v queries per page of %d, want %v as for smaller pages", perPage, limit, baseline)
+			}
+		})
+	}
+}
+
+// newBenchPool connects to the test database with the tracer installed.
+func newBenchPool(b *testing.B, tracer pgx.QueryTracer) *pgxpool.Pool {
+	dbURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+		getEnv("DB_USER", "test"), getEnv("DB_PASSWORD", "test"),
+		getEnv("DB_HOST", "localhost"), getEnv("DB_PORT", "5433"), getEnv("DB_NAME", "test_db"))
+
+	config, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		b.Fatal(err)
+	}
+	config.ConnConfig.Tracer = tracer
+
+	db, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(db.Close)
+	return db
+}
+
+// seedBenchItems creates n items for the benchmark user, each with one participant.
+func seedBenchItems(b *testing.B, db *pgxpool.Pool, n int) {
+	ctx := context.Background()
+	cleanup := func() {
+		db.Exec(ctx, "DELETE FROM priority_items WHERE user_id = $1", benchUserID)
+		db.Exec(ctx, "DELETE FROM users WHERE email = 'bench@example.com'")
+	}
+	cleanup()
+	b.Cleanup(cleanup)
+
+	var participantID string
+	err := db.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Bench User', 'bench@example.com')
+		RETURNING id::text
+	`).Scan(&participantID)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	_, err = db.Exec(ctx, `
+		WITH items AS (
+			INSERT INTO priority_items (user_id, title, source, priority, is_unread, snippet, item_timestamp)
+			SELECT $1, 'Item ' || i, 'email', 'medium', true, '', NOW() - i * INTERVAL '1 minute'
+			FROM generate_series(1, $2::int) AS i
+			RETURNING id
+		)
+		INSERT INTO priority_item_participants (item_id, user_id)
+		SELECT id, $3 FROM items
+	`, benchUserID, n, participantID)
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
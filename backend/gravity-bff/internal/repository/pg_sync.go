@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// GetChanges retrieves up to limit entries of a user's change feed after the
+// given change sequence number. Items carry the number of their latest change,
+// so each changed item appears once; deleted items appear as tombstones.
+func (r *PgStreamRepository) GetChanges(ctx context.Context, userID string, since int64, limit int) ([]model.ItemChange, error) {
+	itemsQuery := `
+		SELECT ` + streamItemColumns + `, p.change_seq, p.created_seq
+		FROM priority_items p
+		WHERE p.user_id = $1 AND p.change_seq > $2
+		ORDER BY p.change_seq
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, itemsQuery, userID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changed items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []model.PriorityItem
+	var itemChanges []model.ItemChange
+	for rows.Next() {
+		var seq, createdSeq int64
+		item, err := scanStreamItem(rows, &seq, &createdSeq)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+		itemChanges = append(itemChanges, model.ItemChange{Seq: seq, ItemID: item.ID, Created: createdSeq > since})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	if err := r.attachParticipants(ctx, items); err != nil {
+		return nil, err
+	}
+	if err := r.attachLabels(ctx, items); err != nil {
+		return nil, err
+	}
+
+	for i := range itemChanges {
+		itemChanges[i].Item = &items[i]
+	}
+
+	tombstones, err := r.getTombstones(ctx, userID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeChanges(itemChanges, tombstones, limit), nil
+}
+
+// getTombstones retrieves up to limit of a user's item deletions after the given change sequence number.
+func (r *PgStreamRepository) getTombstones(ctx context.Context, userID string, since int64, limit int) ([]model.ItemChange, error) {
+	query := `
+		SELECT change_seq, item_id::text
+		FROM sync_tombstones
+		WHERE user_id = $1 AND change_seq > $2
+		ORDER BY change_seq
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tombstones: %w", err)
+	}
+	defer rows.Close()
+
+	var tombstones []model.ItemChange
+	for rows.Next() {
+		var change model.ItemChange
+		if err := rows.Scan(&change.Seq, &change.ItemID); err != nil {
+			return nil, fmt.Errorf("failed to scan tombstone: %w", err)
+		}
+		tombstones = append(tombstones, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return tombstones, nil
+}
+
+// mergeChanges merges two change lists ordered by sequence number and keeps the first limit entries.
+func mergeChanges(a, b []model.ItemChange, limit int) []model.ItemChange {
+	merged := make([]model.ItemChange, 0, len(a)+len(b))
+	for len(merged) < limit && (len(a) > 0 || len(b) > 0) {
+		if len(b) == 0 || (len(a) > 0 && a[0].Seq < b[0].Seq) {
+			merged = append(merged, a[0])
+			a = a[1:]
+		} else {
+			merged = append(merged, b[0])
+			b = b[1:]
+		}
+	}
+	return merged
+}
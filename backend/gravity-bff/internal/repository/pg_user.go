@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,6 +15,32 @@ import (
 // Ensure PgUserRepository implements the UserRepository interface.
 var _ repository.UserRepository = (*PgUserRepository)(nil)
 
+// peopleHalfLife is how long it takes for a shared item to count half as much
+// towards ranking a person.
+const peopleHalfLife = 30 * 24 * time.Hour
+
+// peopleQuery selects the people in the non-trashed items of the user ($1),
+// other than the user. A person's rank is the log of their item count, halved
+// every $2 seconds since their latest interaction; it is measured from the
+// epoch rather than from now, so the ranking does not change over time.
+const peopleQuery = `
+	SELECT u.id, u.name, u.email, u.avatar_url, c.item_count, c.last_interaction,
+		   (v.contact_id IS NOT NULL), c.rank
+	FROM (
+		SELECT pip.user_id AS id,
+			   COUNT(*) AS item_count,
+			   MAX(p.item_timestamp) AS last_interaction,
+			   LN(COUNT(*)::float8) + LN(2) * EXTRACT(EPOCH FROM MAX(p.item_timestamp))::float8 / $2::float8 AS rank
+		FROM priority_items p
+		JOIN priority_item_participants pip ON pip.item_id = p.id
+		WHERE p.user_id = $1 AND p.deleted_at IS NULL
+		GROUP BY pip.user_id
+	) c
+	JOIN users u ON u.id::text = c.id
+	LEFT JOIN vip_contacts v ON v.user_id = $1 AND v.contact_id = u.id
+	WHERE u.clerk_id IS DISTINCT FROM $1
+`
+
 // PgUserRepository implements UserRepository using PostgreSQL.
 type PgUserRepository struct {
 	db *pgxpool.Pool
@@ -84,3 +111,153 @@ func (r *PgUserRepository) GetUsersByIDs(ctx context.Context, userIDs []string)
 
 	return users, nil
 }
+
+// ListPeople retrieves a page of the people in the user's items, highest ranked first.
+func (r *PgUserRepository) ListPeople(ctx context.Context, req model.PeopleRequest) ([]model.Person, *string, error) {
+	q := newQueryBuilder(peopleQuery, req.UserID, peopleHalfLife.Seconds())
+
+	if req.VIPOnly {
+		q.append(" AND v.contact_id IS NOT NULL")
+	}
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		c, err := decodeCursor(*req.Cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		if c.Rank == nil {
+			return nil, nil, fmt.Errorf("invalid cursor: missing rank")
+		}
+		q.where("(c.rank, c.last_interaction, u.id) < (%s, %s, %s)", *c.Rank, c.Timestamp, c.ID)
+	}
+
+	q.append(" ORDER BY c.rank DESC, c.last_interaction DESC, u.id DESC")
+
+	// Fetch one extra to determine if there are more people
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	q.append(" LIMIT " + q.arg(limit+1))
+
+	rows, err := r.db.Query(ctx, q.String(), q.args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query people: %w", err)
+	}
+	defer rows.Close()
+
+	people := make([]model.Person, 0, limit)
+	for rows.Next() {
+		person, err := scanPerson(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan person: %w", err)
+		}
+		people = append(people, *person)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	var nextCursor *string
+	if len(people) > limit {
+		people = people[:limit]
+		last := people[len(people)-1]
+		encoded := encodeRankedCursor(last.Rank, last.LastInteraction, last.ID)
+		nextCursor = &encoded
+	}
+
+	return people, nextCursor, nil
+}
+
+// GetPerson retrieves one of the people in the user's items.
+func (r *PgUserRepository) GetPerson(ctx context.Context, userID, personID string) (*model.Person, error) {
+	query := peopleQuery + " AND c.id = $3"
+
+	person, err := scanPerson(r.db.QueryRow(ctx, query, userID, peopleHalfLife.Seconds(), personID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Not one of the user's people
+		}
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+
+	return person, nil
+}
+
+// SetVIP marks or unmarks one of the people in the user's items as a VIP.
+func (r *PgUserRepository) SetVIP(ctx context.Context, userID, personID string, vip bool) ([]string, error) {
+	query := `
+		SELECT p.id
+		FROM priority_items p
+		JOIN priority_item_participants pip ON pip.item_id = p.id
+		WHERE p.user_id = $1 AND pip.user_id = $2 AND p.deleted_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM users u
+			WHERE u.id::text = $2 AND u.clerk_id = $1
+		  )
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query person items: %w", err)
+	}
+	defer rows.Close()
+
+	var itemIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan item id: %w", err)
+		}
+		itemIDs = append(itemIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	if len(itemIDs) == 0 {
+		return nil, nil // Not one of the user's people
+	}
+
+	if vip {
+		_, err = r.db.Exec(ctx, `
+			INSERT INTO vip_contacts (user_id, contact_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, userID, personID)
+	} else {
+		_, err = r.db.Exec(ctx, `
+			DELETE FROM vip_contacts
+			WHERE user_id = $1 AND contact_id = $2
+		`, userID, personID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update vip: %w", err)
+	}
+
+	return itemIDs, nil
+}
+
+// scanPerson scans a row selected by peopleQuery.
+func scanPerson(row pgx.Row) (*model.Person, error) {
+	var person model.Person
+	err := row.Scan(
+		&person.ID,
+		&person.Name,
+		&person.Email,
+		&person.AvatarURL,
+		&person.ItemCount,
+		&person.LastInteraction,
+		&person.VIP,
+		&person.Rank,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// Evaluate applies the enabled rules, in order, to an item and its messages.
+// When several matching rules set the same field, the last one wins.
+func Evaluate(rules []*Compiled, item model.PriorityItem) model.RuleEffect {
+	var effect model.RuleEffect
+	for _, r := range rules {
+		if !r.rule.Enabled || !r.Matches(item) {
+			continue
+		}
+		effect.RuleIDs = append(effect.RuleIDs, r.rule.ID)
+
+		for _, action := range r.rule.Actions {
+			switch action.Type {
+			case model.ActionSetPriority:
+				priority := action.Priority
+				effect.Priority = &priority
+			case model.ActionMarkRead:
+				unread := false
+				effect.Unread = &unread
+			case model.ActionMarkUnread:
+				unread := true
+				effect.Unread = &unread
+			}
+		}
+	}
+	return effect
+}
+
+// Matches reports whether an item satisfies the rule's conditions.
+// Enabled state is not considered.
+func (c *Compiled) Matches(item model.PriorityItem) bool {
+	for _, cond := range c.conditions {
+		ok := cond.matches(item)
+		if c.rule.Match == model.MatchAny && ok {
+			return true
+		}
+		if c.rule.Match == model.MatchAll && !ok {
+			return false
+		}
+	}
+	return c.rule.Match == model.MatchAll
+}
+
+// matches evaluates a condition. Negated operators (neq, not_contains) hold
+// when the positive form does not, so a negated message condition means
+// that no message satisfies the positive form.
+func (c condition) matches(item model.PriorityItem) bool {
+	positive := c.op
+	negate := false
+	switch c.op {
+	case model.OpNeq:
+		positive, negate = model.OpEq, true
+	case model.OpNotContains:
+		positive, negate = model.OpContains, true
+	}
+
+	var ok bool
+	switch c.field {
+	case model.FieldSource:
+		ok = c.test(positive, string(item.Source))
+	case model.FieldTitle:
+		ok = c.test(positive, item.Title)
+	case model.FieldPriority:
+		ok = c.test(positive, string(item.Priority))
+	case model.FieldUnread:
+		ok = item.IsUnread == c.flag
+	case model.FieldSnippet:
+		snippet := ""
+		if item.Snippet != nil {
+			snippet = *item.Snippet
+		}
+		ok = c.test(positive, snippet)
+	case model.FieldHasAttachments:
+		ok = hasAttachments(item) == c.flag
+	default:
+		ok = c.anyMessage(positive, item.Messages)
+	}
+
+	return ok != negate
+}
+
+// anyMessage reports whether any message satisfies a message condition.
+func (c condition) anyMessage(op model.RuleOp, messages []model.Message) bool {
+	for _, msg := range messages {
+		var value string
+		switch c.field {
+		case model.FieldMessageSender:
+			value = string(msg.SenderType)
+		case model.FieldMessageType:
+			value = string(msg.ContentType)
+		case model.FieldMessageContent:
+			value = msg.Content
+		case model.FieldMessageFrom:
+			if msg.SenderInfo == nil {
+				continue
+			}
+			value = msg.SenderInfo.Name
+		case model.FieldMessageEmail:
+			if msg.SenderInfo == nil || msg.SenderInfo.Email == nil {
+				continue
+			}
+			value = *msg.SenderInfo.Email
+		}
+		if c.test(op, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// test applies a positive operator to a field value.
+func (c condition) test(op model.RuleOp, value string) bool {
+	if op == model.OpMatches {
+		return c.pattern.MatchString(value)
+	}
+
+	value = strings.ToLower(value)
+	switch op {
+	case model.OpEq:
+		return value == c.text
+	case model.OpIn:
+		return contains(c.values, value)
+	case model.OpContains:
+		return strings.Contains(value, c.text)
+	case model.OpStartsWith:
+		return strings.HasPrefix(value, c.text)
+	case model.OpEndsWith:
+		return strings.HasSuffix(value, c.text)
+	default:
+		return false
+	}
+}
+
+// hasAttachments reports whether any of the item's messages has attachments.
+func hasAttachments(item model.PriorityItem) bool {
+	for _, msg := range item.Messages {
+		if len(msg.Attachments) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+// Package rules validates and evaluates user-defined triage rules against
+// priority items and their messages.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// ErrInvalidRule means a rule failed validation.
+var ErrInvalidRule = errors.New("invalid rule")
+
+// Limits on rule size.
+const (
+	MaxNameLength  = 100
+	MaxConditions  = 20
+	MaxActions     = 10
+	MaxValueLength = 256
+)
+
+// fieldKind groups fields by the operators and values they accept.
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindEnum
+	kindBool
+)
+
+// fieldKinds lists every known field.
+var fieldKinds = map[model.RuleField]fieldKind{
+	model.FieldSource:         kindEnum,
+	model.FieldTitle:          kindText,
+	model.FieldPriority:       kindEnum,
+	model.FieldUnread:         kindBool,
+	model.FieldSnippet:        kindText,
+	model.FieldHasAttachments: kindBool,
+	model.FieldMessageSender:  kindEnum,
+	model.FieldMessageType:    kindEnum,
+	model.FieldMessageContent: kindText,
+	model.FieldMessageFrom:    kindText,
+	model.FieldMessageEmail:   kindText,
+}
+
+// enumValues lists the allowed values of enum fields.
+var enumValues = map[model.RuleField][]string{
+	model.FieldSource:        toStrings(model.SourceTypes),
+	model.FieldPriority:      toStrings(model.Priorities),
+	model.FieldMessageSender: {string(model.SenderUser), string(model.SenderOther), string(model.SenderSystem)},
+	model.FieldMessageType:   {string(model.ContentText), string(model.ContentEvent), string(model.ContentSocial)},
+}
+
+// kindOps lists the operators each kind of field accepts.
+var kindOps = map[fieldKind][]model.RuleOp{
+	kindText: {model.OpEq, model.OpNeq, model.OpIn, model.OpContains, model.OpNotContains,
+		model.OpStartsWith, model.OpEndsWith, model.OpMatches},
+	kindEnum: {model.OpEq, model.OpNeq, model.OpIn},
+	kindBool: {model.OpEq},
+}
+
+// Compiled is a validated rule, ready to evaluate.
+type Compiled struct {
+	rule       model.Rule
+	conditions []condition
+}
+
+// condition is a validated condition with its value decoded.
+// Text comparisons are case-insensitive, so text values are lowercased.
+type condition struct {
+	field   model.RuleField
+	op      model.RuleOp
+	text    string
+	values  []string
+	flag    bool
+	pattern *regexp.Regexp
+}
+
+// Compile validates a rule. Errors wrap ErrInvalidRule.
+func Compile(rule model.Rule) (*Compiled, error) {
+	if strings.TrimSpace(rule.Name) == "" {
+		return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidRule)
+	}
+	if n := utf8.RuneCountInString(rule.Name); n > MaxNameLength {
+		return nil, fmt.Errorf("%w: name is too long: %d characters. Maximum is %d", ErrInvalidRule, n, MaxNameLength)
+	}
+	if rule.Match != model.MatchAll && rule.Match != model.MatchAny {
+		return nil, fmt.Errorf("%w: invalid match: %q. Valid values: all, any", ErrInvalidRule, rule.Match)
+	}
+
+	if len(rule.Conditions) == 0 {
+		return nil, fmt.Errorf("%w: conditions must not be empty", ErrInvalidRule)
+	}
+	if len(rule.Conditions) > MaxConditions {
+		return nil, fmt.Errorf("%w: too many conditions: %d. Maximum is %d", ErrInvalidRule, len(rule.Conditions), MaxConditions)
+	}
+	compiled := &Compiled{rule: rule, conditions: make([]condition, 0, len(rule.Conditions))}
+	for i, c := range rule.Conditions {
+		cond, err := compileCondition(c)
+		if err != nil {
+			return nil, fmt.Errorf("%w: conditions[%d]: %v", ErrInvalidRule, i, err)
+		}
+		compiled.conditions = append(compiled.conditions, cond)
+	}
+
+	if err := validateActions(rule.Actions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	return compiled, nil
+}
+
+// Rule returns the compiled rule.
+func (c *Compiled) Rule() model.Rule {
+	return c.rule
+}
+
+// compileCondition validates a condition and decodes its value.
+func compileCondition(c model.RuleCondition) (condition, error) {
+	kind, ok := fieldKinds[c.Field]
+	if !ok {
+		return condition{}, fmt.Errorf("unknown field: %q", c.Field)
+	}
+	if !containsOp(kindOps[kind], c.Op) {
+		return condition{}, fmt.Errorf("operator %q is not supported for field %s", c.Op, c.Field)
+	}
+
+	cond := condition{field: c.Field, op: c.Op}
+
+	if kind == kindBool {
+		flag, ok := c.Value.(bool)
+		if !ok {
+			return condition{}, fmt.Errorf("value for %s must be a boolean", c.Field)
+		}
+		cond.flag = flag
+		return cond, nil
+	}
+
+	if c.Op == model.OpIn {
+		list, ok := c.Value.([]interface{})
+		if !ok || len(list) == 0 {
+			return condition{}, fmt.Errorf("value for in must be a non-empty list of strings")
+		}
+		for _, v := range list {
+			s, ok := v.(string)
+			if !ok {
+				return condition{}, fmt.Errorf("value for in must be a non-empty list of strings")
+			}
+			if err := checkValue(c.Field, kind, s); err != nil {
+				return condition{}, err
+			}
+			cond.values = append(cond.values, strings.ToLower(s))
+		}
+		return cond, nil
+	}
+
+	s, ok := c.Value.(string)
+	if !ok {
+		return condition{}, fmt.Errorf("value for %s must be a string", c.Field)
+	}
+	if err := checkValue(c.Field, kind, s); err != nil {
+		return condition{}, err
+	}
+	if kind == kindText && s == "" && c.Op != model.OpEq && c.Op != model.OpNeq {
+		return condition{}, fmt.Errorf("value must not be empty")
+	}
+
+	if c.Op == model.OpMatches {
+		pattern, err := regexp.Compile("(?i)" + s)
+		if err != nil {
+			return condition{}, fmt.Errorf("invalid pattern: %v", err)
+		}
+		cond.pattern = pattern
+	}
+	cond.text = strings.ToLower(s)
+	return cond, nil
+}
+
+// checkValue validates a single string value for a field.
+func checkValue(field model.RuleField, kind fieldKind, value string) error {
+	if n := utf8.RuneCountInString(value); n > MaxValueLength {
+		return fmt.Errorf("value is too long: %d characters. Maximum is %d", n, MaxValueLength)
+	}
+	if kind == kindEnum && !contains(enumValues[field], value) {
+		return fmt.Errorf("invalid value for %s: %q. Valid values: %s", field, value, strings.Join(enumValues[field], ", "))
+	}
+	return nil
+}
+
+// validateActions checks that a rule has a sensible set of actions.
+func validateActions(actions []model.RuleAction) error {
+	if len(actions) == 0 {
+		return fmt.Errorf("actions must not be empty")
+	}
+	if len(actions) > MaxActions {
+		return fmt.Errorf("too many actions: %d. Maximum is %d", len(actions), MaxActions)
+	}
+
+	seen := make(map[model.RuleActionType]bool, len(actions))
+	for i, a := range actions {
+		switch a.Type {
+		case model.ActionSetPriority:
+			if !a.Priority.IsValid() {
+				return fmt.Errorf("actions[%d]: invalid priority: %q", i, a.Priority)
+			}
+		case model.ActionMarkRead, model.ActionMarkUnread:
+		default:
+			return fmt.Errorf("actions[%d]: unknown action: %q", i, a.Type)
+		}
+		if seen[a.Type] {
+			return fmt.Errorf("actions[%d]: duplicate action: %s", i, a.Type)
+		}
+		seen[a.Type] = true
+	}
+
+	if seen[model.ActionMarkRead] && seen[model.ActionMarkUnread] {
+		return fmt.Errorf("mark_read and mark_unread cannot be combined")
+	}
+	return nil
+}
+
+func toStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsOp(ops []model.RuleOp, op model.RuleOp) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// parseRule decodes a rule from JSON, as the API receives it.
+func parseRule(t *testing.T, raw string) model.Rule {
+	var rule model.Rule
+	require.NoError(t, json.Unmarshal([]byte(raw), &rule))
+	rule.Enabled = true
+	return rule
+}
+
+func mustCompile(t *testing.T, raw string) *Compiled {
+	compiled, err := Compile(parseRule(t, raw))
+	require.NoError(t, err)
+	return compiled
+}
+
+func testItem() model.PriorityItem {
+	manager := "boss@example.com"
+	snippet := "Disk usage above 90%"
+	return model.PriorityItem{
+		ID:       "item-1",
+		Title:    "#alerts",
+		Source:   model.SourceSlack,
+		Priority: model.PriorityMedium,
+		IsUnread: true,
+		Snippet:  &snippet,
+		Messages: []model.Message{
+			{SenderType: model.SenderOther, ContentType: model.ContentText, Content: "Disk usage above 90% on db-1",
+				SenderInfo: &model.User{Name: "Pat Boss", Email: &manager}},
+			{SenderType: model.SenderUser, ContentType: model.ContentText, Content: "Looking",
+				Attachments: []model.Attachment{{Name: "graph.png"}}},
+		},
+	}
+}
+
+func TestCompiled_Matches(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want bool
+	}{
+		{"source and title", `{"name":"r","match":"all","conditions":[{"field":"source","op":"eq","value":"slack"},{"field":"title","op":"eq","value":"#ALERTS"}],"actions":[{"type":"mark_read"}]}`, true},
+		{"all fails on one", `{"name":"r","match":"all","conditions":[{"field":"source","op":"eq","value":"slack"},{"field":"unread","op":"eq","value":false}],"actions":[{"type":"mark_read"}]}`, false},
+		{"any holds on one", `{"name":"r","match":"any","conditions":[{"field":"source","op":"eq","value":"email"},{"field":"unread","op":"eq","value":true}],"actions":[{"type":"mark_read"}]}`, true},
+		{"source in", `{"name":"r","match":"all","conditions":[{"field":"source","op":"in","value":["email","slack"]}],"actions":[{"type":"mark_read"}]}`, true},
+		{"sender email", `{"name":"r","match":"all","conditions":[{"field":"message.from.email","op":"eq","value":"Boss@Example.com"}],"actions":[{"type":"mark_read"}]}`, true},
+		{"content pattern", `{"name":"r","match":"all","conditions":[{"field":"message.content","op":"matches","value":"db-[0-9]+"}],"actions":[{"type":"mark_read"}]}`, true},
+		{"snippet starts with", `{"name":"r","match":"all","conditions":[{"field":"snippet","op":"starts_with","value":"disk"}],"actions":[{"type":"mark_read"}]}`, true},
+		{"has attachments", `{"name":"r","match":"all","conditions":[{"field":"hasAttachments","op":"eq","value":true}],"actions":[{"type":"mark_read"}]}`, true},
+		{"no message contains", `{"name":"r","match":"all","conditions":[{"field":"message.content","op":"not_contains","value":"looking"}],"actions":[{"type":"mark_read"}]}`, false},
+		{"no message from", `{"name":"r","match":"all","conditions":[{"field":"message.from.name","op":"neq","value":"Someone Else"}],"actions":[{"type":"mark_read"}]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mustCompile(t, tt.rule).Matches(testItem()))
+		})
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	tests := []string{
+		`{"name":"","match":"all","conditions":[{"field":"source","op":"eq","value":"slack"}],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"some","conditions":[{"field":"source","op":"eq","value":"slack"}],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"all","conditions":[],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"sender","op":"eq","value":"x"}],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"source","op":"contains","value":"sl"}],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"source","op":"eq","value":"fax"}],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"unread","op":"eq","value":"yes"}],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"title","op":"in","value":[]}],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"title","op":"matches","value":"("}],"actions":[{"type":"mark_read"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"title","op":"eq","value":"x"}],"actions":[]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"title","op":"eq","value":"x"}],"actions":[{"type":"set_priority","priority":"urgent"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"title","op":"eq","value":"x"}],"actions":[{"type":"mark_read"},{"type":"mark_unread"}]}`,
+		`{"name":"r","match":"all","conditions":[{"field":"title","op":"eq","value":"x"}],"actions":[{"type":"delete"}]}`,
+	}
+
+	for _, raw := range tests {
+		_, err := Compile(parseRule(t, raw))
+		assert.ErrorIs(t, err, ErrInvalidRule, raw)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	low := mustCompile(t, `{"id":"r1","name":"alerts","match":"all","conditions":[{"field":"title","op":"eq","value":"#alerts"}],"actions":[{"type":"set_priority","priority":"low"},{"type":"mark_read"}]}`)
+	boss := mustCompile(t, `{"id":"r2","name":"boss","match":"all","conditions":[{"field":"message.from.email","op":"eq","value":"boss@example.com"}],"actions":[{"type":"set_priority","priority":"high"}]}`)
+	disabled := mustCompile(t, `{"id":"r3","name":"off","match":"all","conditions":[{"field":"source","op":"eq","value":"slack"}],"actions":[{"type":"mark_unread"}]}`)
+	disabled.rule.Enabled = false
+	other := mustCompile(t, `{"id":"r4","name":"email","match":"all","conditions":[{"field":"source","op":"eq","value":"email"}],"actions":[{"type":"mark_unread"}]}`)
+
+	effect := Evaluate([]*Compiled{low, boss, disabled, other}, testItem())
+
+	assert.Equal(t, []string{"r1", "r2"}, effect.RuleIDs)
+	require.NotNil(t, effect.Priority)
+	assert.Equal(t, model.PriorityHigh, *effect.Priority) // later rule wins
+	require.NotNil(t, effect.Unread)
+	assert.False(t, *effect.Unread)
+
+	assert.True(t, Evaluate([]*Compiled{other}, testItem()).IsEmpty())
+}
@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// lockKeyPrefix namespaces job locks in Redis.
+const lockKeyPrefix = "scheduler:lock:"
+
+// Locker elects which instance runs a job.
+type Locker interface {
+	// TryLock acquires the named lock for ttl.
+	// Returns false if another holder has it.
+	TryLock(ctx context.Context, name string, ttl time.Duration) (bool, error)
+}
+
+// RedisLocker implements Locker with Redis keys shared by all instances.
+type RedisLocker struct {
+	client *redis.Client
+}
+
+// NewRedisLocker creates a new Redis-backed locker.
+func NewRedisLocker(client *redis.Client) *RedisLocker {
+	return &RedisLocker{client: client}
+}
+
+// TryLock sets the lock key if it does not exist yet.
+func (l *RedisLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	return l.client.SetNX(ctx, lockKeyPrefix+name, time.Now().UTC().Format(time.RFC3339), ttl).Result()
+}
+
+// LocalLocker always acquires. Use it when a single instance runs the jobs.
+type LocalLocker struct{}
+
+// TryLock always succeeds.
+func (LocalLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,111 @@
+// Package scheduler runs periodic background jobs. When several API instances
+// run the same jobs, a shared lock makes sure each job runs at most once per
+// interval across all of them.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// Job is a task that runs periodically.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs jobs on their intervals until stopped.
+type Scheduler struct {
+	locker Locker
+	log    *logger.Logger
+	jobs   []Job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a scheduler that elects job runs with the given locker.
+func New(locker Locker, log *logger.Logger) *Scheduler {
+	return &Scheduler{locker: locker, log: log}
+}
+
+// Add registers a job. Jobs must be added before Start.
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every job once and then on its interval, until ctx is done or Stop is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			s.log.Warn("Scheduler: job %s has no interval; not scheduled", job.Name)
+			continue
+		}
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return.
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// loop runs a job on its interval until ctx is done.
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs a job if this instance wins its lock for the interval.
+// The lock is not released afterwards: it expires shortly before the next
+// tick, so the job runs at most once per interval across instances.
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	acquired, err := s.locker.TryLock(ctx, job.Name, job.Interval-job.Interval/10)
+	if err != nil {
+		s.log.Warn("Scheduler: failed to lock job %s: %v", job.Name, err)
+		return
+	}
+	if !acquired {
+		s.log.Debug("Scheduler: job %s is running elsewhere", job.Name)
+		return
+	}
+
+	start := time.Now()
+	if err := s.run(ctx, job); err != nil {
+		s.log.Error("Scheduler: job %s failed: %v", job.Name, err)
+		return
+	}
+	s.log.Debug("Scheduler: job %s finished in %s", job.Name, time.Since(start))
+}
+
+// run calls the job, turning panics into errors so one bad run does not stop the scheduler.
+func (s *Scheduler) run(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
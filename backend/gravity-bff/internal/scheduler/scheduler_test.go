@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// fakeLocker grants each lock name once, like a Redis key that never expires.
+type fakeLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+	err  error
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return false, l.err
+	}
+	if l.held[name] {
+		return false, nil
+	}
+	l.held[name] = true
+	return true, nil
+}
+
+func TestScheduler_RunsOnInterval(t *testing.T) {
+	var runs atomic.Int32
+	s := New(LocalLocker{}, logger.New())
+	s.Add(Job{Name: "count", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+
+	s.Start(context.Background())
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	s.Stop()
+
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestScheduler_OneRunnerAcrossInstances(t *testing.T) {
+	var runs atomic.Int32
+	locker := &fakeLocker{held: map[string]bool{}}
+	job := Job{Name: "count", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}}
+
+	a, b := New(locker, logger.New()), New(locker, logger.New())
+	a.Add(job)
+	b.Add(job)
+	a.Start(context.Background())
+	b.Start(context.Background())
+	time.Sleep(50 * time.Millisecond)
+	a.Stop()
+	b.Stop()
+
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+func TestScheduler_SurvivesFailures(t *testing.T) {
+	var runs atomic.Int32
+	s := New(LocalLocker{}, logger.New())
+	s.Add(Job{Name: "flaky", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			panic("boom")
+		}
+		return errors.New("still failing")
+	}})
+
+	s.Start(context.Background())
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	s.Stop()
+}
+
+func TestScheduler_LockError(t *testing.T) {
+	var runs atomic.Int32
+	s := New(&fakeLocker{err: errors.New("redis down")}, logger.New())
+	s.Add(Job{Name: "count", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+
+	s.Start(context.Background())
+	time.Sleep(30 * time.Millisecond)
+	s.Stop()
+
+	assert.Equal(t, int32(0), runs.Load())
+}
@@ -0,0 +1,195 @@
+// Package scoring computes numeric priority scores for stream items and maps
+// them onto the high/medium/low priority buckets.
+package scoring
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// MaxScore is the highest possible score.
+const MaxScore = 100
+
+// Weights sets how many points each signal contributes. The default weights
+// add up to MaxScore, apart from VIP, which boosts items with a VIP in them.
+type Weights struct {
+	Recency  float64 // Full points for activity now, halving every RecencyHalfLife
+	Unread   float64
+	Source   float64 // Scaled by Sources
+	Sender   float64 // Scaled by how often the owner replies to the latest sender
+	Mention  float64 // Recent messages mention the owner
+	Deadline float64 // Scaled by how soon the next event starts within DeadlineWindow
+	VIP      float64 // A VIP takes part in the item
+
+	RecencyHalfLife time.Duration
+	DeadlineWindow  time.Duration
+	// SenderReplies is the number of replied items at which sender importance is full.
+	SenderReplies int
+	// Sources rates each source from 0 to 1. Unlisted sources rate 0.5.
+	Sources map[model.SourceType]float64
+}
+
+// Thresholds map scores onto priority buckets.
+type Thresholds struct {
+	High   float64 // Scores at or above are high priority
+	Medium float64 // Scores at or above are medium priority; below are low
+}
+
+// DefaultWeights returns the default signal weights.
+func DefaultWeights() Weights {
+	return Weights{
+		Recency:         25,
+		Unread:          15,
+		Source:          10,
+		Sender:          20,
+		Mention:         15,
+		Deadline:        15,
+		VIP:             25,
+		RecencyHalfLife: 24 * time.Hour,
+		DeadlineWindow:  72 * time.Hour,
+		SenderReplies:   5,
+		Sources: map[model.SourceType]float64{
+			model.SourceWhatsApp: 0.9,
+			model.SourceSlack:    0.8,
+			model.SourceTeams:    0.8,
+			model.SourceTask:     0.8,
+			model.SourceEmail:    0.7,
+			model.SourceCalendar: 0.7,
+			model.SourceLinkedIn: 0.3,
+			model.SourceTwitter:  0.1,
+			model.SourceYouTube:  0.1,
+		},
+	}
+}
+
+// DefaultThresholds returns the default bucket thresholds.
+func DefaultThresholds() Thresholds {
+	return Thresholds{High: 60, Medium: 35}
+}
+
+// Scorer computes item scores.
+type Scorer struct {
+	weights    Weights
+	thresholds Thresholds
+}
+
+// New creates a scorer with the given weights and thresholds.
+func New(weights Weights, thresholds Thresholds) *Scorer {
+	return &Scorer{weights: weights, thresholds: thresholds}
+}
+
+// Score computes an item's score at the given time, rounded to two decimals.
+func (s *Scorer) Score(sig model.ScoreSignals, now time.Time) float64 {
+	w := s.weights
+	score := 0.0
+
+	if age := now.Sub(sig.LastActivity); age <= 0 {
+		score += w.Recency
+	} else if w.RecencyHalfLife > 0 {
+		score += w.Recency * math.Exp2(-float64(age)/float64(w.RecencyHalfLife))
+	}
+
+	if sig.IsUnread {
+		score += w.Unread
+	}
+
+	sourceRating, ok := w.Sources[sig.Source]
+	if !ok {
+		sourceRating = 0.5
+	}
+	score += w.Source * sourceRating
+
+	if w.SenderReplies > 0 {
+		score += w.Sender * math.Min(float64(sig.SenderReplies)/float64(w.SenderReplies), 1)
+	}
+
+	if Mentions(sig.RecentContent, sig.OwnerNames) {
+		score += w.Mention
+	}
+
+	if sig.NextEvent != nil && w.DeadlineWindow > 0 {
+		if until := sig.NextEvent.Sub(now); until >= 0 && until < w.DeadlineWindow {
+			score += w.Deadline * (1 - float64(until)/float64(w.DeadlineWindow))
+		}
+	}
+
+	if sig.VIP {
+		score += w.VIP
+	}
+
+	score = math.Max(0, math.Min(score, MaxScore))
+	return math.Round(score*100) / 100
+}
+
+// Bucket maps a score onto a priority.
+func (s *Scorer) Bucket(score float64) model.Priority {
+	switch {
+	case score >= s.thresholds.High:
+		return model.PriorityHigh
+	case score >= s.thresholds.Medium:
+		return model.PriorityMedium
+	default:
+		return model.PriorityLow
+	}
+}
+
+// ScoreItem computes an item's score and its priority bucket.
+func (s *Scorer) ScoreItem(sig model.ScoreSignals, now time.Time) model.ItemScore {
+	score := s.Score(sig, now)
+	return model.ItemScore{
+		ItemID:   sig.ItemID,
+		UserID:   sig.UserID,
+		Score:    score,
+		Priority: s.Bucket(score),
+	}
+}
+
+// Mentions reports whether any of the texts mention one of the owner's names:
+// a full email address, its local part, or a name's first word, as a whole word
+// and case-insensitively. Names shorter than three characters are ignored.
+func Mentions(texts, names []string) bool {
+	pattern := mentionPattern(names)
+	if pattern == nil {
+		return false
+	}
+	for _, text := range texts {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionPattern builds a whole-word matcher for the owner's names.
+func mentionPattern(names []string) *regexp.Regexp {
+	seen := make(map[string]bool)
+	var terms []string
+	add := func(term string) {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if len([]rune(term)) < 3 || seen[term] {
+			return
+		}
+		seen[term] = true
+		terms = append(terms, regexp.QuoteMeta(term))
+	}
+
+	for _, name := range names {
+		if local, _, ok := strings.Cut(name, "@"); ok {
+			add(name)
+			add(local)
+			continue
+		}
+		if fields := strings.Fields(name); len(fields) > 0 {
+			add(fields[0])
+		}
+	}
+
+	if len(terms) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)(^|[^\pL\pN_])@?(` + strings.Join(terms, "|") + `)($|[^\pL\pN_])`)
+}
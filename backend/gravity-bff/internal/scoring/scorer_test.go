@@ -0,0 +1,130 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+var testNow = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+func TestScore_Signals(t *testing.T) {
+	s := New(DefaultWeights(), DefaultThresholds())
+	base := model.ScoreSignals{Source: model.SourceEmail, LastActivity: testNow.Add(-240 * time.Hour)}
+	baseScore := s.Score(base, testNow)
+
+	// Source alone: 10 * 0.7, plus a negligible recency remainder
+	assert.InDelta(t, 7, baseScore, 0.1)
+
+	tests := []struct {
+		name   string
+		modify func(*model.ScoreSignals)
+		gain   float64
+	}{
+		{"unread", func(sig *model.ScoreSignals) { sig.IsUnread = true }, 15},
+		{"fresh activity", func(sig *model.ScoreSignals) { sig.LastActivity = testNow }, 25},
+		{"important sender", func(sig *model.ScoreSignals) { sig.SenderReplies = 10 }, 20},
+		{"occasional sender", func(sig *model.ScoreSignals) { sig.SenderReplies = 1 }, 4},
+		{"mention", func(sig *model.ScoreSignals) {
+			sig.OwnerNames = []string{"Jane Doe", "jane.doe@example.com"}
+			sig.RecentContent = []string{"Thanks, @Jane, can you check this?"}
+		}, 15},
+		{"event starting now", func(sig *model.ScoreSignals) {
+			start := testNow
+			sig.NextEvent = &start
+		}, 15},
+		{"event in a day", func(sig *model.ScoreSignals) {
+			start := testNow.Add(24 * time.Hour)
+			sig.NextEvent = &start
+		}, 10},
+		{"past event", func(sig *model.ScoreSignals) {
+			start := testNow.Add(-time.Hour)
+			sig.NextEvent = &start
+		}, 0},
+		{"vip", func(sig *model.ScoreSignals) { sig.VIP = true }, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig := base
+			tt.modify(&sig)
+			assert.InDelta(t, tt.gain, s.Score(sig, testNow)-baseScore, 0.1)
+		})
+	}
+}
+
+func TestScore_RecencyHalfLife(t *testing.T) {
+	s := New(DefaultWeights(), DefaultThresholds())
+	sig := model.ScoreSignals{Source: model.SourceTwitter, LastActivity: testNow.Add(-24 * time.Hour)}
+
+	// Half of 25 for recency plus 10 * 0.1 for the source
+	assert.Equal(t, 13.5, s.Score(sig, testNow))
+}
+
+func TestScore_Clamped(t *testing.T) {
+	w := DefaultWeights()
+	w.Unread = 500
+	s := New(w, DefaultThresholds())
+
+	score := s.Score(model.ScoreSignals{IsUnread: true, LastActivity: testNow}, testNow)
+
+	assert.Equal(t, float64(MaxScore), score)
+}
+
+func TestBucket(t *testing.T) {
+	s := New(DefaultWeights(), DefaultThresholds())
+
+	assert.Equal(t, model.PriorityHigh, s.Bucket(100))
+	assert.Equal(t, model.PriorityHigh, s.Bucket(60))
+	assert.Equal(t, model.PriorityMedium, s.Bucket(59.99))
+	assert.Equal(t, model.PriorityMedium, s.Bucket(35))
+	assert.Equal(t, model.PriorityLow, s.Bucket(34.99))
+	assert.Equal(t, model.PriorityLow, s.Bucket(0))
+}
+
+func TestScoreItem(t *testing.T) {
+	s := New(DefaultWeights(), DefaultThresholds())
+	sig := model.ScoreSignals{
+		ItemID:        "item-1",
+		UserID:        "user-1",
+		Source:        model.SourceSlack,
+		IsUnread:      true,
+		LastActivity:  testNow,
+		SenderReplies: 5,
+	}
+
+	result := s.ScoreItem(sig, testNow)
+
+	assert.Equal(t, "item-1", result.ItemID)
+	assert.Equal(t, "user-1", result.UserID)
+	assert.Equal(t, 68.0, result.Score)
+	assert.Equal(t, model.PriorityHigh, result.Priority)
+}
+
+func TestMentions(t *testing.T) {
+	names := []string{"Jane Doe", "jdoe@example.com", "Al"}
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Jane, please review", true},
+		{"ping @jdoe when ready", true},
+		{"cc JDOE@EXAMPLE.COM", true},
+		{"Janet will review", false},
+		{"see jdoes.txt", false},
+		{"Al said hi", false}, // too short to match reliably
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, Mentions([]string{tt.text}, names))
+		})
+	}
+
+	assert.False(t, Mentions([]string{"Jane"}, nil))
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/cache"
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// briefingDateLayout is the layout of a briefing's date.
+const briefingDateLayout = "2006-01-02"
+
+// BriefingService puts together a user's daily briefing from their stream and calendar.
+type BriefingService struct {
+	stream   repository.StreamRepository
+	calendar repository.CalendarRepository
+	cache    cache.Cache
+	config   *config.Config
+	log      *logger.Logger
+	now      func() time.Time
+}
+
+// NewBriefingService creates a new briefing service.
+func NewBriefingService(
+	stream repository.StreamRepository,
+	calendar repository.CalendarRepository,
+	cache cache.Cache,
+	cfg *config.Config,
+	log *logger.Logger,
+) *BriefingService {
+	return &BriefingService{
+		stream:   stream,
+		calendar: calendar,
+		cache:    cache,
+		config:   cfg,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// GetBriefing returns a user's briefing for the day starting at day, midnight
+// in the time zone the day is taken in. Briefings are cached per user and day.
+func (s *BriefingService) GetBriefing(ctx context.Context, userID string, day time.Time) (*model.BriefingResponse, error) {
+	date, timeZone := day.Format(briefingDateLayout), day.Location().String()
+	cacheKey := cache.BriefingKey(userID, date, timeZone)
+
+	cached, err := s.cache.GetBriefing(ctx, cacheKey)
+	if err != nil {
+		s.log.Warn("Cache get error: %v", err)
+	} else if cached != nil {
+		return cached, nil
+	}
+
+	dayEnd := day.AddDate(0, 0, 1)
+	slots, err := s.calendar.GetEventSlots(ctx, userID, day, dayEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	events := make([]model.BriefingEvent, 0, len(slots))
+	for _, slot := range slots {
+		event := model.BriefingEvent{
+			ItemID:    slot.ItemID,
+			MessageID: slot.MessageID,
+			Title:     slot.Title,
+			StartTime: slot.StartTime.In(day.Location()),
+			EndTime:   slot.EndTime.In(day.Location()),
+		}
+		if slot.Conflicts != nil {
+			event.Conflicts = &slot.Conflicts.Content
+		}
+		events = append(events, event)
+	}
+
+	unread := true
+	highPriority, err := s.section(ctx, userID, model.StreamFilter{Priorities: []model.Priority{model.PriorityHigh}, Unread: &unread})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get high priority items: %w", err)
+	}
+
+	wakingToday, err := s.section(ctx, userID, model.StreamFilter{Snoozed: true, WakesBefore: &dayEnd})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snoozed items: %w", err)
+	}
+	sort.SliceStable(wakingToday, func(i, j int) bool {
+		return wakingToday[i].SnoozedUntil.Before(*wakingToday[j].SnoozedUntil)
+	})
+
+	needsReply, err := s.section(ctx, userID, model.StreamFilter{LastSender: model.SenderOther})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items awaiting reply: %w", err)
+	}
+
+	response := &model.BriefingResponse{
+		Date:         date,
+		TimeZone:     timeZone,
+		Events:       events,
+		HighPriority: highPriority,
+		WakingToday:  wakingToday,
+		NeedsReply:   needsReply,
+		GeneratedAt:  s.now().UTC(),
+	}
+
+	if err := s.cache.SetBriefing(ctx, cacheKey, response, s.config.Cache.BriefingTTL); err != nil {
+		s.log.Warn("Failed to cache briefing: %v", err)
+	}
+
+	return response, nil
+}
+
+// section retrieves up to BriefingSectionLimit items matching a filter, pinned
+// items first and the rest by score.
+func (s *BriefingService) section(ctx context.Context, userID string, filter model.StreamFilter) ([]model.PriorityItem, error) {
+	items, err := s.stream.GetPinnedItems(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) >= model.BriefingSectionLimit {
+		return items[:model.BriefingSectionLimit], nil
+	}
+
+	rest, _, err := s.stream.GetStream(ctx, model.StreamRequest{
+		UserID: userID,
+		Filter: filter,
+		Sort:   model.SortScore,
+		Limit:  model.BriefingSectionLimit - len(items),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(items, rest...), nil
+}
+
+// ValidateBriefingDay parses and validates the date and tz query parameters of
+// a briefing request and returns the start of the day. tz is an IANA time zone
+// name, UTC by default, and date a YYYY-MM-DD date in it, today by default.
+func ValidateBriefingDay(date, tz string, now time.Time) (time.Time, error) {
+	tz = strings.TrimSpace(tz)
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return time.Time{}, fmt.Errorf("invalid tz: %q. Must be an IANA time zone name", tz)
+	}
+
+	if date == "" {
+		y, m, d := now.In(loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, loc), nil
+	}
+
+	day, err := time.ParseInLocation(briefingDateLayout, date, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %q. Must be YYYY-MM-DD", date)
+	}
+	return day, nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+var testBriefingNow = time.Date(2026, 3, 2, 7, 30, 0, 0, time.UTC)
+
+func newTestBriefingService(streamRepo *MockStreamRepository, calendarRepo *MockCalendarRepository, cache *MockCache) *BriefingService {
+	svc := NewBriefingService(streamRepo, calendarRepo, cache, newTestConfig(), logger.New())
+	svc.now = func() time.Time { return testBriefingNow }
+	return svc
+}
+
+func TestBriefingService_GetBriefing(t *testing.T) {
+	// Arrange
+	streamRepo := new(MockStreamRepository)
+	calendarRepo := new(MockCalendarRepository)
+	mockCache := new(MockCache)
+	svc := newTestBriefingService(streamRepo, calendarRepo, mockCache)
+
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	require.NoError(t, err)
+	day := time.Date(2026, 7, 1, 0, 0, 0, 0, lisbon)
+	dayEnd := day.AddDate(0, 0, 1)
+	key := "stream:user-123:briefing:2026-07-01:Europe/Lisbon"
+
+	high := func(f model.StreamFilter) bool { return len(f.Priorities) == 1 && f.Unread != nil && *f.Unread }
+	snoozed := func(f model.StreamFilter) bool {
+		return f.Snoozed && f.WakesBefore != nil && f.WakesBefore.Equal(dayEnd)
+	}
+	needsReply := func(f model.StreamFilter) bool { return f.LastSender == model.SenderOther }
+
+	early, late := day.Add(9*time.Hour), day.Add(17*time.Hour)
+	mockCache.On("GetBriefing", mock.Anything, key).Return(nil, nil)
+	calendarRepo.On("GetEventSlots", mock.Anything, "user-123", day, dayEnd).Return([]model.EventSlot{{
+		ItemID: "item-cal", MessageID: "msg-cal", Title: "Standup",
+		StartTime: time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 7, 1, 8, 15, 0, 0, time.UTC),
+		Conflicts: &model.AIInsight{Content: `Conflicts with "1:1".`},
+	}}, nil)
+	streamRepo.On("GetPinnedItems", mock.Anything, "user-123", mock.MatchedBy(high)).Return([]model.PriorityItem{{ID: "item-pinned"}}, nil)
+	streamRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(req model.StreamRequest) bool {
+		return high(req.Filter) && req.Sort == model.SortScore && req.Limit == model.BriefingSectionLimit-1
+	})).Return([]model.PriorityItem{{ID: "item-high"}}, (*string)(nil), nil)
+	streamRepo.On("GetPinnedItems", mock.Anything, "user-123", mock.MatchedBy(snoozed)).Return([]model.PriorityItem{}, nil)
+	streamRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(req model.StreamRequest) bool { return snoozed(req.Filter) })).
+		Return([]model.PriorityItem{{ID: "item-late", SnoozedUntil: &late}, {ID: "item-early", SnoozedUntil: &early}}, (*string)(nil), nil)
+	streamRepo.On("GetPinnedItems", mock.Anything, "user-123", mock.MatchedBy(needsReply)).Return([]model.PriorityItem{}, nil)
+	streamRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(req model.StreamRequest) bool { return needsReply(req.Filter) })).
+		Return([]model.PriorityItem{{ID: "item-reply"}}, (*string)(nil), nil)
+	mockCache.On("SetBriefing", mock.Anything, key, mock.Anything, 5*time.Minute).Return(nil)
+
+	// Act
+	result, err := svc.GetBriefing(context.Background(), "user-123", day)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "2026-07-01", result.Date)
+	assert.Equal(t, "Europe/Lisbon", result.TimeZone)
+	require.Equal(t, 1, len(result.Events))
+	assert.Equal(t, "09:00", result.Events[0].StartTime.Format("15:04"))
+	assert.Equal(t, `Conflicts with "1:1".`, *result.Events[0].Conflicts)
+	assert.Equal(t, []string{"item-pinned", "item-high"}, itemIDs(result.HighPriority))
+	assert.Equal(t, []string{"item-early", "item-late"}, itemIDs(result.WakingToday))
+	assert.Equal(t, []string{"item-reply"}, itemIDs(result.NeedsReply))
+	assert.Equal(t, testBriefingNow, result.GeneratedAt)
+	mockCache.AssertExpectations(t)
+}
+
+func TestBriefingService_GetBriefing_CacheHit(t *testing.T) {
+	// Arrange
+	streamRepo := new(MockStreamRepository)
+	calendarRepo := new(MockCalendarRepository)
+	mockCache := new(MockCache)
+	svc := newTestBriefingService(streamRepo, calendarRepo, mockCache)
+
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	cached := &model.BriefingResponse{Date: "2026-03-02", TimeZone: "UTC"}
+	mockCache.On("GetBriefing", mock.Anything, "stream:user-123:briefing:2026-03-02:UTC").Return(cached, nil)
+
+	// Act
+	result, err := svc.GetBriefing(context.Background(), "user-123", day)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Same(t, cached, result)
+	calendarRepo.AssertNotCalled(t, "GetEventSlots", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	streamRepo.AssertNotCalled(t, "GetStream", mock.Anything, mock.Anything)
+}
+
+func TestBriefingService_GetBriefing_RepositoryError(t *testing.T) {
+	// Arrange
+	streamRepo := new(MockStreamRepository)
+	calendarRepo := new(MockCalendarRepository)
+	mockCache := new(MockCache)
+	svc := newTestBriefingService(streamRepo, calendarRepo, mockCache)
+
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	mockCache.On("GetBriefing", mock.Anything, mock.Anything).Return(nil, nil)
+	calendarRepo.On("GetEventSlots", mock.Anything, "user-123", mock.Anything, mock.Anything).Return([]model.EventSlot{}, nil)
+	streamRepo.On("GetPinnedItems", mock.Anything, "user-123", mock.Anything).Return([]model.PriorityItem{}, errors.New("db down"))
+
+	// Act
+	result, err := svc.GetBriefing(context.Background(), "user-123", day)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockCache.AssertNotCalled(t, "SetBriefing", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func itemIDs(items []model.PriorityItem) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+// Tests for ValidateBriefingDay
+func TestValidateBriefingDay(t *testing.T) {
+	now := time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		date     string
+		tz       string
+		expected string
+		hasError bool
+	}{
+		{"defaults to today in UTC", "", "", "2026-03-02T00:00:00Z", false},
+		{"today in time zone", "", "Asia/Tokyo", "2026-03-03T00:00:00+09:00", false},
+		{"given date", "2026-03-10", "America/New_York", "2026-03-10T00:00:00-04:00", false},
+		{"invalid date", "10/03/2026", "", "", true},
+		{"unknown time zone", "", "Mars/Olympus", "", true},
+		{"local time zone", "", "Local", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, err := ValidateBriefingDay(tt.date, tt.tz, now)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, day.Format(time.RFC3339))
+			}
+		})
+	}
+}
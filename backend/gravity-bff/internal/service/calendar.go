@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/calendar"
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// ErrFeedDisabled means no calendar feed secret is configured.
+var ErrFeedDisabled = errors.New("calendar feed is disabled")
+
+// feedName is the calendar name shown by subscribing clients.
+const feedName = "Gravity"
+
+// DefaultFreeBusyRange is the time range a free/busy query covers when it has no end.
+const DefaultFreeBusyRange = 7 * 24 * time.Hour
+
+// MaxFreeBusyRange is the longest time range a free/busy query can cover.
+const MaxFreeBusyRange = 62 * 24 * time.Hour
+
+// maxConflictsListed is the number of overlapping events named in a conflict insight.
+const maxConflictsListed = 3
+
+// CalendarService provides calendar import and feed export.
+type CalendarService struct {
+	repo   repository.CalendarRepository
+	ingest *IngestService
+	tokens *calendar.FeedTokens // nil when feeds are disabled
+	config *config.Config
+	log    *logger.Logger
+}
+
+// NewCalendarService creates a new calendar service.
+func NewCalendarService(
+	repo repository.CalendarRepository,
+	ingest *IngestService,
+	cfg *config.Config,
+	log *logger.Logger,
+) *CalendarService {
+	var tokens *calendar.FeedTokens
+	if cfg.Calendar.FeedSecret != "" {
+		tokens = calendar.NewFeedTokens(cfg.Calendar.FeedSecret)
+	}
+	return &CalendarService{
+		repo:   repo,
+		ingest: ingest,
+		tokens: tokens,
+		config: cfg,
+		log:    log,
+	}
+}
+
+// FeedToken returns the token for a user's calendar feed URL.
+func (s *CalendarService) FeedToken(userID string) (string, error) {
+	if s.tokens == nil {
+		return "", ErrFeedDisabled
+	}
+	return s.tokens.Token(userID), nil
+}
+
+// GetFeed renders the iCalendar feed for the user a feed token was issued to.
+// Returns calendar.ErrInvalidToken if the token does not verify.
+func (s *CalendarService) GetFeed(ctx context.Context, token string) ([]byte, error) {
+	if s.tokens == nil {
+		return nil, ErrFeedDisabled
+	}
+
+	userID, err := s.tokens.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	events, err := s.repo.GetEvents(ctx, userID, now.Add(-s.config.Calendar.FeedPast), now.Add(s.config.Calendar.FeedFuture))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar events: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Encode(&buf, feedName, events, now); err != nil {
+		return nil, fmt.Errorf("failed to encode calendar feed: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Import parses an iCalendar document and ingests its events for a user.
+// Recurring events are expanded from the start of the feed window up to the
+// recurrence horizon. Returns ingestion.ErrInvalidPayload (wrapped) if the
+// document cannot be parsed.
+func (s *CalendarService) Import(ctx context.Context, userID string, r io.Reader) (*model.IngestResponse, error) {
+	events, err := calendar.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ingestion.ErrInvalidPayload, err)
+	}
+
+	now := time.Now().UTC()
+	from, to := now.Add(-s.config.Calendar.FeedPast), now.Add(s.config.Calendar.RecurrenceHorizon)
+	return s.ingest.ImportCalendar(ctx, events, calendar.Options{UserID: userID}, from, to)
+}
+
+// FreeBusy returns when a user is busy within [from, to): the times taken by
+// the events of their items that are not in the trash, merged.
+func (s *CalendarService) FreeBusy(ctx context.Context, userID string, from, to time.Time) (*model.FreeBusyResponse, error) {
+	slots, err := s.repo.GetEventSlots(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event slots: %w", err)
+	}
+
+	return &model.FreeBusyResponse{From: from, To: to, Busy: mergeBusy(slots, from, to)}, nil
+}
+
+// CheckConflicts annotates a user's upcoming events, up to the recurrence
+// horizon, with an insight naming the events they overlap, and removes the
+// insight from events that no longer overlap any. Returns the events whose
+// insight changed, including those changed before a failure.
+func (s *CalendarService) CheckConflicts(ctx context.Context, userID string) ([]model.EventSlot, error) {
+	now := time.Now().UTC()
+	slots, err := s.repo.GetEventSlots(ctx, userID, now, now.Add(s.config.Calendar.RecurrenceHorizon))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event slots: %w", err)
+	}
+
+	changed := []model.EventSlot{}
+	for i, overlapping := range findConflicts(slots) {
+		slot := slots[i]
+		insight := conflictInsight(slot, overlapping)
+		if sameConflicts(slot.Conflicts, insight) {
+			continue
+		}
+		if err := s.repo.SetConflictInsight(ctx, slot.MessageID, insight); err != nil {
+			return changed, fmt.Errorf("failed to save conflicts of event %s: %w", slot.EventID, err)
+		}
+		changed = append(changed, slot)
+	}
+
+	return changed, nil
+}
+
+// findConflicts returns, for each of slots ordered by start time, the other
+// slots it overlaps. Events without a duration overlap nothing.
+func findConflicts(slots []model.EventSlot) [][]model.EventSlot {
+	conflicts := make([][]model.EventSlot, len(slots))
+	for i := range slots {
+		if !slots[i].EndTime.After(slots[i].StartTime) {
+			continue
+		}
+		for j := i + 1; j < len(slots) && slots[j].StartTime.Before(slots[i].EndTime); j++ {
+			if !slots[j].EndTime.After(slots[j].StartTime) {
+				continue
+			}
+			conflicts[i] = append(conflicts[i], slots[j])
+			conflicts[j] = append(conflicts[j], slots[i])
+		}
+	}
+	return conflicts
+}
+
+// conflictInsight builds the insight naming the events a slot overlaps, or nil if there are none.
+func conflictInsight(slot model.EventSlot, overlapping []model.EventSlot) *model.AIInsight {
+	if len(overlapping) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, maxConflictsListed+1)
+	for _, other := range overlapping[:min(len(overlapping), maxConflictsListed)] {
+		names = append(names, fmt.Sprintf("%q (%s)", other.Title, formatEventTimes(other.StartTime, other.EndTime)))
+	}
+	if more := len(overlapping) - maxConflictsListed; more == 1 {
+		names = append(names, "1 more event")
+	} else if more > 1 {
+		names = append(names, fmt.Sprintf("%d more events", more))
+	}
+
+	content := names[0]
+	if len(names) > 1 {
+		content = strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+
+	return &model.AIInsight{
+		ID:      model.ConflictInsightID(slot.MessageID),
+		Type:    model.InsightAnalysis,
+		Label:   "Conflict",
+		Content: "Conflicts with " + content + ".",
+	}
+}
+
+// sameConflicts reports whether a stored conflict insight already says what a new one would.
+func sameConflicts(stored, next *model.AIInsight) bool {
+	if stored == nil || next == nil {
+		return stored == next
+	}
+	return stored.Type == next.Type && stored.Label == next.Label && stored.Content == next.Content
+}
+
+// mergeBusy merges the times of slots ordered by start time into busy periods
+// clipped to [from, to).
+func mergeBusy(slots []model.EventSlot, from, to time.Time) []model.BusyPeriod {
+	busy := []model.BusyPeriod{}
+	for _, slot := range slots {
+		start, end := slot.StartTime.UTC(), slot.EndTime.UTC()
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		if n := len(busy); n > 0 && !start.After(busy[n-1].End) {
+			if end.After(busy[n-1].End) {
+				busy[n-1].End = end
+			}
+			continue
+		}
+		busy = append(busy, model.BusyPeriod{Start: start, End: end})
+	}
+	return busy
+}
+
+// ValidateFreeBusyRange parses and validates the from and to query parameters
+// of a free/busy query, RFC 3339 timestamps. from defaults to now and to to
+// DefaultFreeBusyRange after from.
+func ValidateFreeBusyRange(fromParam, toParam string, now time.Time) (time.Time, time.Time, error) {
+	from := now.UTC()
+	if fromParam != "" {
+		t, err := time.Parse(time.RFC3339, fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %q. Must be an RFC 3339 timestamp", fromParam)
+		}
+		from = t.UTC()
+	}
+
+	to := from.Add(DefaultFreeBusyRange)
+	if toParam != "" {
+		t, err := time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %q. Must be an RFC 3339 timestamp", toParam)
+		}
+		to = t.UTC()
+	}
+
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must be after from")
+	}
+	if to.Sub(from) > MaxFreeBusyRange {
+		return time.Time{}, time.Time{}, fmt.Errorf("time range is too long. Maximum is %d days", int(MaxFreeBusyRange.Hours()/24))
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/calendar"
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockCalendarRepository is a mock implementation of CalendarRepository.
+type MockCalendarRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarRepository) GetEvents(ctx context.Context, userID string, from, to time.Time) ([]model.CalendarEvent, error) {
+	args := m.Called(ctx, userID, from, to)
+	return args.Get(0).([]model.CalendarEvent), args.Error(1)
+}
+
+func (m *MockCalendarRepository) GetEventSlots(ctx context.Context, userID string, from, to time.Time) ([]model.EventSlot, error) {
+	args := m.Called(ctx, userID, from, to)
+	return args.Get(0).([]model.EventSlot), args.Error(1)
+}
+
+func (m *MockCalendarRepository) SetConflictInsight(ctx context.Context, messageID string, insight *model.AIInsight) error {
+	args := m.Called(ctx, messageID, insight)
+	return args.Error(0)
+}
+
+func newTestCalendarService(repo *MockCalendarRepository, ingestRepo *MockIngestRepository, mockCache *MockCache, secret string) *CalendarService {
+	cfg := newTestConfig()
+	cfg.Calendar = config.CalendarConfig{
+		FeedSecret:        secret,
+		FeedPast:          30 * 24 * time.Hour,
+		FeedFuture:        365 * 24 * time.Hour,
+		RecurrenceHorizon: 90 * 24 * time.Hour,
+	}
+	ingest := NewIngestService(ingestRepo, ingestion.NewRegistry(), mockCache, events.NopPublisher{}, logger.New())
+	return NewCalendarService(repo, ingest, cfg, logger.New())
+}
+
+func TestCalendarService_GetFeed(t *testing.T) {
+	// Arrange
+	repo := new(MockCalendarRepository)
+	svc := newTestCalendarService(repo, new(MockIngestRepository), new(MockCache), "secret")
+
+	repo.On("GetEvents", mock.Anything, "user_1", mock.Anything, mock.Anything).Return([]model.CalendarEvent{{
+		ID:        "evt-1",
+		Title:     "Standup",
+		StartTime: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 1, 5, 9, 15, 0, 0, time.UTC),
+	}}, nil)
+
+	token, err := svc.FeedToken("user_1")
+	assert.NoError(t, err)
+
+	// Act
+	feed, err := svc.GetFeed(context.Background(), token)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, string(feed), "SUMMARY:Standup\r\n")
+	repo.AssertExpectations(t)
+}
+
+func TestCalendarService_GetFeed_Rejected(t *testing.T) {
+	repo := new(MockCalendarRepository)
+
+	_, err := newTestCalendarService(repo, nil, nil, "secret").GetFeed(context.Background(), "forged.token")
+	assert.ErrorIs(t, err, calendar.ErrInvalidToken)
+
+	disabled := newTestCalendarService(repo, nil, nil, "")
+	_, err = disabled.FeedToken("user_1")
+	assert.ErrorIs(t, err, ErrFeedDisabled)
+	_, err = disabled.GetFeed(context.Background(), "any")
+	assert.ErrorIs(t, err, ErrFeedDisabled)
+
+	repo.AssertNotCalled(t, "GetEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCalendarService_Import(t *testing.T) {
+	// Arrange
+	ingestRepo := new(MockIngestRepository)
+	mockCache := new(MockCache)
+	svc := newTestCalendarService(new(MockCalendarRepository), ingestRepo, mockCache, "")
+
+	start := time.Now().UTC().Add(48 * time.Hour).Format("20060102T150405Z")
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:standup\r\nDTSTART:" + start +
+		"\r\nRRULE:FREQ=DAILY;COUNT=2\r\nSUMMARY:Standup\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+	ingestRepo.On("UpsertItem", mock.Anything, mock.MatchedBy(func(item model.IngestItem) bool {
+		return item.Source == model.SourceCalendar && strings.HasPrefix(item.ExternalID, "standup/")
+	})).Return(&model.IngestResult{ItemID: "item-1", Created: true}, nil).Twice()
+	ingestRepo.On("GetCountedItems", mock.Anything, mock.Anything).Return(map[string]model.CountedItem{}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "user_1", mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	resp, err := svc.Import(context.Background(), "user_1", strings.NewReader(ics))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(resp.Results))
+	ingestRepo.AssertExpectations(t)
+}
+
+func TestCalendarService_Import_Invalid(t *testing.T) {
+	svc := newTestCalendarService(new(MockCalendarRepository), new(MockIngestRepository), new(MockCache), "")
+
+	_, err := svc.Import(context.Background(), "user_1", strings.NewReader("BEGIN:VCALENDAR\r\n"))
+
+	assert.ErrorIs(t, err, ingestion.ErrInvalidPayload)
+}
+
+func newTestSlot(id string, start time.Time, minutes int) model.EventSlot {
+	return model.EventSlot{
+		MessageID: "msg-" + id,
+		ItemID:    "item-" + id,
+		EventID:   "evt-" + id,
+		Title:     "Event " + id,
+		StartTime: start,
+		EndTime:   start.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+func TestCalendarService_FreeBusy(t *testing.T) {
+	// Arrange
+	repo := new(MockCalendarRepository)
+	svc := newTestCalendarService(repo, nil, nil, "")
+
+	from := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	to := from.Add(8 * time.Hour)
+	repo.On("GetEventSlots", mock.Anything, "user_1", from, to).Return([]model.EventSlot{
+		newTestSlot("a", from.Add(-30*time.Minute), 60), // Clipped to from
+		newTestSlot("b", from.Add(15*time.Minute), 30),  // Within a
+		newTestSlot("c", from.Add(30*time.Minute), 60),  // Extends a
+		newTestSlot("d", from.Add(3*time.Hour), 0),      // No duration
+		newTestSlot("e", from.Add(7*time.Hour), 120),    // Clipped to to
+	}, nil)
+
+	// Act
+	result, err := svc.FreeBusy(context.Background(), "user_1", from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []model.BusyPeriod{
+		{Start: from, End: from.Add(90 * time.Minute)},
+		{Start: from.Add(7 * time.Hour), End: to},
+	}, result.Busy)
+}
+
+func TestCalendarService_CheckConflicts(t *testing.T) {
+	// Arrange
+	repo := new(MockCalendarRepository)
+	svc := newTestCalendarService(repo, nil, nil, "")
+
+	start := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	a := newTestSlot("a", start, 60)
+	b := newTestSlot("b", start.Add(30*time.Minute), 60)
+	c := newTestSlot("c", start.Add(3*time.Hour), 60)
+	c.Conflicts = &model.AIInsight{ID: "msg-c-conflicts", Type: model.InsightAnalysis, Label: "Conflict", Content: "Conflicts with \"Moved\"."}
+	d := newTestSlot("d", start.Add(5*time.Hour), 30)
+	e := newTestSlot("e", start.Add(5*time.Hour), 30)
+	d.Conflicts = conflictInsight(d, []model.EventSlot{e}) // Already up to date
+	e.Conflicts = conflictInsight(e, []model.EventSlot{d})
+
+	repo.On("GetEventSlots", mock.Anything, "user_1", mock.Anything, mock.Anything).Return([]model.EventSlot{a, b, c, d, e}, nil)
+	repo.On("SetConflictInsight", mock.Anything, "msg-a", mock.MatchedBy(func(insight *model.AIInsight) bool {
+		return insight.ID == "msg-a-conflicts" &&
+			insight.Content == `Conflicts with "Event b" (Mon 2 Mar 2026 15:30–16:30 UTC).`
+	})).Return(nil)
+	repo.On("SetConflictInsight", mock.Anything, "msg-b", mock.MatchedBy(func(insight *model.AIInsight) bool {
+		return insight.Content == `Conflicts with "Event a" (Mon 2 Mar 2026 15:00–16:00 UTC).`
+	})).Return(nil)
+	repo.On("SetConflictInsight", mock.Anything, "msg-c", (*model.AIInsight)(nil)).Return(nil)
+
+	// Act
+	changed, err := svc.CheckConflicts(context.Background(), "user_1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(changed))
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "SetConflictInsight", mock.Anything, "msg-d", mock.Anything)
+}
+
+func TestConflictInsight_ListsAtMostThree(t *testing.T) {
+	start := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	slot := newTestSlot("a", start, 60)
+	others := []model.EventSlot{
+		newTestSlot("b", start, 15),
+		newTestSlot("c", start, 15),
+		newTestSlot("d", start, 15),
+		newTestSlot("e", start, 15),
+		newTestSlot("f", start, 15),
+	}
+
+	insight := conflictInsight(slot, others)
+
+	assert.Equal(t, model.InsightAnalysis, insight.Type)
+	assert.True(t, strings.HasPrefix(insight.Content, `Conflicts with "Event b"`))
+	assert.True(t, strings.HasSuffix(insight.Content, `, "Event d" (Mon 2 Mar 2026 15:00–15:15 UTC) and 2 more events.`))
+	assert.Nil(t, conflictInsight(slot, nil))
+}
+
+// stubConflictChecker reports fixed annotation changes.
+type stubConflictChecker struct {
+	changed []model.EventSlot
+	users   []string
+}
+
+func (c *stubConflictChecker) CheckConflicts(ctx context.Context, userID string) ([]model.EventSlot, error) {
+	c.users = append(c.users, userID)
+	return c.changed, nil
+}
+
+func TestIngestService_Ingest_ChecksConflictsForEvents(t *testing.T) {
+	// Arrange
+	repo := new(MockIngestRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewIngestService(repo, ingestion.NewRegistry(), mockCache, publisher, logger.New())
+	checker := &stubConflictChecker{changed: []model.EventSlot{
+		{ItemID: "item-1", MessageID: "msg-1"},
+		{ItemID: "item-2", MessageID: "msg-2"},
+	}}
+	svc.SetConflictChecker(checker)
+
+	event := newTestIngestItem()
+	event.Source = model.SourceCalendar
+	event.Messages[1].EventDetails = &model.CalendarEvent{ID: "evt-1", Title: "Standup"}
+
+	repo.On("UpsertItem", mock.Anything, mock.Anything).Return(&model.IngestResult{ItemID: "item-1", Created: true}, nil)
+	repo.On("GetCountedItems", mock.Anything, mock.Anything).Return(map[string]model.CountedItem{}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	_, err := svc.Ingest(context.Background(), []model.IngestItem{event})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user_1"}, checker.users)
+	mockCache.AssertCalled(t, "Delete", mock.Anything, []string{"item:item-2"})
+
+	// The other item is announced as updated, the stored one as created
+	assert.Equal(t, 2, len(publisher.published))
+	assert.Equal(t, events.ItemUpdated, publisher.published[0].Type)
+	assert.Equal(t, "item-2", publisher.published[0].ItemID)
+	assert.Equal(t, events.ItemCreated, publisher.published[1].Type)
+}
+
+func TestIngestService_Ingest_SkipsConflictsWithoutEvents(t *testing.T) {
+	// Arrange
+	repo := new(MockIngestRepository)
+	mockCache := new(MockCache)
+	svc := NewIngestService(repo, ingestion.NewRegistry(), mockCache, events.NopPublisher{}, logger.New())
+	checker := &stubConflictChecker{}
+	svc.SetConflictChecker(checker)
+
+	repo.On("UpsertItem", mock.Anything, mock.Anything).Return(&model.IngestResult{ItemID: "item-1"}, nil)
+	repo.On("GetCountedItems", mock.Anything, mock.Anything).Return(map[string]model.CountedItem{}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	_, err := svc.Ingest(context.Background(), []model.IngestItem{newTestIngestItem()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, checker.users)
+}
+
+// Tests for ValidateFreeBusyRange
+func TestValidateFreeBusyRange(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		wantFrom time.Time
+		wantTo   time.Time
+		hasError bool
+	}{
+		{"defaults", "", "", now, now.Add(DefaultFreeBusyRange), false},
+		{"explicit", "2026-03-02T09:00:00+01:00", "2026-03-03T00:00:00Z", time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), false},
+		{"invalid from", "yesterday", "", time.Time{}, time.Time{}, true},
+		{"invalid to", "", "2026-03-03", time.Time{}, time.Time{}, true},
+		{"to before from", "2026-03-03T00:00:00Z", "2026-03-02T00:00:00Z", time.Time{}, time.Time{}, true},
+		{"too long", "2026-03-01T00:00:00Z", "2026-06-01T00:00:00Z", time.Time{}, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := ValidateFreeBusyRange(tt.from, tt.to, now)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFrom, from)
+				assert.Equal(t, tt.wantTo, to)
+			}
+		})
+	}
+}
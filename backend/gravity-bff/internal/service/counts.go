@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mabidoli/gravity-bff/internal/cache"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// GetCounts retrieves the total, unread and high-priority counts of the user's
+// default stream, overall and by source and label. Counts are served from the
+// cache, which read-state changes and ingestion keep up to date incrementally;
+// other mutations drop them so that the next call recomputes them.
+func (s *StreamService) GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error) {
+	cached, err := s.cache.GetCounts(ctx, userID)
+	if err != nil {
+		s.log.Warn("Cache error for counts: %v", err)
+	} else if cached != nil {
+		return cached, nil
+	}
+
+	counts, err := s.repo.GetCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get counts: %w", err)
+	}
+
+	if err := s.cache.SetCounts(ctx, userID, counts, s.config.Cache.CountsTTL); err != nil {
+		s.log.Warn("Failed to cache counts: %v", err)
+	}
+
+	return counts, nil
+}
+
+// adjustCounts applies count changes to the user's cached counts.
+// If that fails the counts are dropped, so they are recomputed rather than left stale.
+func adjustCounts(ctx context.Context, c cache.Cache, log *logger.Logger, userID string, changes []model.CountChange) {
+	delta := model.NewCountsResponse()
+	for _, change := range changes {
+		delta.Apply(change)
+	}
+
+	if err := c.AdjustCounts(ctx, userID, delta); err != nil {
+		log.Warn("Failed to adjust cached counts: %v", err)
+		dropCounts(ctx, c, log, userID)
+	}
+}
+
+// dropCounts removes the user's cached counts after a change that is not
+// tracked incrementally. Failures are logged; the counts expire on their own TTL.
+func dropCounts(ctx context.Context, c cache.Cache, log *logger.Logger, userID string) {
+	if err := c.Delete(ctx, cache.CountsKey(userID)); err != nil {
+		log.Warn("Failed to invalidate cached counts: %v", err)
+	}
+}
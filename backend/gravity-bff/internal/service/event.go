@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
+)
+
+// ErrUnknownAttendee means an RSVP names someone who is not an attendee of the event.
+var ErrUnknownAttendee = errors.New("not an attendee of the event")
+
+// RescheduleEvent moves the calendar event in a message to new times and
+// appends a system message recording the change. Returns nil if the item has
+// no such message with an event.
+func (s *StreamService) RescheduleEvent(ctx context.Context, req model.RescheduleEventRequest) (*model.EventUpdateResponse, error) {
+	start, end, err := ValidateEventTimes(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	change := func(event *model.CalendarEvent) (model.Message, string, error) {
+		content := fmt.Sprintf("Rescheduled %q from %s to %s.",
+			event.Title, formatEventTimes(event.StartTime, event.EndTime), formatEventTimes(start, end))
+		event.StartTime, event.EndTime = start, end
+		return s.systemMessage(content), Snippet(content), nil
+	}
+
+	response, err := s.updateEvent(ctx, req.UserID, req.ItemID, req.MessageID, change)
+	if err != nil || response == nil {
+		return nil, err
+	}
+
+	// Upcoming events score higher the sooner they start
+	if s.scorer != nil {
+		if _, err := s.scorer.ScoreItems(ctx, []string{req.ItemID}); err != nil {
+			s.log.Warn("Failed to score rescheduled item %s: %v", req.ItemID, err)
+		}
+	}
+
+	if s.conflicts != nil {
+		changed, err := s.conflicts.CheckConflicts(ctx, req.UserID)
+		if err != nil {
+			s.log.Warn("Failed to check calendar conflicts: %v", err)
+		}
+		for _, slot := range changed {
+			s.invalidateItems(ctx, req.UserID, slot.ItemID)
+			s.publish(ctx, req.UserID, events.ItemUpdated, slot.ItemID, map[string]string{"insights": slot.MessageID})
+		}
+	}
+
+	return response, nil
+}
+
+// RespondToEvent records an attendee's response to the calendar event in a
+// message, replacing their earlier response, and appends a system message
+// recording it. Returns nil if the item has no such message with an event, or
+// an error wrapping ErrUnknownAttendee.
+func (s *StreamService) RespondToEvent(ctx context.Context, req model.RSVPRequest) (*model.EventUpdateResponse, error) {
+	if err := ValidateRSVP(req.Attendee, req.Status); err != nil {
+		return nil, err
+	}
+	attendee := strings.TrimSpace(req.Attendee)
+
+	change := func(event *model.CalendarEvent) (model.Message, string, error) {
+		var found *model.User
+		for i, a := range event.Attendees {
+			if (a.ID != "" && a.ID == attendee) || (a.Email != nil && strings.EqualFold(*a.Email, attendee)) {
+				found = &event.Attendees[i]
+				break
+			}
+		}
+		if found == nil || model.AttendeeKey(*found) == "" {
+			return model.Message{}, "", fmt.Errorf("%w: %s", ErrUnknownAttendee, attendee)
+		}
+
+		rsvp := model.RSVP{Attendee: model.AttendeeKey(*found), Status: req.Status, RespondedAt: s.now().UTC()}
+		replaced := false
+		for i := range event.RSVPs {
+			if event.RSVPs[i].Attendee == rsvp.Attendee {
+				event.RSVPs[i] = rsvp
+				replaced = true
+			}
+		}
+		if !replaced {
+			event.RSVPs = append(event.RSVPs, rsvp)
+		}
+
+		content := fmt.Sprintf("%s %s %q.", found.Name, rsvpVerbs[req.Status], event.Title)
+		return s.systemMessage(content), Snippet(content), nil
+	}
+
+	return s.updateEvent(ctx, req.UserID, req.ItemID, req.MessageID, change)
+}
+
+// rsvpVerbs describes each response in the system message recording it.
+var rsvpVerbs = map[model.RSVPStatus]string{
+	model.RSVPAccepted:  "accepted",
+	model.RSVPDeclined:  "declined",
+	model.RSVPTentative: "tentatively accepted",
+}
+
+// updateEvent applies a change to an event and announces it.
+func (s *StreamService) updateEvent(ctx context.Context, userID, itemID, messageID string, change repository.EventChange) (*model.EventUpdateResponse, error) {
+	response, err := s.repo.UpdateEvent(ctx, userID, itemID, messageID, change)
+	if err != nil {
+		if errors.Is(err, ErrUnknownAttendee) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update calendar event: %w", err)
+	}
+
+	if response == nil {
+		return nil, nil // Not found
+	}
+
+	s.invalidateItems(ctx, userID, itemID)
+	s.publish(ctx, userID, events.ItemUpdated, itemID, map[string]string{"event": messageID})
+	s.publish(ctx, userID, events.MessageCreated, itemID, response.Message)
+
+	return response, nil
+}
+
+// systemMessage builds a system message recording a change to an item.
+func (s *StreamService) systemMessage(content string) model.Message {
+	return model.Message{
+		SenderType:  model.SenderSystem,
+		Content:     content,
+		Timestamp:   s.now().UTC(),
+		ContentType: model.ContentText,
+	}
+}
+
+// ValidateEventTimes validates the new times of a rescheduled event.
+func ValidateEventTimes(start, end *time.Time) (time.Time, time.Time, error) {
+	if start == nil || start.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("startTime must not be empty")
+	}
+	if end == nil || end.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("endTime must not be empty")
+	}
+	if !end.After(*start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("endTime must be after startTime")
+	}
+	return start.UTC(), end.UTC(), nil
+}
+
+// ValidateRSVP validates an RSVP's attendee and status.
+func ValidateRSVP(attendee string, status model.RSVPStatus) error {
+	if strings.TrimSpace(attendee) == "" {
+		return fmt.Errorf("attendee must not be empty")
+	}
+	if !status.IsValid() {
+		return fmt.Errorf("invalid status: %q. Must be one of accepted, declined or tentative", status)
+	}
+	return nil
+}
+
+// formatEventTimes describes an event's times in UTC, e.g. "Mon 2 Mar 2026 15:00–16:00 UTC".
+func formatEventTimes(start, end time.Time) string {
+	start, end = start.UTC(), end.UTC()
+	if start.Format("2006-01-02") == end.Format("2006-01-02") {
+		return start.Format("Mon 2 Jan 2006 15:04") + "–" + end.Format("15:04") + " UTC"
+	}
+	return start.Format("Mon 2 Jan 2006 15:04") + " – " + end.Format("Mon 2 Jan 2006 15:04") + " UTC"
+}
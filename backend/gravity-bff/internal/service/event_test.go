@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+func newTestEvent() *model.CalendarEvent {
+	email := "Ana@Example.com"
+	return &model.CalendarEvent{
+		ID:        "evt-1",
+		Title:     "Design review",
+		StartTime: time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 3, 2, 16, 0, 0, 0, time.UTC),
+		Attendees: []model.User{
+			{ID: "user-123", Name: "Me"},
+			{Name: "Ana Lima", Email: &email},
+		},
+	}
+}
+
+func TestStreamService_RescheduleEvent(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewStreamService(mockRepo, mockCache, publisher, newTestConfig(), logger.New())
+
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 3, 11, 30, 0, 0, time.UTC)
+	req := model.RescheduleEventRequest{UserID: "user-123", ItemID: "item-1", MessageID: "msg-1", StartTime: &start, EndTime: &end}
+
+	mockRepo.On("UpdateEvent", mock.Anything, "user-123", "item-1", "msg-1", mock.Anything).Return(newTestEvent(), nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	result, err := svc.RescheduleEvent(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, start, result.Event.StartTime)
+	assert.Equal(t, end, result.Event.EndTime)
+	assert.Equal(t, model.SenderSystem, result.Message.SenderType)
+	assert.Equal(t, `Rescheduled "Design review" from Mon 2 Mar 2026 15:00–16:00 UTC to Tue 3 Mar 2026 10:00–11:30 UTC.`, result.Message.Content)
+
+	require.Equal(t, 2, len(publisher.published))
+	assert.Equal(t, events.ItemUpdated, publisher.published[0].Type)
+	assert.Equal(t, events.MessageCreated, publisher.published[1].Type)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_RescheduleEvent_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	req := model.RescheduleEventRequest{UserID: "user-123", ItemID: "item-1", MessageID: "missing", StartTime: &start, EndTime: &end}
+
+	mockRepo.On("UpdateEvent", mock.Anything, "user-123", "item-1", "missing", mock.Anything).Return(nil, nil)
+
+	// Act
+	result, err := svc.RescheduleEvent(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockCache.AssertNotCalled(t, "InvalidateUserCache", mock.Anything, mock.Anything)
+}
+
+func TestStreamService_RespondToEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		attendee string
+		status   model.RSVPStatus
+		key      string
+		content  string
+	}{
+		{"by user ID", "user-123", model.RSVPAccepted, "user-123", `Me accepted "Design review".`},
+		{"by email, any case", "ana@example.com", model.RSVPTentative, "ana@example.com", `Ana Lima tentatively accepted "Design review".`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockStreamRepository)
+			mockCache := new(MockCache)
+			svc := newTestService(mockRepo, mockCache)
+
+			req := model.RSVPRequest{UserID: "user-123", ItemID: "item-1", MessageID: "msg-1", Attendee: tt.attendee, Status: tt.status}
+
+			mockRepo.On("UpdateEvent", mock.Anything, "user-123", "item-1", "msg-1", mock.Anything).Return(newTestEvent(), nil)
+			mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+			mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+			// Act
+			result, err := svc.RespondToEvent(context.Background(), req)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, 1, len(result.Event.RSVPs))
+			assert.Equal(t, tt.key, result.Event.RSVPs[0].Attendee)
+			assert.Equal(t, tt.status, result.Event.RSVPs[0].Status)
+			assert.Equal(t, tt.content, result.Message.Content)
+		})
+	}
+}
+
+func TestStreamService_RespondToEvent_ReplacesEarlierResponse(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	event := newTestEvent()
+	event.RSVPs = []model.RSVP{{Attendee: "user-123", Status: model.RSVPAccepted}}
+	req := model.RSVPRequest{UserID: "user-123", ItemID: "item-1", MessageID: "msg-1", Attendee: "user-123", Status: model.RSVPDeclined}
+
+	mockRepo.On("UpdateEvent", mock.Anything, "user-123", "item-1", "msg-1", mock.Anything).Return(event, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	result, err := svc.RespondToEvent(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.Event.RSVPs))
+	assert.Equal(t, model.RSVPDeclined, result.Event.RSVPs[0].Status)
+	assert.Equal(t, `Me declined "Design review".`, result.Message.Content)
+}
+
+func TestStreamService_RespondToEvent_UnknownAttendee(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	req := model.RSVPRequest{UserID: "user-123", ItemID: "item-1", MessageID: "msg-1", Attendee: "eve@example.com", Status: model.RSVPAccepted}
+
+	mockRepo.On("UpdateEvent", mock.Anything, "user-123", "item-1", "msg-1", mock.Anything).Return(newTestEvent(), nil)
+
+	// Act
+	result, err := svc.RespondToEvent(context.Background(), req)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, ErrUnknownAttendee))
+	mockCache.AssertNotCalled(t, "InvalidateUserCache", mock.Anything, mock.Anything)
+}
+
+// Tests for ValidateEventTimes
+func TestValidateEventTimes(t *testing.T) {
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	before := start.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		start    *time.Time
+		end      *time.Time
+		hasError bool
+	}{
+		{"valid", &start, &end, false},
+		{"missing start", nil, &end, true},
+		{"missing end", &start, nil, true},
+		{"end before start", &start, &before, true},
+		{"end equals start", &start, &start, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ValidateEventTimes(tt.start, tt.end)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+)
+
+// ErrNotAwaitingReply means a follow-up was asked for on an item where the user did not write last.
+var ErrNotAwaitingReply = errors.New("item is not awaiting a reply")
+
+// MaxFollowUpDays is the longest a follow-up can wait for a reply.
+const MaxFollowUpDays = 90
+
+// SetFollowUp resurfaces an item req.Days from now unless someone else replies
+// first. Returns nil if the item does not exist, or ErrNotAwaitingReply if the
+// user did not write last.
+func (s *StreamService) SetFollowUp(ctx context.Context, req model.FollowUpRequest) (*model.FollowUpResponse, error) {
+	if err := ValidateFollowUpDays(req.Days); err != nil {
+		return nil, err
+	}
+	at := s.now().UTC().AddDate(0, 0, req.Days)
+
+	response, err := s.setFollowUp(ctx, req.UserID, req.ItemID, &at)
+	if err != nil || response == nil {
+		return nil, err
+	}
+	if !response.AwaitingReply {
+		return nil, ErrNotAwaitingReply
+	}
+	return response, nil
+}
+
+// ClearFollowUp cancels an item's follow-up. Returns nil if the item does not exist.
+func (s *StreamService) ClearFollowUp(ctx context.Context, userID, itemID string) (*model.FollowUpResponse, error) {
+	return s.setFollowUp(ctx, userID, itemID, nil)
+}
+
+// setFollowUp updates an item's follow-up and announces the change.
+func (s *StreamService) setFollowUp(ctx context.Context, userID, itemID string, at *time.Time) (*model.FollowUpResponse, error) {
+	response, err := s.repo.SetFollowUp(ctx, userID, itemID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update follow-up: %w", err)
+	}
+
+	if response == nil {
+		return nil, nil // Not found
+	}
+
+	if at == nil || response.AwaitingReply {
+		s.invalidateItems(ctx, userID, itemID)
+		s.publish(ctx, userID, events.ItemFollowUp, itemID, response)
+	}
+
+	return response, nil
+}
+
+// ResurfaceFollowUps resurfaces every item whose follow-up is due without a
+// reply, in batches. Resurfaced items are marked unread, moved to the top of
+// the stream and rescored before their owners are notified. Returns the number
+// of items resurfaced.
+func (s *StreamService) ResurfaceFollowUps(ctx context.Context) (int, error) {
+	batchSize := s.config.FollowUp.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		resurfaced, err := s.repo.ResurfaceFollowUps(ctx, s.now(), batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to resurface follow-ups: %w", err)
+		}
+		total += len(resurfaced)
+
+		s.scoreWoken(ctx, resurfaced)
+		for _, item := range resurfaced {
+			s.invalidateItems(ctx, item.UserID, item.ItemID)
+			dropCounts(ctx, s.cache, s.log, item.UserID)
+			s.publish(ctx, item.UserID, events.ItemResurfaced, item.ItemID, nil)
+		}
+
+		if len(resurfaced) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// ValidateFollowUpDays checks that a follow-up waits between 1 and MaxFollowUpDays days.
+func ValidateFollowUpDays(days int) error {
+	if days < 1 || days > MaxFollowUpDays {
+		return fmt.Errorf("days must be between 1 and %d", MaxFollowUpDays)
+	}
+	return nil
+}
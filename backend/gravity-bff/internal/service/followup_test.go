@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+var testFollowUpNow = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func TestStreamService_SetFollowUp(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewStreamService(mockRepo, mockCache, publisher, newTestConfig(), logger.New())
+	svc.now = func() time.Time { return testFollowUpNow }
+
+	at := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	req := model.FollowUpRequest{UserID: "user-123", ItemID: "item-1", Days: 3}
+
+	mockRepo.On("SetFollowUp", mock.Anything, "user-123", "item-1", &at).
+		Return(&model.FollowUpResponse{ItemID: "item-1", FollowUpAt: &at, AwaitingReply: true}, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	result, err := svc.SetFollowUp(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, at, *result.FollowUpAt)
+	require.Equal(t, 1, len(publisher.published))
+	assert.Equal(t, events.ItemFollowUp, publisher.published[0].Type)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_SetFollowUp_NotAwaitingReply(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	req := model.FollowUpRequest{UserID: "user-123", ItemID: "item-1", Days: 3}
+	mockRepo.On("SetFollowUp", mock.Anything, "user-123", "item-1", mock.Anything).
+		Return(&model.FollowUpResponse{ItemID: "item-1"}, nil)
+
+	// Act
+	result, err := svc.SetFollowUp(context.Background(), req)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, ErrNotAwaitingReply))
+	mockCache.AssertNotCalled(t, "InvalidateUserCache", mock.Anything, mock.Anything)
+}
+
+func TestStreamService_ClearFollowUp_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	mockRepo.On("SetFollowUp", mock.Anything, "user-123", "nonexistent", (*time.Time)(nil)).Return(nil, nil)
+
+	// Act
+	result, err := svc.ClearFollowUp(context.Background(), "user-123", "nonexistent")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockCache.AssertNotCalled(t, "InvalidateUserCache", mock.Anything, mock.Anything)
+}
+
+func TestStreamService_ResurfaceFollowUps(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	cfg := newTestConfig()
+	cfg.FollowUp = config.FollowUpConfig{Interval: time.Minute, BatchSize: 2}
+	svc := NewStreamService(mockRepo, mockCache, publisher, cfg, logger.New())
+	svc.now = func() time.Time { return testFollowUpNow }
+
+	// A full batch means there may be more due items, so a second query runs
+	mockRepo.On("ResurfaceFollowUps", mock.Anything, testFollowUpNow, 2).Return([]model.ItemRef{
+		{ItemID: "item-1", UserID: "user-1"},
+		{ItemID: "item-2", UserID: "user-2"},
+	}, nil).Once()
+	mockRepo.On("ResurfaceFollowUps", mock.Anything, testFollowUpNow, 2).Return([]model.ItemRef{}, nil).Once()
+	mockCache.On("InvalidateUserCache", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	n, err := svc.ResurfaceFollowUps(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.Equal(t, 2, len(publisher.published))
+	for _, evt := range publisher.published {
+		assert.Equal(t, events.ItemResurfaced, evt.Type)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+// Tests for ValidateFollowUpDays
+func TestValidateFollowUpDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		days     int
+		hasError bool
+	}{
+		{"one day", 1, false},
+		{"maximum", MaxFollowUpDays, false},
+		{"zero", 0, true},
+		{"negative", -2, true},
+		{"too many", MaxFollowUpDays + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFollowUpDays(tt.days)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
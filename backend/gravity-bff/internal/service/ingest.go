@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/cache"
+	"github.com/mabidoli/gravity-bff/internal/calendar"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/ingestion/email"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MaxIngestItems is the maximum number of items accepted in one delivery.
+const MaxIngestItems = 100
+
+// ItemScorer recomputes the priority scores of stored items.
+type ItemScorer interface {
+	ScoreItems(ctx context.Context, itemIDs []string) ([]model.ItemScore, error)
+}
+
+// ItemTriager applies the owners' triage rules to freshly stored items.
+// results[i] is the stored result of items[i].
+type ItemTriager interface {
+	ApplyRules(ctx context.Context, items []model.IngestItem, results []model.IngestResult) error
+}
+
+// ItemInsighter queues stored items for AI insight generation.
+type ItemInsighter interface {
+	QueueInsights(ctx context.Context, itemIDs []string) error
+}
+
+// ConflictChecker annotates a user's calendar events with the events they overlap.
+// It returns the events whose annotation changed.
+type ConflictChecker interface {
+	CheckConflicts(ctx context.Context, userID string) ([]model.EventSlot, error)
+}
+
+// IngestService stores items received from source connectors.
+type IngestService struct {
+	repo       repository.IngestRepository
+	connectors *ingestion.Registry
+	triager    ItemTriager     // nil until SetTriager
+	scorer     ItemScorer      // nil until SetScorer; unscored items wait for the scheduled rescore
+	insighter  ItemInsighter   // nil until SetInsighter
+	conflicts  ConflictChecker // nil until SetConflictChecker
+	cache      cache.Cache
+	events     events.Publisher
+	log        *logger.Logger
+}
+
+// NewIngestService creates a new ingest service.
+func NewIngestService(
+	repo repository.IngestRepository,
+	connectors *ingestion.Registry,
+	cache cache.Cache,
+	publisher events.Publisher,
+	log *logger.Logger,
+) *IngestService {
+	return &IngestService{
+		repo:       repo,
+		connectors: connectors,
+		cache:      cache,
+		events:     publisher,
+		log:        log,
+	}
+}
+
+// SetScorer makes the service score items as soon as they are stored.
+func (s *IngestService) SetScorer(scorer ItemScorer) {
+	s.scorer = scorer
+}
+
+// SetTriager makes the service apply triage rules to items as they are stored.
+func (s *IngestService) SetTriager(triager ItemTriager) {
+	s.triager = triager
+}
+
+// SetInsighter makes the service queue items with new messages for insight generation.
+func (s *IngestService) SetInsighter(insighter ItemInsighter) {
+	s.insighter = insighter
+}
+
+// SetConflictChecker makes the service check the owners' calendars for
+// conflicts when events are stored.
+func (s *IngestService) SetConflictChecker(conflicts ConflictChecker) {
+	s.conflicts = conflicts
+}
+
+// HandleWebhook authenticates, parses and stores a webhook delivery for a source.
+// Returns ingestion.ErrUnknownSource, ingestion.ErrInvalidSignature or
+// ingestion.ErrInvalidPayload (wrapped) when the delivery is rejected.
+func (s *IngestService) HandleWebhook(ctx context.Context, source model.SourceType, body []byte, signature string) (*model.IngestResponse, error) {
+	connector, err := s.connectors.Get(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := connector.Verify(body, signature); err != nil {
+		return nil, err
+	}
+
+	items, err := connector.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Ingest(ctx, items)
+}
+
+// Ingest validates and upserts normalized items. Items are upserted by external ID,
+// so delivering the same items again is a no-op apart from refreshing content.
+// The whole batch is validated before anything is stored.
+func (s *IngestService) Ingest(ctx context.Context, items []model.IngestItem) (*model.IngestResponse, error) {
+	if len(items) > MaxIngestItems {
+		return nil, fmt.Errorf("%w: too many items: %d. Maximum is %d",
+			ingestion.ErrInvalidPayload, len(items), MaxIngestItems)
+	}
+
+	for i := range items {
+		if err := NormalizeIngestItem(&items[i]); err != nil {
+			return nil, fmt.Errorf("%w: items[%d]: %v", ingestion.ErrInvalidPayload, i, err)
+		}
+	}
+
+	response := &model.IngestResponse{Results: make([]model.IngestResult, 0, len(items))}
+	var upsertErr error
+	for _, item := range items {
+		result, err := s.repo.UpsertItem(ctx, item)
+		if err != nil {
+			upsertErr = fmt.Errorf("failed to ingest item %s: %w", item.ExternalID, err)
+			break
+		}
+		response.Results = append(response.Results, *result)
+	}
+
+	// Apply rules, then score (respecting rule priorities), before notifying
+	// so that refetches see the final state. Items stored before a failure
+	// are still processed and announced.
+	s.triageResults(ctx, items[:len(response.Results)], response.Results)
+	s.scoreResults(ctx, response.Results)
+	s.countResults(ctx, items, response.Results)
+	s.queueInsights(ctx, response.Results)
+	s.checkConflicts(ctx, items[:len(response.Results)], response.Results)
+
+	for i, result := range response.Results {
+		userID := items[i].UserID
+		s.invalidateItem(ctx, userID, result.ItemID)
+
+		eventType := events.ItemUpdated
+		if result.Created {
+			eventType = events.ItemCreated
+		}
+		s.publish(ctx, userID, eventType, result.ItemID, result)
+	}
+
+	if upsertErr != nil {
+		return nil, upsertErr
+	}
+
+	return response, nil
+}
+
+// triageResults applies triage rules to freshly stored items. Failures are
+// logged; the items are stored either way.
+func (s *IngestService) triageResults(ctx context.Context, items []model.IngestItem, results []model.IngestResult) {
+	if s.triager == nil || len(results) == 0 {
+		return
+	}
+	if err := s.triager.ApplyRules(ctx, items, results); err != nil {
+		s.log.Warn("Failed to apply rules to ingested items: %v", err)
+	}
+}
+
+// scoreResults scores freshly stored items. Failures are logged; the items
+// keep their previous score until the scheduled rescore picks them up.
+func (s *IngestService) scoreResults(ctx context.Context, results []model.IngestResult) {
+	if s.scorer == nil || len(results) == 0 {
+		return
+	}
+
+	itemIDs := make([]string, len(results))
+	for i, result := range results {
+		itemIDs[i] = result.ItemID
+	}
+	if _, err := s.scorer.ScoreItems(ctx, itemIDs); err != nil {
+		s.log.Warn("Failed to score ingested items: %v", err)
+	}
+}
+
+// queueInsights queues the items that received new messages for insight
+// generation, which happens in the background. Failures are logged; the items
+// get insights with their next message or when the user regenerates them.
+func (s *IngestService) queueInsights(ctx context.Context, results []model.IngestResult) {
+	if s.insighter == nil {
+		return
+	}
+
+	var itemIDs []string
+	for _, result := range results {
+		if len(result.NewMessages) > 0 {
+			itemIDs = append(itemIDs, result.ItemID)
+		}
+	}
+	if len(itemIDs) == 0 {
+		return
+	}
+	if err := s.insighter.QueueInsights(ctx, itemIDs); err != nil {
+		s.log.Warn("Failed to queue ingested items for insights: %v", err)
+	}
+}
+
+// checkConflicts checks the calendars of the owners of stored items that carry
+// events for conflicts. Other items whose events' annotations changed are
+// invalidated and announced here; the stored items are announced with the
+// batch. Failures are logged; annotations catch up with the next calendar change.
+func (s *IngestService) checkConflicts(ctx context.Context, items []model.IngestItem, results []model.IngestResult) {
+	if s.conflicts == nil {
+		return
+	}
+
+	stored := make(map[string]bool, len(results))
+	var userIDs []string
+	for i, result := range results {
+		stored[result.ItemID] = true
+		if hasEvents(items[i]) && !slices.Contains(userIDs, items[i].UserID) {
+			userIDs = append(userIDs, items[i].UserID)
+		}
+	}
+
+	for _, userID := range userIDs {
+		changed, err := s.conflicts.CheckConflicts(ctx, userID)
+		if err != nil {
+			s.log.Warn("Failed to check calendar conflicts: %v", err)
+		}
+		for _, slot := range changed {
+			if stored[slot.ItemID] {
+				continue
+			}
+			stored[slot.ItemID] = true
+			s.invalidateItem(ctx, userID, slot.ItemID)
+			s.publish(ctx, userID, events.ItemUpdated, slot.ItemID, map[string]string{"insights": slot.MessageID})
+		}
+	}
+}
+
+// hasEvents reports whether any message of an item carries a calendar event.
+func hasEvents(item model.IngestItem) bool {
+	for _, msg := range item.Messages {
+		if msg.EventDetails != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// countResults adjusts the owners' cached counts by the difference between the
+// items' counted state before the upsert and after triage and scoring.
+// If the new state cannot be read the owners' counts are dropped instead.
+func (s *IngestService) countResults(ctx context.Context, items []model.IngestItem, results []model.IngestResult) {
+	if len(results) == 0 {
+		return
+	}
+
+	itemIDs := make([]string, len(results))
+	for i, result := range results {
+		itemIDs[i] = result.ItemID
+	}
+	counted, err := s.repo.GetCountedItems(ctx, itemIDs)
+
+	changes := make(map[string][]model.CountChange)
+	for i, result := range results {
+		change := model.CountChange{ItemID: result.ItemID, Before: result.CountedBefore}
+		if after, ok := counted[result.ItemID]; ok {
+			change.After = &after
+		}
+		changes[items[i].UserID] = append(changes[items[i].UserID], change)
+	}
+
+	if err != nil {
+		s.log.Warn("Failed to get counted state of ingested items: %v", err)
+		for userID := range changes {
+			dropCounts(ctx, s.cache, s.log, userID)
+		}
+		return
+	}
+
+	for userID, userChanges := range changes {
+		adjustCounts(ctx, s.cache, s.log, userID, userChanges)
+	}
+}
+
+// ImportEmail threads parsed email messages into items and ingests them.
+// Threads that fail validation are skipped and logged; the rest are still imported.
+func (s *IngestService) ImportEmail(ctx context.Context, messages []*email.Message, opts email.Options) (*model.IngestResponse, error) {
+	items, err := email.ToItems(ctx, email.ThreadMessages(messages), opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.importItems(ctx, items)
+}
+
+// ImportCalendar expands parsed calendar events into occurrences and ingests
+// them as calendar items. Recurring events are expanded within [from, to).
+// Occurrences that fail validation are skipped and logged.
+func (s *IngestService) ImportCalendar(ctx context.Context, events []calendar.Event, opts calendar.Options, from, to time.Time) (*model.IngestResponse, error) {
+	items := calendar.ToItems(calendar.Expand(events, from, to), opts)
+	return s.importItems(ctx, items)
+}
+
+// importItems validates items one by one, skipping invalid ones, and ingests
+// the rest in batches of MaxIngestItems.
+func (s *IngestService) importItems(ctx context.Context, items []model.IngestItem) (*model.IngestResponse, error) {
+	valid := make([]model.IngestItem, 0, len(items))
+	for _, item := range items {
+		if err := NormalizeIngestItem(&item); err != nil {
+			s.log.Warn("Skipping imported item %s: %v", item.ExternalID, err)
+			continue
+		}
+		valid = append(valid, item)
+	}
+
+	response := &model.IngestResponse{Results: make([]model.IngestResult, 0, len(valid))}
+	for start := 0; start < len(valid); start += MaxIngestItems {
+		end := min(start+MaxIngestItems, len(valid))
+		batch, err := s.Ingest(ctx, valid[start:end])
+		if err != nil {
+			return response, err
+		}
+		response.Results = append(response.Results, batch.Results...)
+	}
+
+	return response, nil
+}
+
+// invalidateItem drops the user's cached stream pages and the item's details.
+// Cache failures are logged and otherwise ignored; entries expire on their own TTL.
+func (s *IngestService) invalidateItem(ctx context.Context, userID, itemID string) {
+	if err := s.cache.InvalidateUserCache(ctx, userID); err != nil {
+		s.log.Warn("Failed to invalidate stream cache: %v", err)
+	}
+	if err := s.cache.Delete(ctx, cache.ItemKey(itemID)); err != nil {
+		s.log.Warn("Failed to invalidate item cache: %v", err)
+	}
+}
+
+// publish sends a live update event to the user's clients.
+// Failures are logged; clients fall back to refetching on their own.
+func (s *IngestService) publish(ctx context.Context, userID string, eventType events.Type, itemID string, data interface{}) {
+	evt, err := events.New(eventType, itemID, data)
+	if err != nil {
+		s.log.Warn("Failed to build %s event: %v", eventType, err)
+		return
+	}
+	if err := s.events.Publish(ctx, userID, evt); err != nil {
+		s.log.Warn("Failed to publish %s event: %v", eventType, err)
+	}
+}
+
+// NormalizeIngestItem validates an ingested item and fills in defaults:
+// medium priority, text messages from other senders, and a snippet taken
+// from the latest message when the source does not provide one.
+func NormalizeIngestItem(item *model.IngestItem) error {
+	item.UserID = strings.TrimSpace(item.UserID)
+	item.ExternalID = strings.TrimSpace(item.ExternalID)
+	item.Title = strings.TrimSpace(item.Title)
+
+	if item.UserID == "" {
+		return fmt.Errorf("userId must not be empty")
+	}
+	if !item.Source.IsValid() {
+		return fmt.Errorf("invalid source: %q", item.Source)
+	}
+	if item.ExternalID == "" {
+		return fmt.Errorf("externalId must not be empty")
+	}
+	if item.Title == "" {
+		return fmt.Errorf("title must not be empty")
+	}
+	if item.Timestamp.IsZero() {
+		return fmt.Errorf("timestamp must not be empty")
+	}
+
+	if item.Priority == "" {
+		item.Priority = model.PriorityMedium
+	}
+	if !item.Priority.IsValid() {
+		return fmt.Errorf("invalid priority: %q", item.Priority)
+	}
+
+	seen := make(map[string]struct{}, len(item.Messages))
+	for i := range item.Messages {
+		msg := &item.Messages[i]
+		msg.ExternalID = strings.TrimSpace(msg.ExternalID)
+		if msg.ExternalID == "" {
+			return fmt.Errorf("messages[%d].externalId must not be empty", i)
+		}
+		if _, ok := seen[msg.ExternalID]; ok {
+			return fmt.Errorf("messages[%d].externalId is duplicated: %q", i, msg.ExternalID)
+		}
+		seen[msg.ExternalID] = struct{}{}
+
+		if msg.Timestamp.IsZero() {
+			msg.Timestamp = item.Timestamp
+		}
+		if msg.SenderType == "" {
+			msg.SenderType = model.SenderOther
+		}
+		if !msg.SenderType.IsValid() {
+			return fmt.Errorf("messages[%d].sender is invalid: %q", i, msg.SenderType)
+		}
+		if msg.ContentType == "" {
+			msg.ContentType = model.ContentText
+		}
+		if !msg.ContentType.IsValid() {
+			return fmt.Errorf("messages[%d].type is invalid: %q", i, msg.ContentType)
+		}
+	}
+
+	if item.Snippet == nil && len(item.Messages) > 0 {
+		latest := item.Messages[0]
+		for _, msg := range item.Messages[1:] {
+			if msg.Timestamp.After(latest.Timestamp) {
+				latest = msg
+			}
+		}
+		snippet := Snippet(latest.Content)
+		item.Snippet = &snippet
+	}
+
+	return nil
+}
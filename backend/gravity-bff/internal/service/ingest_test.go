@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/ingestion/email"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockIngestRepository is a mock implementation of IngestRepository.
+type MockIngestRepository struct {
+	mock.Mock
+}
+
+func (m *MockIngestRepository) UpsertItem(ctx context.Context, item model.IngestItem) (*model.IngestResult, error) {
+	args := m.Called(ctx, item)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*model.IngestResult), args.Error(1)
+}
+
+func (m *MockIngestRepository) GetCountedItems(ctx context.Context, itemIDs []string) (map[string]model.CountedItem, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]model.CountedItem), args.Error(1)
+}
+
+func newTestIngestItem() model.IngestItem {
+	return model.IngestItem{
+		UserID:     "user_1",
+		Source:     model.SourceSlack,
+		ExternalID: "C1/1700000000.000100",
+		Title:      "#deploys",
+		Timestamp:  time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+		Messages: []model.IngestMessage{
+			{ExternalID: "1", Content: "First", Timestamp: time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)},
+			{ExternalID: "2", Content: "Latest   deploy\nfinished"},
+		},
+	}
+}
+
+func TestIngestService_HandleWebhook_Created(t *testing.T) {
+	// Arrange
+	repo := new(MockIngestRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	connector := ingestion.NewFakeConnector(model.SourceSlack, newTestIngestItem())
+	svc := NewIngestService(repo, ingestion.NewRegistry(connector), mockCache, publisher, logger.New())
+
+	repo.On("UpsertItem", mock.Anything, mock.MatchedBy(func(item model.IngestItem) bool {
+		return item.Priority == model.PriorityMedium && *item.Snippet == "Latest deploy finished"
+	})).Return(&model.IngestResult{ItemID: "item-1", Created: true, NewMessages: []string{"m1", "m2"}}, nil)
+	repo.On("GetCountedItems", mock.Anything, []string{"item-1"}).Return(map[string]model.CountedItem{
+		"item-1": {Source: model.SourceSlack, Unread: true},
+	}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "user_1", mock.MatchedBy(func(delta *model.CountsResponse) bool {
+		return delta.Total == 1 && delta.Unread == 1 && delta.High == 0 &&
+			delta.Sources[model.SourceSlack] == model.StreamCounts{Total: 1, Unread: 1}
+	})).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	resp, err := svc.HandleWebhook(context.Background(), model.SourceSlack, []byte("{}"), "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Results))
+	assert.True(t, resp.Results[0].Created)
+	assert.Equal(t, 1, len(publisher.published))
+	assert.Equal(t, events.ItemCreated, publisher.published[0].Type)
+	repo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestIngestService_HandleWebhook_Rejected(t *testing.T) {
+	connector := ingestion.NewFakeConnector(model.SourceSlack, newTestIngestItem())
+	connector.Signature = "sha256=good"
+	svc := NewIngestService(new(MockIngestRepository), ingestion.NewRegistry(connector), new(MockCache), events.NopPublisher{}, logger.New())
+
+	_, err := svc.HandleWebhook(context.Background(), model.SourceTeams, nil, "sha256=good")
+	assert.ErrorIs(t, err, ingestion.ErrUnknownSource)
+
+	_, err = svc.HandleWebhook(context.Background(), model.SourceSlack, nil, "sha256=bad")
+	assert.ErrorIs(t, err, ingestion.ErrInvalidSignature)
+}
+
+func TestIngestService_Ingest_InvalidItemStoresNothing(t *testing.T) {
+	// Arrange
+	repo := new(MockIngestRepository)
+	svc := NewIngestService(repo, ingestion.NewRegistry(), new(MockCache), events.NopPublisher{}, logger.New())
+
+	invalid := newTestIngestItem()
+	invalid.ExternalID = ""
+
+	// Act
+	_, err := svc.Ingest(context.Background(), []model.IngestItem{newTestIngestItem(), invalid})
+
+	// Assert
+	assert.ErrorIs(t, err, ingestion.ErrInvalidPayload)
+	repo.AssertNotCalled(t, "UpsertItem", mock.Anything, mock.Anything)
+}
+
+func TestIngestService_Ingest_RepositoryError(t *testing.T) {
+	repo := new(MockIngestRepository)
+	svc := NewIngestService(repo, ingestion.NewRegistry(), new(MockCache), events.NopPublisher{}, logger.New())
+	repo.On("UpsertItem", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	_, err := svc.Ingest(context.Background(), []model.IngestItem{newTestIngestItem()})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ingestion.ErrInvalidPayload)
+}
+
+func TestNormalizeIngestItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(item *model.IngestItem)
+		wantErr bool
+	}{
+		{"valid", func(item *model.IngestItem) {}, false},
+		{"missing user", func(item *model.IngestItem) { item.UserID = " " }, true},
+		{"unknown source", func(item *model.IngestItem) { item.Source = "fax" }, true},
+		{"missing title", func(item *model.IngestItem) { item.Title = "" }, true},
+		{"missing timestamp", func(item *model.IngestItem) { item.Timestamp = time.Time{} }, true},
+		{"invalid priority", func(item *model.IngestItem) { item.Priority = "urgent" }, true},
+		{"duplicate message", func(item *model.IngestItem) { item.Messages[1].ExternalID = "1" }, true},
+		{"message without id", func(item *model.IngestItem) { item.Messages[0].ExternalID = "" }, true},
+		{"unknown sender", func(item *model.IngestItem) { item.Messages[0].SenderType = "USER" }, true},
+		{"unknown content type", func(item *model.IngestItem) { item.Messages[0].ContentType = "video" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestIngestItem()
+			tt.mutate(&item)
+
+			err := NormalizeIngestItem(&item)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, model.SenderOther, item.Messages[1].SenderType)
+			assert.Equal(t, model.ContentText, item.Messages[1].ContentType)
+			assert.Equal(t, item.Timestamp, item.Messages[1].Timestamp)
+		})
+	}
+}
+
+func TestIngestService_ImportEmail(t *testing.T) {
+	// Arrange
+	repo := new(MockIngestRepository)
+	mockCache := new(MockCache)
+	svc := NewIngestService(repo, ingestion.NewRegistry(), mockCache, events.NopPublisher{}, logger.New())
+
+	date := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	messages := []*email.Message{
+		{ID: "root@example.com", Subject: "Plans", Text: "Hello", Date: date},
+		{ID: "reply@example.com", InReplyTo: "root@example.com", Subject: "Re: Plans", Text: "Sounds good", Date: date.Add(time.Hour)},
+	}
+
+	repo.On("UpsertItem", mock.Anything, mock.MatchedBy(func(item model.IngestItem) bool {
+		return item.ExternalID == "root@example.com" && len(item.Messages) == 2 && *item.Snippet == "Sounds good"
+	})).Return(&model.IngestResult{ItemID: "item-1", Created: true}, nil)
+	repo.On("GetCountedItems", mock.Anything, []string{"item-1"}).Return(map[string]model.CountedItem{}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "user_1", mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	resp, err := svc.ImportEmail(context.Background(), messages, email.Options{UserID: "user_1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Results))
+	repo.AssertExpectations(t)
+}
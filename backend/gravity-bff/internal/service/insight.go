@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mabidoli/gravity-bff/internal/cache"
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/insights"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// ErrNotDraft is returned when a draft operation targets an insight that is not
+// a draft, such as a draft that was already sent.
+var ErrNotDraft = errors.New("insight is not a draft")
+
+// ErrDraftChanged is returned when a draft changed while it was being refined or sent.
+var ErrDraftChanged = errors.New("draft was changed by another request")
+
+// MaxDraftInstructionLength is the maximum number of characters in a refinement instruction.
+const MaxDraftInstructionLength = 500
+
+// MaxDraftVersions is the number of versions kept in a draft's history; older ones are dropped.
+const MaxDraftVersions = 20
+
+// MessageSender sends messages into item threads on behalf of their owners.
+type MessageSender interface {
+	SendMessage(ctx context.Context, req model.SendMessageRequest) (*model.Message, error)
+}
+
+// InsightService generates AI insights for items and stores them on each item's latest message.
+type InsightService struct {
+	repo     repository.InsightRepository
+	provider insights.Provider
+	sender   MessageSender
+	cache    cache.Cache
+	events   events.Publisher
+	config   *config.Config
+	log      *logger.Logger
+}
+
+// NewInsightService creates a new insight service.
+func NewInsightService(
+	repo repository.InsightRepository,
+	provider insights.Provider,
+	sender MessageSender,
+	cache cache.Cache,
+	publisher events.Publisher,
+	cfg *config.Config,
+	log *logger.Logger,
+) *InsightService {
+	return &InsightService{
+		repo:     repo,
+		provider: provider,
+		sender:   sender,
+		cache:    cache,
+		events:   publisher,
+		config:   cfg,
+		log:      log,
+	}
+}
+
+// QueueInsights queues items for insight generation by ProcessQueue.
+func (s *InsightService) QueueInsights(ctx context.Context, itemIDs []string) error {
+	return s.repo.QueueItems(ctx, itemIDs)
+}
+
+// ProcessQueue generates insights for queued items, in batches, until the queue
+// is empty. Items that fail are logged and skipped; they get insights again when
+// new messages arrive or the user regenerates them. Returns the number of items processed.
+func (s *InsightService) ProcessQueue(ctx context.Context) (int, error) {
+	batchSize := s.config.Insights.BatchSize
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		itemIDs, err := s.repo.DequeueItems(ctx, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to dequeue items: %w", err)
+		}
+
+		for _, itemID := range itemIDs {
+			thread, err := s.repo.GetThread(ctx, itemID)
+			if err != nil {
+				s.log.Warn("Failed to get thread %s for insights: %v", itemID, err)
+				continue
+			}
+			if thread == nil {
+				continue // Deleted since it was queued
+			}
+			if _, err := s.generate(ctx, thread); err != nil {
+				s.log.Warn("Failed to generate insights for item %s: %v", itemID, err)
+				continue
+			}
+			total++
+		}
+
+		if len(itemIDs) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// RegenerateInsights generates the insights of a user's item right away,
+// replacing those on its latest message. Returns nil if the item does not exist.
+func (s *InsightService) RegenerateInsights(ctx context.Context, userID, itemID string) (*model.InsightsResponse, error) {
+	thread, err := s.repo.GetThread(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread: %w", err)
+	}
+	if thread == nil || thread.UserID != userID {
+		return nil, nil // Not found
+	}
+
+	return s.generate(ctx, thread)
+}
+
+// generate generates and stores the insights of a thread's latest message.
+func (s *InsightService) generate(ctx context.Context, thread *model.InsightThread) (*model.InsightsResponse, error) {
+	generated, err := insights.Generate(ctx, s.provider, *thread)
+	if err != nil {
+		return nil, fmt.Errorf("%s provider: %w", s.provider.Name(), err)
+	}
+
+	latest := thread.Latest()
+	if latest == nil {
+		return &model.InsightsResponse{Data: generated}, nil
+	}
+
+	if err := s.repo.SaveInsights(ctx, latest.ID, generated); err != nil {
+		return nil, fmt.Errorf("failed to save insights: %w", err)
+	}
+
+	s.notify(ctx, thread.UserID, thread.ItemID, latest.ID)
+
+	return &model.InsightsResponse{MessageID: latest.ID, Data: generated}, nil
+}
+
+// RefineDraft rewrites a draft reply following req.Instruction and adds the
+// result to the draft's version history. Returns nil if the draft does not exist.
+func (s *InsightService) RefineDraft(ctx context.Context, req model.DraftRequest) (*model.AIInsight, error) {
+	instruction, err := ValidateDraftInstruction(req.Instruction)
+	if err != nil {
+		return nil, err
+	}
+	return s.rework(ctx, req, instruction)
+}
+
+// RegenerateDraft replaces a draft reply with a different one and adds it to the
+// draft's version history. Returns nil if the draft does not exist.
+func (s *InsightService) RegenerateDraft(ctx context.Context, req model.DraftRequest) (*model.AIInsight, error) {
+	return s.rework(ctx, req, "")
+}
+
+// SendDraft sends a draft reply into the item's thread as a message from the user
+// and marks the draft as sent. Returns nil if the draft does not exist.
+func (s *InsightService) SendDraft(ctx context.Context, req model.DraftRequest) (*model.Message, error) {
+	_, draft, err := s.loadDraft(ctx, req)
+	if err != nil || draft == nil {
+		return nil, err
+	}
+
+	// Claim the draft first, so that it is sent only once
+	sent := *draft
+	sent.IsDraft = false
+	claimed, err := s.repo.ReplaceInsight(ctx, req.MessageID, *draft, sent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim draft: %w", err)
+	}
+	if !claimed {
+		return nil, ErrDraftChanged
+	}
+
+	msg, err := s.sender.SendMessage(ctx, model.SendMessageRequest{
+		UserID:  req.UserID,
+		ItemID:  req.ItemID,
+		Content: draft.Content,
+	})
+	if err != nil || msg == nil {
+		if _, restoreErr := s.repo.ReplaceInsight(ctx, req.MessageID, sent, *draft); restoreErr != nil {
+			s.log.Warn("Failed to restore draft %s: %v", draft.ID, restoreErr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to send draft: %w", err)
+		}
+		return nil, nil // Item deleted meanwhile
+	}
+
+	recorded := sent
+	recorded.SentMessageID = msg.ID
+	if _, err := s.repo.ReplaceInsight(ctx, req.MessageID, sent, recorded); err != nil {
+		s.log.Warn("Failed to record sent draft %s: %v", draft.ID, err)
+	}
+
+	s.notify(ctx, req.UserID, req.ItemID, req.MessageID)
+
+	return msg, nil
+}
+
+// rework has the provider rewrite a draft and stores the new version.
+func (s *InsightService) rework(ctx context.Context, req model.DraftRequest, instruction string) (*model.AIInsight, error) {
+	thread, draft, err := s.loadDraft(ctx, req)
+	if err != nil || draft == nil {
+		return nil, err
+	}
+
+	content, err := s.provider.RefineReply(ctx, *thread, *draft, instruction)
+	if err != nil {
+		return nil, fmt.Errorf("%s provider: %w", s.provider.Name(), err)
+	}
+	if content, err = ValidateMessageContent(content); err != nil {
+		return nil, fmt.Errorf("%s provider returned an unusable draft: %w", s.provider.Name(), err)
+	}
+
+	next := *draft
+	next.Content = content
+	next.Versions = appendDraftVersion(draft, model.DraftVersion{Content: content, Instruction: instruction})
+
+	replaced, err := s.repo.ReplaceInsight(ctx, req.MessageID, *draft, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save draft: %w", err)
+	}
+	if !replaced {
+		return nil, ErrDraftChanged
+	}
+
+	s.notify(ctx, req.UserID, req.ItemID, req.MessageID)
+
+	return &next, nil
+}
+
+// loadDraft retrieves a user's draft and the thread up to the draft's message.
+// Returns a nil draft if the item, message or insight does not exist, and
+// ErrNotDraft if the insight is not a draft.
+func (s *InsightService) loadDraft(ctx context.Context, req model.DraftRequest) (*model.InsightThread, *model.AIInsight, error) {
+	thread, err := s.repo.GetThread(ctx, req.ItemID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get thread: %w", err)
+	}
+	if thread == nil || thread.UserID != req.UserID {
+		return nil, nil, nil // Not found
+	}
+
+	stored, err := s.repo.GetMessageInsights(ctx, req.ItemID, req.MessageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get insights: %w", err)
+	}
+
+	var draft *model.AIInsight
+	for i := range stored {
+		if stored[i].ID == req.InsightID {
+			draft = &stored[i]
+			break
+		}
+	}
+	if draft == nil {
+		return nil, nil, nil // Not found
+	}
+	if !draft.IsDraft {
+		return nil, nil, ErrNotDraft
+	}
+
+	// The reply answers the draft's message, not anything written after it
+	for i, msg := range thread.Messages {
+		if msg.ID == req.MessageID {
+			thread.Messages = thread.Messages[:i+1]
+			break
+		}
+	}
+
+	return thread, draft, nil
+}
+
+// appendDraftVersion returns a draft's version history with version added,
+// starting it from the draft's content if the draft was never refined.
+func appendDraftVersion(draft *model.AIInsight, version model.DraftVersion) []model.DraftVersion {
+	versions := append([]model.DraftVersion{}, draft.Versions...)
+	if len(versions) == 0 {
+		versions = append(versions, model.DraftVersion{Content: draft.Content})
+	}
+	versions = append(versions, version)
+	if len(versions) > MaxDraftVersions {
+		versions = versions[len(versions)-MaxDraftVersions:]
+	}
+	return versions
+}
+
+// notify drops an item's cached copy after its insights changed and tells the owner's clients.
+func (s *InsightService) notify(ctx context.Context, userID, itemID, messageID string) {
+	if err := s.cache.Delete(ctx, cache.ItemKey(itemID)); err != nil {
+		s.log.Warn("Failed to invalidate item cache: %v", err)
+	}
+
+	evt, err := events.New(events.ItemUpdated, itemID, map[string]string{"insights": messageID})
+	if err != nil {
+		s.log.Warn("Failed to build %s event: %v", events.ItemUpdated, err)
+	} else if err := s.events.Publish(ctx, userID, evt); err != nil {
+		s.log.Warn("Failed to publish %s event: %v", events.ItemUpdated, err)
+	}
+}
+
+// ValidateDraftInstruction trims and validates a draft refinement instruction.
+func ValidateDraftInstruction(instruction string) (string, error) {
+	instruction = strings.TrimSpace(instruction)
+	if instruction == "" {
+		return "", fmt.Errorf("instruction must not be empty")
+	}
+	if n := utf8.RuneCountInString(instruction); n > MaxDraftInstructionLength {
+		return "", fmt.Errorf("instruction is too long: %d characters. Maximum is %d", n, MaxDraftInstructionLength)
+	}
+	return instruction, nil
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/insights"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockInsightRepository is a mock implementation of InsightRepository.
+type MockInsightRepository struct {
+	mock.Mock
+}
+
+func (m *MockInsightRepository) QueueItems(ctx context.Context, itemIDs []string) error {
+	args := m.Called(ctx, itemIDs)
+	return args.Error(0)
+}
+
+func (m *MockInsightRepository) DequeueItems(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockInsightRepository) GetThread(ctx context.Context, itemID string) (*model.InsightThread, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InsightThread), args.Error(1)
+}
+
+func (m *MockInsightRepository) SaveInsights(ctx context.Context, messageID string, insights []model.AIInsight) error {
+	args := m.Called(ctx, messageID, insights)
+	return args.Error(0)
+}
+
+func (m *MockInsightRepository) GetMessageInsights(ctx context.Context, itemID, messageID string) ([]model.AIInsight, error) {
+	args := m.Called(ctx, itemID, messageID)
+	return args.Get(0).([]model.AIInsight), args.Error(1)
+}
+
+func (m *MockInsightRepository) ReplaceInsight(ctx context.Context, messageID string, prev, next model.AIInsight) (bool, error) {
+	args := m.Called(ctx, messageID, prev, next)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockMessageSender is a mock implementation of MessageSender.
+type MockMessageSender struct {
+	mock.Mock
+}
+
+func (m *MockMessageSender) SendMessage(ctx context.Context, req model.SendMessageRequest) (*model.Message, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Message), args.Error(1)
+}
+
+func newTestInsightService(repo *MockInsightRepository, sender MessageSender, mockCache *MockCache, publisher events.Publisher) *InsightService {
+	cfg := newTestConfig()
+	cfg.Insights = config.InsightsConfig{Provider: insights.RuleBasedName, BatchSize: 2}
+	return NewInsightService(repo, insights.NewRuleBased(), sender, mockCache, publisher, cfg, logger.New())
+}
+
+func newTestThread(itemID string) *model.InsightThread {
+	return &model.InsightThread{
+		ItemID: itemID,
+		UserID: "user_1",
+		Title:  "Contract",
+		Source: model.SourceEmail,
+		Messages: []model.Message{{
+			ID:         itemID + "-msg",
+			SenderType: model.SenderOther,
+			SenderInfo: &model.User{Name: "Ana Lima"},
+			Content:    "Could you sign the contract today?",
+		}},
+	}
+}
+
+func TestInsightService_RegenerateInsights(t *testing.T) {
+	// Arrange
+	repo := new(MockInsightRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := newTestInsightService(repo, new(MockMessageSender), mockCache, publisher)
+
+	repo.On("GetThread", mock.Anything, "item-1").Return(newTestThread("item-1"), nil)
+	repo.On("SaveInsights", mock.Anything, "item-1-msg", mock.AnythingOfType("[]model.AIInsight")).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	result, err := svc.RegenerateInsights(context.Background(), "user_1", "item-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "item-1-msg", result.MessageID)
+	assert.Len(t, result.Data, 3)
+	require.Len(t, publisher.published, 1)
+	assert.Equal(t, events.ItemUpdated, publisher.published[0].Type)
+	repo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestInsightService_RegenerateInsights_OtherUser(t *testing.T) {
+	// Arrange
+	repo := new(MockInsightRepository)
+	svc := newTestInsightService(repo, new(MockMessageSender), new(MockCache), events.NopPublisher{})
+
+	repo.On("GetThread", mock.Anything, "item-1").Return(newTestThread("item-1"), nil)
+
+	// Act
+	result, err := svc.RegenerateInsights(context.Background(), "user_2", "item-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	repo.AssertNotCalled(t, "SaveInsights", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInsightService_ProcessQueue(t *testing.T) {
+	// Arrange
+	repo := new(MockInsightRepository)
+	mockCache := new(MockCache)
+	svc := newTestInsightService(repo, new(MockMessageSender), mockCache, events.NopPublisher{})
+
+	// A full batch, then a short one; item-2 was deleted and item-3 fails to load
+	repo.On("DequeueItems", mock.Anything, 2).Return([]string{"item-1", "item-2"}, nil).Once()
+	repo.On("DequeueItems", mock.Anything, 2).Return([]string{"item-3"}, nil).Once()
+	repo.On("GetThread", mock.Anything, "item-1").Return(newTestThread("item-1"), nil)
+	repo.On("GetThread", mock.Anything, "item-2").Return(nil, nil)
+	repo.On("GetThread", mock.Anything, "item-3").Return(nil, errors.New("db down"))
+	repo.On("SaveInsights", mock.Anything, "item-1-msg", mock.Anything).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	processed, err := svc.ProcessQueue(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	repo.AssertExpectations(t)
+}
+
+func TestIngestService_Ingest_QueuesInsightsForNewMessages(t *testing.T) {
+	// Arrange
+	repo := new(MockIngestRepository)
+	insightRepo := new(MockInsightRepository)
+	mockCache := new(MockCache)
+	svc := NewIngestService(repo, ingestion.NewRegistry(), mockCache, events.NopPublisher{}, logger.New())
+	svc.SetInsighter(newTestInsightService(insightRepo, new(MockMessageSender), mockCache, events.NopPublisher{}))
+
+	updated := newTestIngestItem()
+	updated.ExternalID = "thread-2"
+
+	repo.On("UpsertItem", mock.Anything, mock.Anything).
+		Return(&model.IngestResult{ItemID: "item-1", Created: true, NewMessages: []string{"msg-1"}}, nil).Once()
+	repo.On("UpsertItem", mock.Anything, mock.Anything).
+		Return(&model.IngestResult{ItemID: "item-2", NewMessages: []string{}}, nil).Once()
+	repo.On("GetCountedItems", mock.Anything, mock.Anything).Return(map[string]model.CountedItem{}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	insightRepo.On("QueueItems", mock.Anything, []string{"item-1"}).Return(nil)
+
+	// Act
+	_, err := svc.Ingest(context.Background(), []model.IngestItem{newTestIngestItem(), updated})
+
+	// Assert
+	assert.NoError(t, err)
+	insightRepo.AssertExpectations(t)
+}
+
+func newTestDraft() model.AIInsight {
+	return model.AIInsight{
+		ID:      "item-1-msg-reply",
+		Type:    model.InsightDraft,
+		Label:   "Suggested reply",
+		Content: "Thanks, Ana. I'll take care of it and let you know once it's done.",
+		IsDraft: true,
+	}
+}
+
+func newTestDraftRequest() model.DraftRequest {
+	return model.DraftRequest{UserID: "user_1", ItemID: "item-1", MessageID: "item-1-msg", InsightID: "item-1-msg-reply"}
+}
+
+func TestInsightService_RefineDraft(t *testing.T) {
+	// Arrange
+	repo := new(MockInsightRepository)
+	mockCache := new(MockCache)
+	svc := newTestInsightService(repo, new(MockMessageSender), mockCache, events.NopPublisher{})
+	draft := newTestDraft()
+
+	repo.On("GetThread", mock.Anything, "item-1").Return(newTestThread("item-1"), nil)
+	repo.On("GetMessageInsights", mock.Anything, "item-1", "item-1-msg").Return([]model.AIInsight{draft}, nil)
+	repo.On("ReplaceInsight", mock.Anything, "item-1-msg", draft, mock.Anything).Return(true, nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	req := newTestDraftRequest()
+	req.Instruction = "  make it shorter "
+
+	// Act
+	refined, err := svc.RefineDraft(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "On it, thanks!", refined.Content)
+	assert.True(t, refined.IsDraft)
+	assert.Equal(t, []model.DraftVersion{
+		{Content: draft.Content},
+		{Content: "On it, thanks!", Instruction: "make it shorter"},
+	}, refined.Versions)
+	repo.AssertExpectations(t)
+}
+
+func TestInsightService_RefineDraft_Errors(t *testing.T) {
+	sent := newTestDraft()
+	sent.IsDraft = false
+
+	tests := []struct {
+		name        string
+		instruction string
+		stored      []model.AIInsight
+		replaced    bool
+		wantErr     error
+	}{
+		{"unsupported instruction", "make it rhyme", []model.AIInsight{newTestDraft()}, true, insights.ErrUnsupportedInstruction},
+		{"already sent", "shorter", []model.AIInsight{sent}, true, ErrNotDraft},
+		{"changed meanwhile", "shorter", []model.AIInsight{newTestDraft()}, false, ErrDraftChanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockInsightRepository)
+			svc := newTestInsightService(repo, new(MockMessageSender), new(MockCache), events.NopPublisher{})
+
+			repo.On("GetThread", mock.Anything, "item-1").Return(newTestThread("item-1"), nil)
+			repo.On("GetMessageInsights", mock.Anything, "item-1", "item-1-msg").Return(tt.stored, nil)
+			repo.On("ReplaceInsight", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.replaced, nil)
+
+			req := newTestDraftRequest()
+			req.Instruction = tt.instruction
+
+			draft, err := svc.RefineDraft(context.Background(), req)
+
+			assert.Nil(t, draft)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestInsightService_RegenerateDraft(t *testing.T) {
+	// Arrange
+	repo := new(MockInsightRepository)
+	mockCache := new(MockCache)
+	svc := newTestInsightService(repo, new(MockMessageSender), mockCache, events.NopPublisher{})
+
+	// Refined to a formal reply before: regenerating keeps the tone and skips used phrasings
+	draft := newTestDraft()
+	draft.Content = "Dear Ana,\n\nThank you for your message. I will take care of this and let you know once it is done.\n\nBest regards"
+	draft.Versions = []model.DraftVersion{
+		{Content: newTestDraft().Content},
+		{Content: draft.Content, Instruction: "more formal"},
+	}
+
+	repo.On("GetThread", mock.Anything, "item-1").Return(newTestThread("item-1"), nil)
+	repo.On("GetMessageInsights", mock.Anything, "item-1", "item-1-msg").Return([]model.AIInsight{draft}, nil)
+	repo.On("ReplaceInsight", mock.Anything, "item-1-msg", draft, mock.Anything).Return(true, nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	regenerated, err := svc.RegenerateDraft(context.Background(), newTestDraftRequest())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Dear Ana,\n\nThank you for reaching out. I will see to this and confirm once it is complete.\n\nKind regards", regenerated.Content)
+	assert.Len(t, regenerated.Versions, 3)
+	assert.Empty(t, regenerated.Versions[2].Instruction)
+}
+
+func TestInsightService_SendDraft(t *testing.T) {
+	// Arrange
+	repo := new(MockInsightRepository)
+	sender := new(MockMessageSender)
+	mockCache := new(MockCache)
+	svc := newTestInsightService(repo, sender, mockCache, events.NopPublisher{})
+	draft := newTestDraft()
+	sent := draft
+	sent.IsDraft = false
+	recorded := sent
+	recorded.SentMessageID = "msg-sent"
+
+	var calls []string
+	repo.On("GetThread", mock.Anything, "item-1").Return(newTestThread("item-1"), nil)
+	repo.On("GetMessageInsights", mock.Anything, "item-1", "item-1-msg").Return([]model.AIInsight{draft}, nil)
+	repo.On("ReplaceInsight", mock.Anything, "item-1-msg", draft, sent).
+		Run(func(mock.Arguments) { calls = append(calls, "claim") }).Return(true, nil)
+	sender.On("SendMessage", mock.Anything, model.SendMessageRequest{UserID: "user_1", ItemID: "item-1", Content: draft.Content}).
+		Run(func(mock.Arguments) { calls = append(calls, "send") }).Return(&model.Message{ID: "msg-sent", Content: draft.Content}, nil)
+	repo.On("ReplaceInsight", mock.Anything, "item-1-msg", sent, recorded).
+		Run(func(mock.Arguments) { calls = append(calls, "record") }).Return(true, nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	msg, err := svc.SendDraft(context.Background(), newTestDraftRequest())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "msg-sent", msg.ID)
+	assert.Equal(t, []string{"claim", "send", "record"}, calls)
+	repo.AssertExpectations(t)
+}
+
+func TestInsightService_SendDraft_RestoresDraftOnFailure(t *testing.T) {
+	// Arrange
+	repo := new(MockInsightRepository)
+	sender := new(MockMessageSender)
+	svc := newTestInsightService(repo, sender, new(MockCache), events.NopPublisher{})
+	draft := newTestDraft()
+	sent := draft
+	sent.IsDraft = false
+
+	repo.On("GetThread", mock.Anything, "item-1").Return(newTestThread("item-1"), nil)
+	repo.On("GetMessageInsights", mock.Anything, "item-1", "item-1-msg").Return([]model.AIInsight{draft}, nil)
+	repo.On("ReplaceInsight", mock.Anything, "item-1-msg", draft, sent).Return(true, nil)
+	sender.On("SendMessage", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+	repo.On("ReplaceInsight", mock.Anything, "item-1-msg", sent, draft).Return(true, nil)
+
+	// Act
+	msg, err := svc.SendDraft(context.Background(), newTestDraftRequest())
+
+	// Assert
+	assert.Nil(t, msg)
+	assert.Error(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestValidateDraftInstruction(t *testing.T) {
+	instruction, err := ValidateDraftInstruction("  shorter  ")
+	assert.NoError(t, err)
+	assert.Equal(t, "shorter", instruction)
+
+	_, err = ValidateDraftInstruction(" ")
+	assert.Error(t, err)
+
+	_, err = ValidateDraftInstruction(string(make([]rune, MaxDraftInstructionLength+1)))
+	assert.Error(t, err)
+}
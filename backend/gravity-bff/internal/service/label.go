@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mabidoli/gravity-bff/internal/cache"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MaxLabels is the maximum number of labels a user can have.
+const MaxLabels = 100
+
+// MaxLabelNameLength is the maximum length of a label name, in characters.
+const MaxLabelNameLength = 50
+
+// ErrInvalidLabel means a label request or a label ID in it was rejected.
+var ErrInvalidLabel = errors.New("invalid label")
+
+// ErrTooManyLabels means the user already has MaxLabels labels.
+var ErrTooManyLabels = errors.New("too many labels")
+
+// colorPattern matches a #rrggbb hex color.
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// LabelService manages labels and the labels applied to priority items.
+type LabelService struct {
+	repo   repository.LabelRepository
+	cache  cache.Cache
+	events events.Publisher
+	log    *logger.Logger
+}
+
+// NewLabelService creates a new label service.
+func NewLabelService(repo repository.LabelRepository, cache cache.Cache, publisher events.Publisher, log *logger.Logger) *LabelService {
+	return &LabelService{
+		repo:   repo,
+		cache:  cache,
+		events: publisher,
+		log:    log,
+	}
+}
+
+// ValidateLabel validates a label request and returns the label it describes.
+// The name is trimmed and the color lowercased. Errors wrap ErrInvalidLabel.
+func ValidateLabel(userID string, req model.LabelRequest) (model.Label, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return model.Label{}, fmt.Errorf("%w: name must not be empty", ErrInvalidLabel)
+	}
+	if utf8.RuneCountInString(name) > MaxLabelNameLength {
+		return model.Label{}, fmt.Errorf("%w: name must be at most %d characters", ErrInvalidLabel, MaxLabelNameLength)
+	}
+
+	label := model.Label{UserID: userID, Name: name}
+	if req.Color != nil {
+		if !colorPattern.MatchString(*req.Color) {
+			return model.Label{}, fmt.Errorf("%w: color must be a hex color like #4f46e5", ErrInvalidLabel)
+		}
+		color := strings.ToLower(*req.Color)
+		label.Color = &color
+	}
+	return label, nil
+}
+
+// ListLabels retrieves the user's labels ordered by name.
+func (s *LabelService) ListLabels(ctx context.Context, userID string) (*model.LabelsResponse, error) {
+	list, err := s.repo.ListLabels(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	return &model.LabelsResponse{Data: list}, nil
+}
+
+// GetLabel retrieves a label. Returns nil if it does not exist.
+func (s *LabelService) GetLabel(ctx context.Context, userID, labelID string) (*model.Label, error) {
+	if !isUUID(labelID) {
+		return nil, nil // Not found
+	}
+	label, err := s.repo.GetLabel(ctx, userID, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+	return label, nil
+}
+
+// CreateLabel validates and stores a new label. Returns an error wrapping
+// ErrInvalidLabel, ErrTooManyLabels or repository.ErrLabelExists when the label is rejected.
+func (s *LabelService) CreateLabel(ctx context.Context, userID string, req model.LabelRequest) (*model.Label, error) {
+	label, err := ValidateLabel(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListLabels(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	if len(existing) >= MaxLabels {
+		return nil, fmt.Errorf("%w: maximum is %d", ErrTooManyLabels, MaxLabels)
+	}
+
+	created, err := s.repo.CreateLabel(ctx, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateLabel validates and replaces a label. Items carrying the label are
+// invalidated so they show the new name and color. Returns nil if the label does not exist.
+func (s *LabelService) UpdateLabel(ctx context.Context, userID, labelID string, req model.LabelRequest) (*model.Label, error) {
+	label, err := ValidateLabel(userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if !isUUID(labelID) {
+		return nil, nil // Not found
+	}
+	label.ID = labelID
+
+	updated, err := s.repo.UpdateLabel(ctx, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+	if updated == nil {
+		return nil, nil
+	}
+
+	itemIDs, err := s.repo.ListLabeledItemIDs(ctx, userID, labelID)
+	if err != nil {
+		s.log.Warn("Failed to list items with label %s: %v", labelID, err)
+	}
+	s.invalidateItems(ctx, userID, itemIDs...)
+
+	return updated, nil
+}
+
+// DeleteLabel deletes a label and removes it from all items. Returns false if it does not exist.
+func (s *LabelService) DeleteLabel(ctx context.Context, userID, labelID string) (bool, error) {
+	if !isUUID(labelID) {
+		return false, nil
+	}
+
+	// Collect the labeled items first; the links are gone after the delete
+	itemIDs, err := s.repo.ListLabeledItemIDs(ctx, userID, labelID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list labeled items: %w", err)
+	}
+
+	deleted, err := s.repo.DeleteLabel(ctx, userID, labelID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete label: %w", err)
+	}
+	if deleted {
+		s.invalidateItems(ctx, userID, itemIDs...)
+		dropCounts(ctx, s.cache, s.log, userID)
+	}
+	return deleted, nil
+}
+
+// AddItemLabels applies labels to an item and returns the item's labels.
+// Every label must belong to the user; otherwise the error wraps ErrInvalidLabel.
+// Returns nil if the item does not exist.
+func (s *LabelService) AddItemLabels(ctx context.Context, userID, itemID string, labelIDs []string) (*model.LabelsResponse, error) {
+	ids, err := validateLabelIDs(labelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := s.repo.ListLabels(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	known := make(map[string]struct{}, len(owned))
+	for _, label := range owned {
+		known[label.ID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := known[id]; !ok {
+			return nil, fmt.Errorf("%w: unknown label %s", ErrInvalidLabel, id)
+		}
+	}
+
+	found, err := s.repo.AddItemLabels(ctx, userID, itemID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add item labels: %w", err)
+	}
+	if !found {
+		return nil, nil // Item not found
+	}
+
+	return s.itemLabelsChanged(ctx, userID, itemID)
+}
+
+// RemoveItemLabel removes a label from an item and returns the item's remaining labels.
+// Returns nil if the item does not exist or does not have the label.
+func (s *LabelService) RemoveItemLabel(ctx context.Context, userID, itemID, labelID string) (*model.LabelsResponse, error) {
+	if !isUUID(labelID) {
+		return nil, nil // Not found
+	}
+
+	removed, err := s.repo.RemoveItemLabel(ctx, userID, itemID, strings.ToLower(labelID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove item label: %w", err)
+	}
+	if !removed {
+		return nil, nil
+	}
+
+	return s.itemLabelsChanged(ctx, userID, itemID)
+}
+
+// itemLabelsChanged invalidates the item's cache entries and the counts,
+// publishes its new labels and returns them.
+func (s *LabelService) itemLabelsChanged(ctx context.Context, userID, itemID string) (*model.LabelsResponse, error) {
+	labels, err := s.repo.GetItemLabels(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item labels: %w", err)
+	}
+
+	s.invalidateItems(ctx, userID, itemID)
+	dropCounts(ctx, s.cache, s.log, userID)
+
+	evt, err := events.New(events.ItemUpdated, itemID, map[string]interface{}{
+		"labels": labels,
+	})
+	if err != nil {
+		s.log.Warn("Failed to build %s event: %v", events.ItemUpdated, err)
+	} else if err := s.events.Publish(ctx, userID, evt); err != nil {
+		s.log.Warn("Failed to publish %s event: %v", events.ItemUpdated, err)
+	}
+
+	return &model.LabelsResponse{Data: labels}, nil
+}
+
+// invalidateItems drops the user's cached streams and the given items' cache entries.
+// Failures are logged; the entries expire on their own.
+func (s *LabelService) invalidateItems(ctx context.Context, userID string, itemIDs ...string) {
+	if err := s.cache.InvalidateUserCache(ctx, userID); err != nil {
+		s.log.Warn("Failed to invalidate stream cache: %v", err)
+	}
+
+	if len(itemIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		keys = append(keys, cache.ItemKey(id))
+	}
+	if err := s.cache.Delete(ctx, keys...); err != nil {
+		s.log.Warn("Failed to invalidate item cache: %v", err)
+	}
+}
+
+// validateLabelIDs validates, lowercases and de-duplicates the label IDs of a request.
+func validateLabelIDs(labelIDs []string) ([]string, error) {
+	if len(labelIDs) == 0 {
+		return nil, fmt.Errorf("%w: labelIds must not be empty", ErrInvalidLabel)
+	}
+
+	seen := make(map[string]struct{}, len(labelIDs))
+	ids := make([]string, 0, len(labelIDs))
+	for _, id := range labelIDs {
+		if !isUUID(id) {
+			return nil, fmt.Errorf("%w: unknown label %s", ErrInvalidLabel, id)
+		}
+		id = strings.ToLower(id)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
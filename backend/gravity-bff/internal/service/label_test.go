@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockLabelRepository is a mock implementation of LabelRepository.
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) ListLabels(ctx context.Context, userID string) ([]model.Label, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetLabel(ctx context.Context, userID, labelID string) (*model.Label, error) {
+	args := m.Called(ctx, userID, labelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) CreateLabel(ctx context.Context, label model.Label) (*model.Label, error) {
+	args := m.Called(ctx, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) UpdateLabel(ctx context.Context, label model.Label) (*model.Label, error) {
+	args := m.Called(ctx, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) DeleteLabel(ctx context.Context, userID, labelID string) (bool, error) {
+	args := m.Called(ctx, userID, labelID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) ListLabeledItemIDs(ctx context.Context, userID, labelID string) ([]string, error) {
+	args := m.Called(ctx, userID, labelID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockLabelRepository) AddItemLabels(ctx context.Context, userID, itemID string, labelIDs []string) (bool, error) {
+	args := m.Called(ctx, userID, itemID, labelIDs)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) RemoveItemLabel(ctx context.Context, userID, itemID, labelID string) (bool, error) {
+	args := m.Called(ctx, userID, itemID, labelID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetItemLabels(ctx context.Context, itemID string) ([]model.Label, error) {
+	args := m.Called(ctx, itemID)
+	return args.Get(0).([]model.Label), args.Error(1)
+}
+
+const (
+	testLabelID      = "0b6f4a8e-3c1d-4e2f-9a7b-5c6d7e8f9a0b"
+	testOtherLabelID = "7d2c9e14-6a3b-4f5c-8d9e-0f1a2b3c4d5e"
+)
+
+func TestValidateLabel(t *testing.T) {
+	color := "#4F46E5"
+	label, err := ValidateLabel("user_1", model.LabelRequest{Name: "  Launch ", Color: &color})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Launch", label.Name)
+	assert.Equal(t, "#4f46e5", *label.Color)
+
+	badColor := "blue"
+	invalid := []struct {
+		name string
+		req  model.LabelRequest
+	}{
+		{"empty name", model.LabelRequest{Name: "   "}},
+		{"long name", model.LabelRequest{Name: string(make([]rune, MaxLabelNameLength+1))}},
+		{"invalid color", model.LabelRequest{Name: "Launch", Color: &badColor}},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateLabel("user_1", tt.req)
+			assert.True(t, errors.Is(err, ErrInvalidLabel))
+		})
+	}
+}
+
+func TestLabelService_CreateLabel(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	svc := NewLabelService(repo, new(MockCache), &recordingPublisher{}, logger.New())
+
+	repo.On("ListLabels", mock.Anything, "user_1").Return([]model.Label{}, nil)
+	repo.On("CreateLabel", mock.Anything, model.Label{UserID: "user_1", Name: "Launch"}).
+		Return(&model.Label{ID: testLabelID, Name: "Launch"}, nil)
+
+	// Act
+	label, err := svc.CreateLabel(context.Background(), "user_1", model.LabelRequest{Name: "Launch"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, testLabelID, label.ID)
+	repo.AssertExpectations(t)
+}
+
+func TestLabelService_CreateLabel_TooMany(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	svc := NewLabelService(repo, new(MockCache), &recordingPublisher{}, logger.New())
+
+	repo.On("ListLabels", mock.Anything, "user_1").Return(make([]model.Label, MaxLabels), nil)
+
+	// Act
+	label, err := svc.CreateLabel(context.Background(), "user_1", model.LabelRequest{Name: "Launch"})
+
+	// Assert
+	assert.Nil(t, label)
+	assert.True(t, errors.Is(err, ErrTooManyLabels))
+	repo.AssertNotCalled(t, "CreateLabel", mock.Anything, mock.Anything)
+}
+
+func TestLabelService_CreateLabel_Duplicate(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	svc := NewLabelService(repo, new(MockCache), &recordingPublisher{}, logger.New())
+
+	repo.On("ListLabels", mock.Anything, "user_1").Return([]model.Label{}, nil)
+	repo.On("CreateLabel", mock.Anything, mock.Anything).Return(nil, repository.ErrLabelExists)
+
+	// Act
+	_, err := svc.CreateLabel(context.Background(), "user_1", model.LabelRequest{Name: "Launch"})
+
+	// Assert
+	assert.True(t, errors.Is(err, repository.ErrLabelExists))
+}
+
+func TestLabelService_UpdateLabel_InvalidatesLabeledItems(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	mockCache := new(MockCache)
+	svc := NewLabelService(repo, mockCache, &recordingPublisher{}, logger.New())
+
+	repo.On("UpdateLabel", mock.Anything, model.Label{ID: testLabelID, UserID: "user_1", Name: "Q3 launch"}).
+		Return(&model.Label{ID: testLabelID, Name: "Q3 launch"}, nil)
+	repo.On("ListLabeledItemIDs", mock.Anything, "user_1", testLabelID).Return([]string{"item-1", "item-2"}, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1", "item:item-2"}).Return(nil)
+
+	// Act
+	label, err := svc.UpdateLabel(context.Background(), "user_1", testLabelID, model.LabelRequest{Name: "Q3 launch"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Q3 launch", label.Name)
+	mockCache.AssertExpectations(t)
+}
+
+func TestLabelService_DeleteLabel_NotUUID(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	svc := NewLabelService(repo, new(MockCache), &recordingPublisher{}, logger.New())
+
+	// Act
+	deleted, err := svc.DeleteLabel(context.Background(), "user_1", "label-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	repo.AssertNotCalled(t, "DeleteLabel", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLabelService_AddItemLabels(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewLabelService(repo, mockCache, publisher, logger.New())
+
+	labels := []model.Label{{ID: testLabelID, Name: "Launch"}}
+	repo.On("ListLabels", mock.Anything, "user_1").Return(labels, nil)
+	repo.On("AddItemLabels", mock.Anything, "user_1", "item-1", []string{testLabelID}).Return(true, nil)
+	repo.On("GetItemLabels", mock.Anything, "item-1").Return(labels, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"counts:user_1"}).Return(nil)
+
+	// Act: duplicate and upper-case IDs collapse to one
+	response, err := svc.AddItemLabels(context.Background(), "user_1", "item-1", []string{testLabelID, "0B6F4A8E-3C1D-4E2F-9A7B-5C6D7E8F9A0B"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, labels, response.Data)
+	assert.Len(t, publisher.published, 1)
+	assert.Equal(t, events.ItemUpdated, publisher.published[0].Type)
+	repo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestLabelService_AddItemLabels_UnknownLabel(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	svc := NewLabelService(repo, new(MockCache), &recordingPublisher{}, logger.New())
+
+	repo.On("ListLabels", mock.Anything, "user_1").Return([]model.Label{{ID: testLabelID}}, nil)
+
+	// Act
+	response, err := svc.AddItemLabels(context.Background(), "user_1", "item-1", []string{testOtherLabelID})
+
+	// Assert
+	assert.Nil(t, response)
+	assert.True(t, errors.Is(err, ErrInvalidLabel))
+	repo.AssertNotCalled(t, "AddItemLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLabelService_AddItemLabels_ItemNotFound(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	svc := NewLabelService(repo, new(MockCache), &recordingPublisher{}, logger.New())
+
+	repo.On("ListLabels", mock.Anything, "user_1").Return([]model.Label{{ID: testLabelID}}, nil)
+	repo.On("AddItemLabels", mock.Anything, "user_1", "item-9", []string{testLabelID}).Return(false, nil)
+
+	// Act
+	response, err := svc.AddItemLabels(context.Background(), "user_1", "item-9", []string{testLabelID})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	repo.AssertNotCalled(t, "GetItemLabels", mock.Anything, mock.Anything)
+}
+
+func TestLabelService_RemoveItemLabel_NotApplied(t *testing.T) {
+	// Arrange
+	repo := new(MockLabelRepository)
+	publisher := &recordingPublisher{}
+	svc := NewLabelService(repo, new(MockCache), publisher, logger.New())
+
+	repo.On("RemoveItemLabel", mock.Anything, "user_1", "item-1", testLabelID).Return(false, nil)
+
+	// Act
+	response, err := svc.RemoveItemLabel(context.Background(), "user_1", "item-1", testLabelID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	assert.Empty(t, publisher.published)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// ItemRescorer rescores items right away and announces the scores that changed.
+type ItemRescorer interface {
+	RescoreItems(ctx context.Context, itemIDs []string) error
+}
+
+// PeopleService manages the user's people directory: the participants of
+// their items, and which of them are VIPs.
+type PeopleService struct {
+	users  repository.UserRepository
+	stream repository.StreamRepository
+	scorer ItemRescorer // nil until SetScorer; VIP changes wait for the scheduled rescore
+	log    *logger.Logger
+}
+
+// NewPeopleService creates a new people service.
+func NewPeopleService(users repository.UserRepository, stream repository.StreamRepository, log *logger.Logger) *PeopleService {
+	return &PeopleService{
+		users:  users,
+		stream: stream,
+		log:    log,
+	}
+}
+
+// SetScorer makes the service rescore a person's items as soon as they are
+// marked or unmarked as a VIP.
+func (s *PeopleService) SetScorer(scorer ItemRescorer) {
+	s.scorer = scorer
+}
+
+// ListPeople retrieves a page of the user's people, the most frequent and
+// recent first.
+func (s *PeopleService) ListPeople(ctx context.Context, req model.PeopleRequest) (*model.PeopleResponse, error) {
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	people, nextCursor, err := s.users.ListPeople(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list people: %w", err)
+	}
+
+	return &model.PeopleResponse{
+		Data:       people,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetPerson retrieves a person's profile with up to PersonRecentItems of the
+// items they take part in, pinned items first and the rest newest first.
+// Returns nil if the person is not one of the user's people.
+func (s *PeopleService) GetPerson(ctx context.Context, userID, personID string) (*model.PersonResponse, error) {
+	if !isUUID(personID) {
+		return nil, nil
+	}
+
+	person, err := s.users.GetPerson(ctx, userID, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	if person == nil {
+		return nil, nil // Not found
+	}
+
+	items, err := s.recentItems(ctx, userID, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person items: %w", err)
+	}
+
+	return &model.PersonResponse{
+		Person:      *person,
+		RecentItems: items,
+	}, nil
+}
+
+// SetVIP marks or unmarks a person as a VIP and rescores the items they take
+// part in. Returns nil if the person is not one of the user's people.
+func (s *PeopleService) SetVIP(ctx context.Context, userID, personID string, vip bool) (*model.Person, error) {
+	if !isUUID(personID) {
+		return nil, nil
+	}
+
+	itemIDs, err := s.users.SetVIP(ctx, userID, personID, vip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update vip: %w", err)
+	}
+	if itemIDs == nil {
+		return nil, nil // Not found
+	}
+
+	if s.scorer != nil {
+		if err := s.scorer.RescoreItems(ctx, itemIDs); err != nil {
+			s.log.Warn("Failed to rescore items after VIP change: %v", err)
+		}
+	}
+
+	person, err := s.users.GetPerson(ctx, userID, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	return person, nil
+}
+
+// recentItems retrieves up to PersonRecentItems stream items the person takes
+// part in, pinned items first.
+func (s *PeopleService) recentItems(ctx context.Context, userID, personID string) ([]model.PriorityItem, error) {
+	filter := model.StreamFilter{ParticipantID: &personID}
+
+	items, err := s.stream.GetPinnedItems(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) >= model.PersonRecentItems {
+		return items[:model.PersonRecentItems], nil
+	}
+
+	rest, _, err := s.stream.GetStream(ctx, model.StreamRequest{
+		UserID: userID,
+		Filter: filter,
+		Sort:   model.SortRecent,
+		Limit:  model.PersonRecentItems - len(items),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(items, rest...), nil
+}
+
+// ParseVIPOnly parses the vip query parameter of a people request, false by default.
+func ParseVIPOnly(raw string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	return parseBool(raw, "vip")
+}
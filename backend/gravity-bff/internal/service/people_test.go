@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockUserRepository is a mock implementation of UserRepository.
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUsersByIDs(ctx context.Context, userIDs []string) ([]model.User, error) {
+	args := m.Called(ctx, userIDs)
+	return args.Get(0).([]model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListPeople(ctx context.Context, req model.PeopleRequest) ([]model.Person, *string, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]model.Person), args.Get(1).(*string), args.Error(2)
+}
+
+func (m *MockUserRepository) GetPerson(ctx context.Context, userID, personID string) (*model.Person, error) {
+	args := m.Called(ctx, userID, personID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Person), args.Error(1)
+}
+
+func (m *MockUserRepository) SetVIP(ctx context.Context, userID, personID string, vip bool) ([]string, error) {
+	args := m.Called(ctx, userID, personID, vip)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+const testPersonID = "8a6e0804-2bd0-4672-b79d-d97027f9071a"
+
+func TestPeopleService_ListPeople(t *testing.T) {
+	// Arrange
+	users := new(MockUserRepository)
+	svc := NewPeopleService(users, new(MockStreamRepository), logger.New())
+
+	next := "next-cursor"
+	people := []model.Person{{User: model.User{ID: testPersonID, Name: "Ada"}, ItemCount: 3, VIP: true}}
+	users.On("ListPeople", mock.Anything, model.PeopleRequest{UserID: "user-123", VIPOnly: true, Limit: 100}).
+		Return(people, &next, nil)
+
+	// Act
+	result, err := svc.ListPeople(context.Background(), model.PeopleRequest{UserID: "user-123", VIPOnly: true, Limit: 500})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, people, result.Data)
+	assert.Equal(t, &next, result.NextCursor)
+	users.AssertExpectations(t)
+}
+
+func TestPeopleService_GetPerson(t *testing.T) {
+	// Arrange
+	users := new(MockUserRepository)
+	streamRepo := new(MockStreamRepository)
+	svc := NewPeopleService(users, streamRepo, logger.New())
+
+	person := &model.Person{User: model.User{ID: testPersonID, Name: "Ada"}, ItemCount: 2, LastInteraction: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	participant := func(f model.StreamFilter) bool { return f.ParticipantID != nil && *f.ParticipantID == testPersonID }
+	users.On("GetPerson", mock.Anything, "user-123", testPersonID).Return(person, nil)
+	streamRepo.On("GetPinnedItems", mock.Anything, "user-123", mock.MatchedBy(participant)).Return([]model.PriorityItem{{ID: "item-pinned"}}, nil)
+	streamRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(req model.StreamRequest) bool {
+		return participant(req.Filter) && req.Sort == model.SortRecent && req.Limit == model.PersonRecentItems-1
+	})).Return([]model.PriorityItem{{ID: "item-email"}, {ID: "item-slack"}}, (*string)(nil), nil)
+
+	// Act
+	result, err := svc.GetPerson(context.Background(), "user-123", testPersonID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, *person, result.Person)
+	assert.Equal(t, []string{"item-pinned", "item-email", "item-slack"}, itemIDs(result.RecentItems))
+	streamRepo.AssertExpectations(t)
+}
+
+func TestPeopleService_GetPerson_NotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		personID string
+	}{
+		{"not one of the user's people", testPersonID},
+		{"not a UUID", "someone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			users := new(MockUserRepository)
+			streamRepo := new(MockStreamRepository)
+			svc := NewPeopleService(users, streamRepo, logger.New())
+			users.On("GetPerson", mock.Anything, "user-123", testPersonID).Return(nil, nil)
+
+			// Act
+			result, err := svc.GetPerson(context.Background(), "user-123", tt.personID)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Nil(t, result)
+			streamRepo.AssertNotCalled(t, "GetStream", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPeopleService_SetVIP(t *testing.T) {
+	// Arrange
+	users := new(MockUserRepository)
+	scoringRepo := new(MockScoringRepository)
+	mockCache := new(MockCache)
+	svc := NewPeopleService(users, new(MockStreamRepository), logger.New())
+	svc.SetScorer(newTestScoringService(scoringRepo, mockCache, events.NopPublisher{}))
+
+	users.On("SetVIP", mock.Anything, "user-123", testPersonID, true).Return([]string{"item-1", "item-2"}, nil)
+	scoringRepo.On("GetScoreSignals", mock.Anything, []string{"item-1", "item-2"}, testScoringNow).Return([]model.ScoreSignals{}, nil)
+	scoringRepo.On("UpdateScores", mock.Anything, []model.ItemScore{}).Return(nil)
+	users.On("GetPerson", mock.Anything, "user-123", testPersonID).Return(&model.Person{User: model.User{ID: testPersonID}, VIP: true}, nil)
+
+	// Act
+	result, err := svc.SetVIP(context.Background(), "user-123", testPersonID, true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.VIP)
+	scoringRepo.AssertExpectations(t)
+}
+
+func TestPeopleService_SetVIP_NotFound(t *testing.T) {
+	// Arrange
+	users := new(MockUserRepository)
+	svc := NewPeopleService(users, new(MockStreamRepository), logger.New())
+	users.On("SetVIP", mock.Anything, "user-123", testPersonID, false).Return([]string(nil), nil)
+
+	// Act
+	result, err := svc.SetVIP(context.Background(), "user-123", testPersonID, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	users.AssertNotCalled(t, "GetPerson", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPeopleService_SetVIP_RescoreFailureIsIgnored(t *testing.T) {
+	// Arrange
+	users := new(MockUserRepository)
+	scoringRepo := new(MockScoringRepository)
+	svc := NewPeopleService(users, new(MockStreamRepository), logger.New())
+	svc.SetScorer(newTestScoringService(scoringRepo, new(MockCache), events.NopPublisher{}))
+
+	users.On("SetVIP", mock.Anything, "user-123", testPersonID, true).Return([]string{"item-1"}, nil)
+	scoringRepo.On("GetScoreSignals", mock.Anything, mock.Anything, mock.Anything).Return([]model.ScoreSignals(nil), errors.New("db down"))
+	users.On("GetPerson", mock.Anything, "user-123", testPersonID).Return(&model.Person{User: model.User{ID: testPersonID}, VIP: true}, nil)
+
+	// Act
+	result, err := svc.SetVIP(context.Background(), "user-123", testPersonID, true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.VIP)
+}
+
+// Tests for ParseVIPOnly
+func TestParseVIPOnly(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected bool
+		hasError bool
+	}{
+		{"", false, false},
+		{"true", true, false},
+		{"false", false, false},
+		{"yes", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			vipOnly, err := ParseVIPOnly(tt.raw)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, vipOnly)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mabidoli/gravity-bff/internal/cache"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+)
+
+// MaxPins is the maximum number of items a user can pin.
+const MaxPins = 50
+
+// ErrTooManyPins means the user already has MaxPins pinned items.
+var ErrTooManyPins = errors.New("too many pinned items")
+
+// ErrInvalidPinOrder means a reorder request does not list exactly the user's pinned items.
+var ErrInvalidPinOrder = errors.New("invalid pin order")
+
+// PinItem pins an item after the user's other pinned items and returns the new pin order.
+// Pinning an item that is already pinned keeps its position.
+// Returns nil if the item does not exist, or an error wrapping ErrTooManyPins.
+func (s *StreamService) PinItem(ctx context.Context, userID, itemID string) (*model.PinsResponse, error) {
+	pinned, err := s.repo.ListPinnedItemIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned items: %w", err)
+	}
+	if len(pinned) >= MaxPins && !contains(pinned, itemID) {
+		return nil, fmt.Errorf("%w: maximum is %d", ErrTooManyPins, MaxPins)
+	}
+
+	found, err := s.repo.PinItem(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pin item: %w", err)
+	}
+	if !found {
+		return nil, nil // Not found
+	}
+
+	s.pinsChanged(ctx, userID, itemID)
+	s.publish(ctx, userID, events.ItemPinned, itemID, nil)
+
+	return s.listPins(ctx, userID)
+}
+
+// UnpinItem unpins an item and returns the remaining pin order.
+// Returns nil if the item does not exist.
+func (s *StreamService) UnpinItem(ctx context.Context, userID, itemID string) (*model.PinsResponse, error) {
+	found, err := s.repo.UnpinItem(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpin item: %w", err)
+	}
+	if !found {
+		return nil, nil // Not found
+	}
+
+	s.pinsChanged(ctx, userID, itemID)
+	s.publish(ctx, userID, events.ItemUnpinned, itemID, nil)
+
+	return s.listPins(ctx, userID)
+}
+
+// ReorderPins orders the user's pinned items as listed. The list must contain
+// every pinned item exactly once; otherwise the error wraps ErrInvalidPinOrder.
+func (s *StreamService) ReorderPins(ctx context.Context, userID string, itemIDs []string) (*model.PinsResponse, error) {
+	pinned, err := s.repo.ListPinnedItemIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned items: %w", err)
+	}
+	if err := validatePinOrder(pinned, itemIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetPinOrder(ctx, userID, itemIDs); err != nil {
+		return nil, fmt.Errorf("failed to reorder pinned items: %w", err)
+	}
+
+	s.pinsChanged(ctx, userID)
+	s.publish(ctx, userID, events.PinsReordered, "", map[string][]string{"itemIds": itemIDs})
+
+	return &model.PinsResponse{ItemIDs: itemIDs}, nil
+}
+
+// listPins returns the user's pinned item IDs in pin order.
+func (s *StreamService) listPins(ctx context.Context, userID string) (*model.PinsResponse, error) {
+	pinned, err := s.repo.ListPinnedItemIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned items: %w", err)
+	}
+	return &model.PinsResponse{ItemIDs: pinned}, nil
+}
+
+// pinsChanged moves the user's pin version forward, which retires their cached
+// stream pages, and drops the cache entries of items whose pin state changed.
+// Failures are logged; the entries expire on their own.
+func (s *StreamService) pinsChanged(ctx context.Context, userID string, itemIDs ...string) {
+	if err := s.cache.BumpPinVersion(ctx, userID); err != nil {
+		s.log.Warn("Failed to bump pin version: %v", err)
+		// Fall back to dropping the cached pages
+		if err := s.cache.InvalidateUserCache(ctx, userID); err != nil {
+			s.log.Warn("Failed to invalidate stream cache: %v", err)
+		}
+	}
+
+	if len(itemIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		keys = append(keys, cache.ItemKey(id))
+	}
+	if err := s.cache.Delete(ctx, keys...); err != nil {
+		s.log.Warn("Failed to invalidate item cache: %v", err)
+	}
+}
+
+// validatePinOrder checks that order lists every pinned item exactly once.
+func validatePinOrder(pinned, order []string) error {
+	if len(order) != len(pinned) {
+		return fmt.Errorf("%w: itemIds must list all %d pinned items", ErrInvalidPinOrder, len(pinned))
+	}
+
+	remaining := make(map[string]struct{}, len(pinned))
+	for _, id := range pinned {
+		remaining[id] = struct{}{}
+	}
+	for _, id := range order {
+		if _, ok := remaining[id]; !ok {
+			return fmt.Errorf("%w: %s is not pinned or is listed twice", ErrInvalidPinOrder, id)
+		}
+		delete(remaining, id)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+func TestStreamService_GetStream_PinnedFirstPage(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	pinned := []model.PriorityItem{{ID: "item-pinned", Pinned: true}}
+	mockCache.On("PinVersion", mock.Anything, "user-123").Return(int64(4), nil)
+	mockCache.On("GetStream", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasSuffix(key, ":recent:p4:none")
+	})).Return(nil, nil)
+	mockRepo.On("GetStream", mock.Anything, mock.Anything).Return([]model.PriorityItem{{ID: "item-1"}}, (*string)(nil), nil)
+	mockRepo.On("GetPinnedItems", mock.Anything, "user-123", model.StreamFilter{}).Return(pinned, nil)
+	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	result, err := svc.GetStream(context.Background(), model.StreamRequest{UserID: "user-123", Limit: 20})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, pinned, result.Pinned)
+	assert.Equal(t, "item-1", result.Data[0].ID)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_GetStream_PinnedOmittedOnLaterPages(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	cursor := "abc123"
+	mockCache.On("PinVersion", mock.Anything, "user-123").Return(int64(0), nil)
+	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("GetStream", mock.Anything, mock.Anything).Return([]model.PriorityItem{}, (*string)(nil), nil)
+	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	result, err := svc.GetStream(context.Background(), model.StreamRequest{UserID: "user-123", Limit: 20, Cursor: &cursor})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result.Pinned)
+	mockRepo.AssertNotCalled(t, "GetPinnedItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStreamService_GetStream_PinVersionErrorBypassesCache(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	mockCache.On("PinVersion", mock.Anything, "user-123").Return(int64(0), errors.New("redis down"))
+	mockRepo.On("GetStream", mock.Anything, mock.Anything).Return([]model.PriorityItem{}, (*string)(nil), nil)
+	mockRepo.On("GetPinnedItems", mock.Anything, "user-123", mock.Anything).Return([]model.PriorityItem{}, nil)
+
+	// Act
+	_, err := svc.GetStream(context.Background(), model.StreamRequest{UserID: "user-123", Limit: 20})
+
+	// Assert
+	assert.NoError(t, err)
+	mockCache.AssertNotCalled(t, "GetStream", mock.Anything, mock.Anything)
+	mockCache.AssertNotCalled(t, "SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStreamService_PinItem(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewStreamService(mockRepo, mockCache, publisher, newTestConfig(), logger.New())
+
+	mockRepo.On("ListPinnedItemIDs", mock.Anything, "user-123").Return([]string{"item-1"}, nil).Once()
+	mockRepo.On("PinItem", mock.Anything, "user-123", "item-2").Return(true, nil)
+	mockRepo.On("ListPinnedItemIDs", mock.Anything, "user-123").Return([]string{"item-1", "item-2"}, nil).Once()
+	mockCache.On("BumpPinVersion", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-2"}).Return(nil)
+
+	// Act
+	result, err := svc.PinItem(context.Background(), "user-123", "item-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"item-1", "item-2"}, result.ItemIDs)
+	assert.Len(t, publisher.published, 1)
+	assert.Equal(t, events.ItemPinned, publisher.published[0].Type)
+	mockCache.AssertNotCalled(t, "InvalidateUserCache", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_PinItem_TooMany(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	svc := newTestService(mockRepo, new(MockCache))
+
+	pinned := make([]string, MaxPins)
+	for i := range pinned {
+		pinned[i] = "item-pinned"
+	}
+	mockRepo.On("ListPinnedItemIDs", mock.Anything, "user-123").Return(pinned, nil)
+
+	// Act
+	result, err := svc.PinItem(context.Background(), "user-123", "item-new")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, ErrTooManyPins))
+	mockRepo.AssertNotCalled(t, "PinItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStreamService_UnpinItem_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	mockRepo.On("UnpinItem", mock.Anything, "user-123", "item-999").Return(false, nil)
+
+	// Act
+	result, err := svc.UnpinItem(context.Background(), "user-123", "item-999")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockCache.AssertNotCalled(t, "BumpPinVersion", mock.Anything, mock.Anything)
+}
+
+func TestStreamService_ReorderPins(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	mockRepo.On("ListPinnedItemIDs", mock.Anything, "user-123").Return([]string{"item-1", "item-2", "item-3"}, nil)
+	mockRepo.On("SetPinOrder", mock.Anything, "user-123", []string{"item-3", "item-1", "item-2"}).Return(nil)
+	mockCache.On("BumpPinVersion", mock.Anything, "user-123").Return(nil)
+
+	// Act
+	result, err := svc.ReorderPins(context.Background(), "user-123", []string{"item-3", "item-1", "item-2"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"item-3", "item-1", "item-2"}, result.ItemIDs)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_ReorderPins_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		order []string
+	}{
+		{"missing item", []string{"item-2", "item-1"}},
+		{"unpinned item", []string{"item-3", "item-1", "item-9"}},
+		{"duplicate item", []string{"item-1", "item-1", "item-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockStreamRepository)
+			svc := newTestService(mockRepo, new(MockCache))
+
+			mockRepo.On("ListPinnedItemIDs", mock.Anything, "user-123").Return([]string{"item-1", "item-2", "item-3"}, nil)
+
+			// Act
+			_, err := svc.ReorderPins(context.Background(), "user-123", tt.order)
+
+			// Assert
+			assert.True(t, errors.Is(err, ErrInvalidPinOrder))
+			mockRepo.AssertNotCalled(t, "SetPinOrder", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/rules"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MaxRules is the maximum number of rules a user can have.
+const MaxRules = 100
+
+// DryRunLimit is the number of most recent items a dry run evaluates.
+const DryRunLimit = 1000
+
+// ErrTooManyRules means the user already has MaxRules rules.
+var ErrTooManyRules = errors.New("too many rules")
+
+// RuleService manages triage rules and applies them to ingested items.
+type RuleService struct {
+	repo repository.RuleRepository
+	log  *logger.Logger
+}
+
+// NewRuleService creates a new rule service.
+func NewRuleService(repo repository.RuleRepository, log *logger.Logger) *RuleService {
+	return &RuleService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// ListRules retrieves the user's rules in the order they apply.
+func (s *RuleService) ListRules(ctx context.Context, userID string) (*model.RulesResponse, error) {
+	list, err := s.repo.ListRules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	return &model.RulesResponse{Data: list}, nil
+}
+
+// GetRule retrieves a rule. Returns nil if it does not exist.
+func (s *RuleService) GetRule(ctx context.Context, userID, ruleID string) (*model.Rule, error) {
+	rule, err := s.repo.GetRule(ctx, userID, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+	return rule, nil
+}
+
+// CreateRule validates and stores a new rule. Returns an error wrapping
+// rules.ErrInvalidRule or ErrTooManyRules when the rule is rejected.
+func (s *RuleService) CreateRule(ctx context.Context, userID string, req model.RuleRequest) (*model.Rule, error) {
+	rule := ruleFromRequest(userID, req)
+	if _, err := rules.Compile(rule); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListRules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	if len(existing) >= MaxRules {
+		return nil, fmt.Errorf("%w: maximum is %d", ErrTooManyRules, MaxRules)
+	}
+
+	if req.Position == nil {
+		rule.Position, err = s.repo.NextRulePosition(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to position rule: %w", err)
+		}
+	}
+
+	created, err := s.repo.CreateRule(ctx, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateRule validates and replaces a rule. Omitting the position keeps the
+// current one. Returns nil if the rule does not exist.
+func (s *RuleService) UpdateRule(ctx context.Context, userID, ruleID string, req model.RuleRequest) (*model.Rule, error) {
+	rule := ruleFromRequest(userID, req)
+	rule.ID = ruleID
+	if _, err := rules.Compile(rule); err != nil {
+		return nil, err
+	}
+
+	if req.Position == nil {
+		current, err := s.repo.GetRule(ctx, userID, ruleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rule: %w", err)
+		}
+		if current == nil {
+			return nil, nil // Not found
+		}
+		rule.Position = current.Position
+	}
+
+	updated, err := s.repo.UpdateRule(ctx, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteRule deletes a rule. Returns false if it does not exist.
+func (s *RuleService) DeleteRule(ctx context.Context, userID, ruleID string) (bool, error) {
+	deleted, err := s.repo.DeleteRule(ctx, userID, ruleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return deleted, nil
+}
+
+// DryRun reports which of the user's most recent items an unsaved rule would match.
+// Returns an error wrapping rules.ErrInvalidRule when the rule is invalid.
+func (s *RuleService) DryRun(ctx context.Context, userID string, req model.RuleRequest) (*model.RuleDryRunResponse, error) {
+	compiled, err := rules.Compile(ruleFromRequest(userID, req))
+	if err != nil {
+		return nil, err
+	}
+	return s.dryRun(ctx, userID, compiled)
+}
+
+// DryRunRule reports which of the user's most recent items a saved rule would match,
+// whether or not it is enabled. Returns nil if the rule does not exist.
+func (s *RuleService) DryRunRule(ctx context.Context, userID, ruleID string) (*model.RuleDryRunResponse, error) {
+	rule, err := s.GetRule(ctx, userID, ruleID)
+	if err != nil || rule == nil {
+		return nil, err
+	}
+	compiled, err := rules.Compile(*rule)
+	if err != nil {
+		return nil, fmt.Errorf("stored rule %s is invalid: %w", ruleID, err)
+	}
+	return s.dryRun(ctx, userID, compiled)
+}
+
+// dryRun evaluates a rule against the user's most recent items.
+func (s *RuleService) dryRun(ctx context.Context, userID string, compiled *rules.Compiled) (*model.RuleDryRunResponse, error) {
+	// Fetch one extra to report whether the scan was truncated
+	items, err := s.repo.ListRuleCandidates(ctx, userID, DryRunLimit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list items: %w", err)
+	}
+
+	response := &model.RuleDryRunResponse{Matches: []model.PriorityItem{}}
+	if len(items) > DryRunLimit {
+		items = items[:DryRunLimit]
+		response.Truncated = true
+	}
+	response.Scanned = len(items)
+
+	for _, item := range items {
+		if compiled.Matches(item) {
+			item.Messages = nil
+			response.Matches = append(response.Matches, item)
+		}
+	}
+
+	return response, nil
+}
+
+// ApplyRules evaluates the owners' enabled rules against freshly ingested items
+// and applies the resulting effects. Items are evaluated as delivered, with the
+// delivered messages. Stored rules that no longer validate are skipped.
+func (s *RuleService) ApplyRules(ctx context.Context, items []model.IngestItem, results []model.IngestResult) error {
+	compiled := make(map[string][]*rules.Compiled)
+	for i, result := range results {
+		item := items[i]
+
+		userRules, ok := compiled[item.UserID]
+		if !ok {
+			var err error
+			userRules, err = s.loadRules(ctx, item.UserID)
+			if err != nil {
+				return err
+			}
+			compiled[item.UserID] = userRules
+		}
+		if len(userRules) == 0 {
+			continue
+		}
+
+		effect := rules.Evaluate(userRules, ingestedItemView(item, result.ItemID))
+		if effect.IsEmpty() {
+			continue
+		}
+		if err := s.repo.ApplyRuleEffect(ctx, item.UserID, result.ItemID, effect); err != nil {
+			return fmt.Errorf("failed to apply rules to item %s: %w", result.ItemID, err)
+		}
+		s.log.Debug("Rules %v applied to item %s", effect.RuleIDs, result.ItemID)
+	}
+	return nil
+}
+
+// loadRules loads and compiles a user's enabled rules.
+func (s *RuleService) loadRules(ctx context.Context, userID string) ([]*rules.Compiled, error) {
+	list, err := s.repo.ListRules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+
+	compiled := make([]*rules.Compiled, 0, len(list))
+	for _, rule := range list {
+		if !rule.Enabled {
+			continue
+		}
+		c, err := rules.Compile(rule)
+		if err != nil {
+			s.log.Warn("Skipping invalid rule %s: %v", rule.ID, err)
+			continue
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// ruleFromRequest builds a rule from a request body, filling in defaults.
+func ruleFromRequest(userID string, req model.RuleRequest) model.Rule {
+	rule := model.Rule{
+		UserID:     userID,
+		Name:       strings.TrimSpace(req.Name),
+		Enabled:    true,
+		Match:      req.Match,
+		Conditions: req.Conditions,
+		Actions:    req.Actions,
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	if req.Position != nil {
+		rule.Position = *req.Position
+	}
+	if rule.Match == "" {
+		rule.Match = model.MatchAll
+	}
+	return rule
+}
+
+// ingestedItemView presents an ingested item in the shape rules evaluate.
+func ingestedItemView(item model.IngestItem, itemID string) model.PriorityItem {
+	view := model.PriorityItem{
+		ID:        itemID,
+		Title:     item.Title,
+		Source:    item.Source,
+		Priority:  item.Priority,
+		IsUnread:  item.IsUnread,
+		Snippet:   item.Snippet,
+		Timestamp: item.Timestamp,
+		Messages:  make([]model.Message, 0, len(item.Messages)),
+	}
+	for _, m := range item.Messages {
+		msg := model.Message{
+			SenderType:  m.SenderType,
+			ContentType: m.ContentType,
+			Content:     m.Content,
+			Timestamp:   m.Timestamp,
+			Attachments: m.Attachments,
+		}
+		if m.From != nil {
+			msg.SenderInfo = &model.User{Name: m.From.Name, Email: m.From.Email}
+		}
+		view.Messages = append(view.Messages, msg)
+	}
+	return view
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/rules"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockRuleRepository is a mock implementation of RuleRepository.
+type MockRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRuleRepository) ListRules(ctx context.Context, userID string) ([]model.Rule, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) GetRule(ctx context.Context, userID, ruleID string) (*model.Rule, error) {
+	args := m.Called(ctx, userID, ruleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) CreateRule(ctx context.Context, rule model.Rule) (*model.Rule, error) {
+	args := m.Called(ctx, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) UpdateRule(ctx context.Context, rule model.Rule) (*model.Rule, error) {
+	args := m.Called(ctx, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) DeleteRule(ctx context.Context, userID, ruleID string) (bool, error) {
+	args := m.Called(ctx, userID, ruleID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRuleRepository) NextRulePosition(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRuleRepository) ApplyRuleEffect(ctx context.Context, userID, itemID string, effect model.RuleEffect) error {
+	args := m.Called(ctx, userID, itemID, effect)
+	return args.Error(0)
+}
+
+func (m *MockRuleRepository) ListRuleCandidates(ctx context.Context, userID string, limit int) ([]model.PriorityItem, error) {
+	args := m.Called(ctx, userID, limit)
+	return args.Get(0).([]model.PriorityItem), args.Error(1)
+}
+
+func newTestRuleRequest() model.RuleRequest {
+	return model.RuleRequest{
+		Name:       "  Deploys are low priority ",
+		Conditions: []model.RuleCondition{{Field: model.FieldTitle, Op: model.OpContains, Value: "deploy"}},
+		Actions:    []model.RuleAction{{Type: model.ActionSetPriority, Priority: model.PriorityLow}},
+	}
+}
+
+func TestRuleService_CreateRule(t *testing.T) {
+	// Arrange
+	repo := new(MockRuleRepository)
+	svc := NewRuleService(repo, logger.New())
+
+	repo.On("ListRules", mock.Anything, "user_1").Return([]model.Rule{}, nil)
+	repo.On("NextRulePosition", mock.Anything, "user_1").Return(3, nil)
+	repo.On("CreateRule", mock.Anything, mock.MatchedBy(func(rule model.Rule) bool {
+		return rule.Name == "Deploys are low priority" && rule.Enabled && rule.Match == model.MatchAll && rule.Position == 3
+	})).Return(&model.Rule{ID: "rule-1", Position: 3}, nil)
+
+	// Act
+	rule, err := svc.CreateRule(context.Background(), "user_1", newTestRuleRequest())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "rule-1", rule.ID)
+	repo.AssertExpectations(t)
+}
+
+func TestRuleService_CreateRule_Invalid(t *testing.T) {
+	// Arrange
+	repo := new(MockRuleRepository)
+	svc := NewRuleService(repo, logger.New())
+
+	req := newTestRuleRequest()
+	req.Conditions[0].Op = model.OpMatches
+	req.Conditions[0].Value = "deploy("
+
+	// Act
+	rule, err := svc.CreateRule(context.Background(), "user_1", req)
+
+	// Assert
+	assert.Nil(t, rule)
+	assert.True(t, errors.Is(err, rules.ErrInvalidRule))
+	repo.AssertNotCalled(t, "CreateRule", mock.Anything, mock.Anything)
+}
+
+func TestRuleService_CreateRule_TooMany(t *testing.T) {
+	// Arrange
+	repo := new(MockRuleRepository)
+	svc := NewRuleService(repo, logger.New())
+
+	repo.On("ListRules", mock.Anything, "user_1").Return(make([]model.Rule, MaxRules), nil)
+
+	// Act
+	rule, err := svc.CreateRule(context.Background(), "user_1", newTestRuleRequest())
+
+	// Assert
+	assert.Nil(t, rule)
+	assert.True(t, errors.Is(err, ErrTooManyRules))
+}
+
+func TestRuleService_UpdateRule_NotFound(t *testing.T) {
+	// Arrange
+	repo := new(MockRuleRepository)
+	svc := NewRuleService(repo, logger.New())
+
+	repo.On("GetRule", mock.Anything, "user_1", "rule-9").Return(nil, nil)
+
+	// Act
+	rule, err := svc.UpdateRule(context.Background(), "user_1", "rule-9", newTestRuleRequest())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, rule)
+	repo.AssertNotCalled(t, "UpdateRule", mock.Anything, mock.Anything)
+}
+
+func TestRuleService_DryRun(t *testing.T) {
+	// Arrange
+	repo := new(MockRuleRepository)
+	svc := NewRuleService(repo, logger.New())
+
+	repo.On("ListRuleCandidates", mock.Anything, "user_1", DryRunLimit+1).Return([]model.PriorityItem{
+		{ID: "item-1", Title: "#deploys", Messages: []model.Message{{ID: "m1"}}},
+		{ID: "item-2", Title: "Quarterly planning"},
+	}, nil)
+
+	// Act
+	response, err := svc.DryRun(context.Background(), "user_1", newTestRuleRequest())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.Scanned)
+	assert.False(t, response.Truncated)
+	assert.Equal(t, 1, len(response.Matches))
+	assert.Equal(t, "item-1", response.Matches[0].ID)
+	assert.Nil(t, response.Matches[0].Messages)
+}
+
+func TestRuleService_ApplyRules(t *testing.T) {
+	// Arrange
+	repo := new(MockRuleRepository)
+	svc := NewRuleService(repo, logger.New())
+	low := model.PriorityLow
+
+	repo.On("ListRules", mock.Anything, "user_1").Return([]model.Rule{
+		{ID: "rule-1", Name: "Deploys", Enabled: true, Match: model.MatchAll,
+			Conditions: []model.RuleCondition{{Field: model.FieldTitle, Op: model.OpContains, Value: "deploy"}},
+			Actions:    []model.RuleAction{{Type: model.ActionSetPriority, Priority: model.PriorityLow}}},
+		{ID: "rule-2", Name: "Slack", Enabled: false, Match: model.MatchAll,
+			Conditions: []model.RuleCondition{{Field: model.FieldSource, Op: model.OpEq, Value: "slack"}},
+			Actions:    []model.RuleAction{{Type: model.ActionSetPriority, Priority: model.PriorityHigh}}},
+	}, nil).Once()
+	repo.On("ApplyRuleEffect", mock.Anything, "user_1", "item-1", model.RuleEffect{RuleIDs: []string{"rule-1"}, Priority: &low}).Return(nil)
+
+	items := []model.IngestItem{newTestIngestItem(), newTestIngestItem()}
+	items[1].Title = "#general"
+	results := []model.IngestResult{{ItemID: "item-1"}, {ItemID: "item-2"}}
+
+	// Act
+	err := svc.ApplyRules(context.Background(), items, results)
+
+	// Assert
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestIngestService_Ingest_AppliesRules(t *testing.T) {
+	// Arrange
+	repo := new(MockIngestRepository)
+	ruleRepo := new(MockRuleRepository)
+	mockCache := new(MockCache)
+	svc := NewIngestService(repo, ingestion.NewRegistry(), mockCache, events.NopPublisher{}, logger.New())
+	svc.SetTriager(NewRuleService(ruleRepo, logger.New()))
+
+	repo.On("UpsertItem", mock.Anything, mock.Anything).Return(&model.IngestResult{ItemID: "item-1", Created: true}, nil)
+	repo.On("GetCountedItems", mock.Anything, []string{"item-1"}).Return(map[string]model.CountedItem{}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "user_1", mock.Anything).Return(nil)
+	ruleRepo.On("ListRules", mock.Anything, "user_1").Return([]model.Rule{
+		{ID: "rule-1", Name: "Slack is read", Enabled: true, Match: model.MatchAll,
+			Conditions: []model.RuleCondition{{Field: model.FieldSource, Op: model.OpEq, Value: "slack"}},
+			Actions:    []model.RuleAction{{Type: model.ActionMarkRead}}},
+	}, nil)
+	ruleRepo.On("ApplyRuleEffect", mock.Anything, "user_1", "item-1", mock.MatchedBy(func(effect model.RuleEffect) bool {
+		return effect.Unread != nil && !*effect.Unread
+	})).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	_, err := svc.Ingest(context.Background(), []model.IngestItem{newTestIngestItem()})
+
+	// Assert
+	assert.NoError(t, err)
+	ruleRepo.AssertExpectations(t)
+}
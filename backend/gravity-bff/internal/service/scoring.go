@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mabidoli/gravity-bff/internal/cache"
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/scoring"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// minScoreChange is the smallest score change worth invalidating cached streams for.
+// Smaller drifts (mostly recency decay) show up when the cache expires.
+const minScoreChange = 0.5
+
+// ScoringService computes and stores priority scores.
+type ScoringService struct {
+	repo   repository.ScoringRepository
+	scorer *scoring.Scorer
+	cache  cache.Cache
+	events events.Publisher
+	config *config.Config
+	log    *logger.Logger
+	now    func() time.Time
+}
+
+// NewScoringService creates a new scoring service.
+func NewScoringService(
+	repo repository.ScoringRepository,
+	scorer *scoring.Scorer,
+	cache cache.Cache,
+	publisher events.Publisher,
+	cfg *config.Config,
+	log *logger.Logger,
+) *ScoringService {
+	return &ScoringService{
+		repo:   repo,
+		scorer: scorer,
+		cache:  cache,
+		events: publisher,
+		config: cfg,
+		log:    log,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ScoreItems recomputes and stores the scores of the given items.
+// Items with a priority set by a triage rule keep that priority.
+// Returns the scores that changed noticeably: a new priority bucket or a
+// score change of at least minScoreChange.
+func (s *ScoringService) ScoreItems(ctx context.Context, itemIDs []string) ([]model.ItemScore, error) {
+	if len(itemIDs) == 0 {
+		return []model.ItemScore{}, nil
+	}
+
+	now := s.now()
+	signals, err := s.repo.GetScoreSignals(ctx, itemIDs, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score signals: %w", err)
+	}
+
+	scores := make([]model.ItemScore, 0, len(signals))
+	changed := []model.ItemScore{}
+	for _, sig := range signals {
+		score := s.scorer.ScoreItem(sig, now)
+		if sig.PriorityOverride != nil {
+			score.Priority = *sig.PriorityOverride
+		}
+		score.PreviousPriority = sig.Priority
+		scores = append(scores, score)
+		if score.Priority != sig.Priority || math.Abs(score.Score-sig.Score) >= minScoreChange {
+			changed = append(changed, score)
+		}
+	}
+
+	if err := s.repo.UpdateScores(ctx, scores); err != nil {
+		return nil, fmt.Errorf("failed to store scores: %w", err)
+	}
+
+	return changed, nil
+}
+
+// RescoreItems rescores the given items right away, invalidating the cache
+// entries of those whose score changed noticeably and publishing their new scores.
+func (s *ScoringService) RescoreItems(ctx context.Context, itemIDs []string) error {
+	changed, err := s.ScoreItems(ctx, itemIDs)
+	if err != nil {
+		return err
+	}
+	s.notify(ctx, changed)
+	return nil
+}
+
+// RescoreStale rescores every item last scored more than one scoring interval ago,
+// in batches. Items whose score changed noticeably have their cache entries
+// invalidated and an item.updated event published. Returns the number of items rescored.
+func (s *ScoringService) RescoreStale(ctx context.Context) (int, error) {
+	cutoff := s.now().Add(-s.config.Scoring.Interval)
+	batchSize := s.config.Scoring.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		itemIDs, err := s.repo.ListItemsToScore(ctx, cutoff, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to list items to score: %w", err)
+		}
+		if len(itemIDs) == 0 {
+			return total, nil
+		}
+
+		changed, err := s.ScoreItems(ctx, itemIDs)
+		if err != nil {
+			return total, err
+		}
+		total += len(itemIDs)
+		s.notify(ctx, changed)
+
+		if len(itemIDs) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// notify invalidates the cache entries affected by changed scores, including the
+// counts of users whose items moved in or out of high priority, and publishes
+// the new scores. Failures are logged and otherwise ignored.
+func (s *ScoringService) notify(ctx context.Context, changed []model.ItemScore) {
+	users := make(map[string]bool) // Whether the user's high-priority counts changed
+	keys := make([]string, 0, len(changed))
+	for _, score := range changed {
+		users[score.UserID] = users[score.UserID] ||
+			(score.Priority == model.PriorityHigh) != (score.PreviousPriority == model.PriorityHigh)
+		keys = append(keys, cache.ItemKey(score.ItemID))
+	}
+
+	for userID, countsChanged := range users {
+		if err := s.cache.InvalidateUserCache(ctx, userID); err != nil {
+			s.log.Warn("Failed to invalidate stream cache: %v", err)
+		}
+		if countsChanged {
+			dropCounts(ctx, s.cache, s.log, userID)
+		}
+	}
+	if len(keys) > 0 {
+		if err := s.cache.Delete(ctx, keys...); err != nil {
+			s.log.Warn("Failed to invalidate item cache: %v", err)
+		}
+	}
+
+	for _, score := range changed {
+		evt, err := events.New(events.ItemUpdated, score.ItemID, map[string]interface{}{
+			"score":    score.Score,
+			"priority": score.Priority,
+		})
+		if err != nil {
+			s.log.Warn("Failed to build %s event: %v", events.ItemUpdated, err)
+			continue
+		}
+		if err := s.events.Publish(ctx, score.UserID, evt); err != nil {
+			s.log.Warn("Failed to publish %s event: %v", events.ItemUpdated, err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/config"
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/scoring"
+	"github.com/mabidoli/gravity-bff/pkg/logger"
+)
+
+// MockScoringRepository is a mock implementation of ScoringRepository.
+type MockScoringRepository struct {
+	mock.Mock
+}
+
+func (m *MockScoringRepository) ListItemsToScore(ctx context.Context, scoredBefore time.Time, limit int) ([]string, error) {
+	args := m.Called(ctx, scoredBefore, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockScoringRepository) GetScoreSignals(ctx context.Context, itemIDs []string, now time.Time) ([]model.ScoreSignals, error) {
+	args := m.Called(ctx, itemIDs, now)
+	return args.Get(0).([]model.ScoreSignals), args.Error(1)
+}
+
+func (m *MockScoringRepository) UpdateScores(ctx context.Context, scores []model.ItemScore) error {
+	args := m.Called(ctx, scores)
+	return args.Error(0)
+}
+
+var testScoringNow = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+func newTestScoringService(repo *MockScoringRepository, mockCache *MockCache, publisher events.Publisher) *ScoringService {
+	cfg := newTestConfig()
+	cfg.Scoring = config.ScoringConfig{Interval: 15 * time.Minute, BatchSize: 2}
+	scorer := scoring.New(scoring.DefaultWeights(), scoring.DefaultThresholds())
+	svc := NewScoringService(repo, scorer, mockCache, publisher, cfg, logger.New())
+	svc.now = func() time.Time { return testScoringNow }
+	return svc
+}
+
+func TestScoringService_ScoreItems(t *testing.T) {
+	// Arrange
+	repo := new(MockScoringRepository)
+	svc := newTestScoringService(repo, new(MockCache), events.NopPublisher{})
+
+	// item-1 becomes high priority; item-2 keeps its stored score
+	repo.On("GetScoreSignals", mock.Anything, []string{"item-1", "item-2"}, testScoringNow).Return([]model.ScoreSignals{
+		{ItemID: "item-1", UserID: "user_1", Source: model.SourceSlack, IsUnread: true, LastActivity: testScoringNow, SenderReplies: 5, Priority: model.PriorityMedium},
+		{ItemID: "item-2", UserID: "user_1", Source: model.SourceTwitter, LastActivity: testScoringNow.Add(-24 * time.Hour), Score: 13.5, Priority: model.PriorityLow},
+	}, nil)
+	repo.On("UpdateScores", mock.Anything, []model.ItemScore{
+		{ItemID: "item-1", UserID: "user_1", Score: 68, Priority: model.PriorityHigh, PreviousPriority: model.PriorityMedium},
+		{ItemID: "item-2", UserID: "user_1", Score: 13.5, Priority: model.PriorityLow, PreviousPriority: model.PriorityLow},
+	}).Return(nil)
+
+	// Act
+	changed, err := svc.ScoreItems(context.Background(), []string{"item-1", "item-2"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ItemScore{
+		{ItemID: "item-1", UserID: "user_1", Score: 68, Priority: model.PriorityHigh, PreviousPriority: model.PriorityMedium},
+	}, changed)
+	repo.AssertExpectations(t)
+}
+
+func TestScoringService_ScoreItems_PriorityOverride(t *testing.T) {
+	// Arrange
+	repo := new(MockScoringRepository)
+	svc := newTestScoringService(repo, new(MockCache), events.NopPublisher{})
+	low := model.PriorityLow
+
+	repo.On("GetScoreSignals", mock.Anything, []string{"item-1"}, testScoringNow).Return([]model.ScoreSignals{
+		{ItemID: "item-1", UserID: "user_1", Source: model.SourceSlack, IsUnread: true, LastActivity: testScoringNow, SenderReplies: 5,
+			Score: 68, Priority: model.PriorityLow, PriorityOverride: &low},
+	}, nil)
+	repo.On("UpdateScores", mock.Anything, []model.ItemScore{
+		{ItemID: "item-1", UserID: "user_1", Score: 68, Priority: model.PriorityLow, PreviousPriority: model.PriorityLow},
+	}).Return(nil)
+
+	// Act
+	changed, err := svc.ScoreItems(context.Background(), []string{"item-1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+	repo.AssertExpectations(t)
+}
+
+func TestScoringService_RescoreItems(t *testing.T) {
+	// Arrange
+	repo := new(MockScoringRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := newTestScoringService(repo, mockCache, publisher)
+
+	// A VIP raises item-1's score by 25 points
+	repo.On("GetScoreSignals", mock.Anything, []string{"item-1"}, testScoringNow).Return([]model.ScoreSignals{
+		{ItemID: "item-1", UserID: "user_1", Source: model.SourceEmail, LastActivity: testScoringNow.Add(-240 * time.Hour), VIP: true, Score: 7, Priority: model.PriorityLow},
+	}, nil)
+	repo.On("UpdateScores", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	err := svc.RescoreItems(context.Background(), []string{"item-1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(publisher.published))
+	assert.Equal(t, events.ItemUpdated, publisher.published[0].Type)
+	repo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestScoringService_RescoreStale(t *testing.T) {
+	// Arrange
+	repo := new(MockScoringRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := newTestScoringService(repo, mockCache, publisher)
+	cutoff := testScoringNow.Add(-15 * time.Minute)
+
+	// A full batch, then a partial one
+	repo.On("ListItemsToScore", mock.Anything, cutoff, 2).Return([]string{"item-1", "item-2"}, nil).Once()
+	repo.On("ListItemsToScore", mock.Anything, cutoff, 2).Return([]string{"item-3"}, nil).Once()
+	repo.On("GetScoreSignals", mock.Anything, []string{"item-1", "item-2"}, testScoringNow).Return([]model.ScoreSignals{
+		{ItemID: "item-1", UserID: "user_1", Source: model.SourceSlack, IsUnread: true, LastActivity: testScoringNow, Priority: model.PriorityLow},
+		{ItemID: "item-2", UserID: "user_1", Source: model.SourceTwitter, LastActivity: testScoringNow.Add(-24 * time.Hour), Score: 13.5, Priority: model.PriorityLow},
+	}, nil)
+	repo.On("GetScoreSignals", mock.Anything, []string{"item-3"}, testScoringNow).Return([]model.ScoreSignals{}, nil)
+	repo.On("UpdateScores", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil).Once()
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil).Once()
+
+	// Act
+	n, err := svc.RescoreStale(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 1, len(publisher.published))
+	assert.Equal(t, events.ItemUpdated, publisher.published[0].Type)
+	assert.Equal(t, "item-1", publisher.published[0].ItemID)
+	repo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestScoringService_RescoreStale_DropsCountsWhenHighPriorityChanges(t *testing.T) {
+	// Arrange
+	repo := new(MockScoringRepository)
+	mockCache := new(MockCache)
+	svc := newTestScoringService(repo, mockCache, events.NopPublisher{})
+
+	// item-1 becomes high priority
+	repo.On("ListItemsToScore", mock.Anything, mock.Anything, 2).Return([]string{"item-1"}, nil)
+	repo.On("GetScoreSignals", mock.Anything, []string{"item-1"}, testScoringNow).Return([]model.ScoreSignals{
+		{ItemID: "item-1", UserID: "user_1", Source: model.SourceSlack, IsUnread: true, LastActivity: testScoringNow, SenderReplies: 5, Priority: model.PriorityMedium},
+	}, nil)
+	repo.On("UpdateScores", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"counts:user_1"}).Return(nil)
+
+	// Act
+	_, err := svc.RescoreStale(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	mockCache.AssertExpectations(t)
+}
+
+func TestScoringService_RescoreStale_RepositoryError(t *testing.T) {
+	repo := new(MockScoringRepository)
+	svc := newTestScoringService(repo, new(MockCache), events.NopPublisher{})
+	repo.On("ListItemsToScore", mock.Anything, mock.Anything, mock.Anything).Return([]string(nil), errors.New("db down"))
+
+	_, err := svc.RescoreStale(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestIngestService_Ingest_ScoresBeforeNotifying(t *testing.T) {
+	// Arrange
+	repo := new(MockIngestRepository)
+	scoringRepo := new(MockScoringRepository)
+	mockCache := new(MockCache)
+	svc := NewIngestService(repo, ingestion.NewRegistry(), mockCache, events.NopPublisher{}, logger.New())
+	svc.SetScorer(newTestScoringService(scoringRepo, mockCache, events.NopPublisher{}))
+
+	var calls []string
+	repo.On("UpsertItem", mock.Anything, mock.Anything).Return(&model.IngestResult{ItemID: "item-1", Created: true}, nil)
+	repo.On("GetCountedItems", mock.Anything, []string{"item-1"}).Return(map[string]model.CountedItem{}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "user_1", mock.Anything).Return(nil)
+	scoringRepo.On("GetScoreSignals", mock.Anything, []string{"item-1"}, testScoringNow).Return([]model.ScoreSignals{}, nil)
+	scoringRepo.On("UpdateScores", mock.Anything, []model.ItemScore{}).
+		Run(func(mock.Arguments) { calls = append(calls, "score") }).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user_1").
+		Run(func(mock.Arguments) { calls = append(calls, "invalidate") }).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	_, err := svc.Ingest(context.Background(), []model.IngestItem{newTestIngestItem()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"score", "invalidate"}, calls)
+	scoringRepo.AssertExpectations(t)
+}
+
+func TestValidateSort(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected model.StreamSort
+		hasError bool
+	}{
+		{"", model.SortRecent, false},
+		{"recent", model.SortRecent, false},
+		{"score", model.SortScore, false},
+		{"priority", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ValidateSort(tt.input)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
@@ -3,38 +3,62 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/mabidoli/gravity-bff/internal/cache"
 	"github.com/mabidoli/gravity-bff/internal/config"
 	"github.com/mabidoli/gravity-bff/internal/domain/model"
 	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
 	"github.com/mabidoli/gravity-bff/pkg/logger"
 )
 
 // StreamService provides business logic for stream operations.
 type StreamService struct {
-	repo   repository.StreamRepository
-	cache  cache.Cache
-	config *config.Config
-	log    *logger.Logger
+	repo      repository.StreamRepository
+	scorer    ItemScorer      // nil until SetScorer; woken items wait for the scheduled rescore
+	conflicts ConflictChecker // nil until SetConflictChecker
+	cache     cache.Cache
+	events    events.Publisher
+	config    *config.Config
+	log       *logger.Logger
+	now       func() time.Time
 }
 
 // NewStreamService creates a new stream service.
 func NewStreamService(
 	repo repository.StreamRepository,
 	cache cache.Cache,
+	publisher events.Publisher,
 	cfg *config.Config,
 	log *logger.Logger,
 ) *StreamService {
 	return &StreamService{
 		repo:   repo,
 		cache:  cache,
+		events: publisher,
 		config: cfg,
 		log:    log,
+		now:    func() time.Time { return time.Now().UTC() },
 	}
 }
 
+// SetScorer makes the service rescore items as soon as they wake from a snooze.
+func (s *StreamService) SetScorer(scorer ItemScorer) {
+	s.scorer = scorer
+}
+
+// SetConflictChecker makes the service check the user's calendar for conflicts
+// when an event is rescheduled.
+func (s *StreamService) SetConflictChecker(conflicts ConflictChecker) {
+	s.conflicts = conflicts
+}
+
 // GetStream retrieves the priority stream for a user with caching.
 func (s *StreamService) GetStream(ctx context.Context, req model.StreamRequest) (*model.StreamResponse, error) {
 	// Validate and set defaults
@@ -44,24 +68,31 @@ func (s *StreamService) GetStream(ctx context.Context, req model.StreamRequest)
 	if req.Limit > 100 {
 		req.Limit = 100
 	}
-	if req.Filter == "" {
-		req.Filter = model.FilterAll
+	req.Filter = req.Filter.Normalize()
+
+	// Pages are cached per pin version; without it a cached page could show stale pins
+	pinVersion, err := s.cache.PinVersion(ctx, req.UserID)
+	useCache := err == nil
+	if err != nil {
+		s.log.Warn("Failed to get pin version, bypassing stream cache: %v", err)
 	}
 
 	// Generate cache key
-	cacheKey := cache.StreamKey(req.UserID, req.Filter, req.Cursor)
+	cacheKey := cache.StreamKey(req.UserID, req.Filter, req.Sort, pinVersion, req.Cursor)
 
 	// Try to get from cache first
-	cachedResponse, err := s.cache.GetStream(ctx, cacheKey)
-	if err != nil {
-		s.log.Warn("Cache get error: %v", err)
-		// Continue without cache
-	} else if cachedResponse != nil {
-		s.log.Debug("Cache hit for stream: %s", cacheKey)
-		return cachedResponse, nil
-	}
+	if useCache {
+		cachedResponse, err := s.cache.GetStream(ctx, cacheKey)
+		if err != nil {
+			s.log.Warn("Cache get error: %v", err)
+			// Continue without cache
+		} else if cachedResponse != nil {
+			s.log.Debug("Cache hit for stream: %s", cacheKey)
+			return cachedResponse, nil
+		}
 
-	s.log.Debug("Cache miss for stream: %s", cacheKey)
+		s.log.Debug("Cache miss for stream: %s", cacheKey)
+	}
 
 	// Fetch from repository
 	items, nextCursor, err := s.repo.GetStream(ctx, req)
@@ -74,15 +105,48 @@ func (s *StreamService) GetStream(ctx context.Context, req model.StreamRequest)
 		NextCursor: nextCursor,
 	}
 
+	// Pinned items head the first page only
+	if req.Cursor == nil || *req.Cursor == "" {
+		pinned, err := s.repo.GetPinnedItems(ctx, req.UserID, req.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pinned items: %w", err)
+		}
+		response.Pinned = pinned
+	}
+
 	// Cache the response
-	if err := s.cache.SetStream(ctx, cacheKey, response, s.config.Cache.StreamTTL); err != nil {
-		s.log.Warn("Failed to cache stream: %v", err)
-		// Continue without caching
+	if useCache {
+		if err := s.cache.SetStream(ctx, cacheKey, response, s.config.Cache.StreamTTL); err != nil {
+			s.log.Warn("Failed to cache stream: %v", err)
+			// Continue without caching
+		}
 	}
 
 	return response, nil
 }
 
+// SearchStream runs a full-text search over the user's stream.
+// Search results are not cached; queries are too varied to get useful hit rates.
+func (s *StreamService) SearchStream(ctx context.Context, req model.SearchRequest) (*model.SearchResponse, error) {
+	// Validate and set defaults
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	results, nextCursor, err := s.repo.SearchStream(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search stream: %w", err)
+	}
+
+	return &model.SearchResponse{
+		Data:       results,
+		NextCursor: nextCursor,
+	}, nil
+}
+
 // GetStreamItemDetails retrieves full details of a stream item with caching.
 func (s *StreamService) GetStreamItemDetails(ctx context.Context, req model.StreamItemRequest) (*model.PriorityItem, error) {
 	// Generate cache key
@@ -119,16 +183,590 @@ func (s *StreamService) GetStreamItemDetails(ctx context.Context, req model.Stre
 	return item, nil
 }
 
-// ValidateFilter validates the filter parameter.
+// GetMessages retrieves a page of an item's messages, going back in time from
+// the request's cursor. Pages are not cached: the latest messages are served
+// with the cached item, and older pages are rarely read twice.
+// Returns nil if the item does not exist.
+func (s *StreamService) GetMessages(ctx context.Context, req model.MessagesRequest) (*model.MessagesResponse, error) {
+	messages, nextCursor, err := s.repo.GetMessages(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	if messages == nil {
+		return nil, nil // Not found
+	}
+
+	return &model.MessagesResponse{
+		Data:       messages,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// SetReadState marks items as read or unread, invalidates the affected cache
+// entries and adjusts the cached counts.
+func (s *StreamService) SetReadState(ctx context.Context, req model.ReadStateRequest) (*model.ReadStateResponse, error) {
+	changes, err := s.repo.SetReadState(ctx, req.UserID, req.ItemIDs, req.Unread)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update read state: %w", err)
+	}
+
+	updated := make([]string, len(changes))
+	for i, change := range changes {
+		updated[i] = change.ItemID
+	}
+
+	if len(updated) > 0 {
+		s.invalidateItems(ctx, req.UserID, updated...)
+		adjustCounts(ctx, s.cache, s.log, req.UserID, changes)
+	}
+
+	for _, itemID := range updated {
+		s.publish(ctx, req.UserID, events.ItemRead, itemID, map[string]bool{"unread": req.Unread})
+	}
+
+	return &model.ReadStateResponse{
+		ItemIDs: updated,
+		Unread:  req.Unread,
+	}, nil
+}
+
+// itemStateEvents maps each item state to the event announcing a move into it.
+var itemStateEvents = map[model.ItemState]events.Type{
+	model.ItemArchived: events.ItemArchived,
+	model.ItemTrashed:  events.ItemTrashed,
+	model.ItemActive:   events.ItemRestored,
+}
+
+// SetItemState archives, trashes or restores items and invalidates the affected cache entries.
+func (s *StreamService) SetItemState(ctx context.Context, req model.ItemStateRequest) (*model.ItemStateResponse, error) {
+	eventType, ok := itemStateEvents[req.State]
+	if !ok {
+		return nil, fmt.Errorf("unknown item state: %s", req.State)
+	}
+
+	updated, err := s.repo.SetItemState(ctx, req.UserID, req.ItemIDs, req.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update item state: %w", err)
+	}
+
+	if len(updated) > 0 {
+		s.invalidateItems(ctx, req.UserID, updated...)
+		dropCounts(ctx, s.cache, s.log, req.UserID)
+	}
+
+	for _, itemID := range updated {
+		s.publish(ctx, req.UserID, eventType, itemID, nil)
+	}
+
+	return &model.ItemStateResponse{
+		ItemIDs: updated,
+		State:   req.State,
+	}, nil
+}
+
+// PurgeTrash permanently deletes items that have been in the trash for longer
+// than the configured retention, in batches. Returns the number of items deleted.
+func (s *StreamService) PurgeTrash(ctx context.Context) (int, error) {
+	cutoff := s.now().Add(-s.config.Trash.Retention)
+	batchSize := s.config.Trash.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		purged, err := s.repo.PurgeTrash(ctx, cutoff, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to purge trash: %w", err)
+		}
+		total += len(purged)
+
+		// Trashed items already left the stream; only their details may still be cached
+		if len(purged) > 0 {
+			keys := make([]string, len(purged))
+			for i, item := range purged {
+				keys[i] = cache.ItemKey(item.ItemID)
+			}
+			if err := s.cache.Delete(ctx, keys...); err != nil {
+				s.log.Warn("Failed to invalidate item cache: %v", err)
+			}
+		}
+
+		if len(purged) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// SendMessage stores a reply from the user in an item's thread.
+// Returns nil if the item does not exist.
+func (s *StreamService) SendMessage(ctx context.Context, req model.SendMessageRequest) (*model.Message, error) {
+	content, err := ValidateMessageContent(req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := model.Message{
+		SenderType:  model.SenderUser,
+		Content:     content,
+		Timestamp:   time.Now().UTC(),
+		ContentType: model.ContentText,
+	}
+
+	stored, err := s.repo.CreateMessage(ctx, req.UserID, req.ItemID, msg, Snippet(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	if stored == nil {
+		return nil, nil // Not found
+	}
+
+	s.invalidateItems(ctx, req.UserID, req.ItemID)
+	s.publish(ctx, req.UserID, events.MessageCreated, req.ItemID, stored)
+
+	return stored, nil
+}
+
+// SnoozeItem hides an item from the stream until req.Until, when the wake job
+// returns it as unread. Returns nil if the item does not exist, and an error
+// wrapping ErrInvalidSnooze if req.Until is not a valid deadline.
+func (s *StreamService) SnoozeItem(ctx context.Context, req model.SnoozeRequest) (*model.SnoozeResponse, error) {
+	until, err := ValidateSnoozeUntil(req.Until, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return s.setSnooze(ctx, req.UserID, req.ItemID, &until)
+}
+
+// UnsnoozeItem returns a snoozed item to the stream right away, leaving its
+// read state and timestamp unchanged. Returns nil if the item does not exist.
+func (s *StreamService) UnsnoozeItem(ctx context.Context, userID, itemID string) (*model.SnoozeResponse, error) {
+	return s.setSnooze(ctx, userID, itemID, nil)
+}
+
+// setSnooze updates an item's snooze and announces the change.
+func (s *StreamService) setSnooze(ctx context.Context, userID, itemID string, until *time.Time) (*model.SnoozeResponse, error) {
+	found, err := s.repo.SetSnooze(ctx, userID, itemID, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update snooze: %w", err)
+	}
+
+	if !found {
+		return nil, nil // Not found
+	}
+
+	response := &model.SnoozeResponse{ItemID: itemID, SnoozedUntil: until}
+	s.invalidateItems(ctx, userID, itemID)
+	dropCounts(ctx, s.cache, s.log, userID)
+	s.publish(ctx, userID, events.ItemSnoozed, itemID, response)
+
+	return response, nil
+}
+
+// WakeSnoozed wakes every item whose snooze has expired, in batches. Woken items
+// are marked unread, moved to the top of the stream and rescored before their
+// owners are notified. Returns the number of items woken.
+func (s *StreamService) WakeSnoozed(ctx context.Context) (int, error) {
+	batchSize := s.config.Snooze.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		woken, err := s.repo.WakeSnoozed(ctx, s.now(), batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to wake snoozed items: %w", err)
+		}
+		total += len(woken)
+
+		s.scoreWoken(ctx, woken)
+		for _, item := range woken {
+			s.invalidateItems(ctx, item.UserID, item.ItemID)
+			dropCounts(ctx, s.cache, s.log, item.UserID)
+			s.publish(ctx, item.UserID, events.ItemWoken, item.ItemID, nil)
+		}
+
+		if len(woken) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// scoreWoken rescores woken or resurfaced items. Failures are logged; the items
+// keep their previous score until the scheduled rescore picks them up.
+func (s *StreamService) scoreWoken(ctx context.Context, woken []model.ItemRef) {
+	if s.scorer == nil || len(woken) == 0 {
+		return
+	}
+
+	itemIDs := make([]string, len(woken))
+	for i, item := range woken {
+		itemIDs[i] = item.ItemID
+	}
+	if _, err := s.scorer.ScoreItems(ctx, itemIDs); err != nil {
+		s.log.Warn("Failed to score woken items: %v", err)
+	}
+}
+
+// invalidateItems drops the user's cached stream pages and the given item details.
+// Cache failures are logged and otherwise ignored; entries expire on their own TTL.
+func (s *StreamService) invalidateItems(ctx context.Context, userID string, itemIDs ...string) {
+	if err := s.cache.InvalidateUserCache(ctx, userID); err != nil {
+		s.log.Warn("Failed to invalidate stream cache: %v", err)
+	}
+
+	if len(itemIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		keys = append(keys, cache.ItemKey(id))
+	}
+	if err := s.cache.Delete(ctx, keys...); err != nil {
+		s.log.Warn("Failed to invalidate item cache: %v", err)
+	}
+}
+
+// publish sends a live update event to the user's clients.
+// Failures are logged; clients fall back to refetching on their own.
+func (s *StreamService) publish(ctx context.Context, userID string, eventType events.Type, itemID string, data interface{}) {
+	evt, err := events.New(eventType, itemID, data)
+	if err != nil {
+		s.log.Warn("Failed to build %s event: %v", eventType, err)
+		return
+	}
+	if err := s.events.Publish(ctx, userID, evt); err != nil {
+		s.log.Warn("Failed to publish %s event: %v", eventType, err)
+	}
+}
+
+// MaxBulkItems is the maximum number of items accepted by bulk mutations.
+const MaxBulkItems = 100
+
+// ValidateItemIDs validates and de-duplicates a list of item IDs for bulk mutations.
+func ValidateItemIDs(itemIDs []string) ([]string, error) {
+	if len(itemIDs) == 0 {
+		return nil, fmt.Errorf("itemIds must not be empty")
+	}
+
+	seen := make(map[string]struct{}, len(itemIDs))
+	unique := make([]string, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		if id == "" {
+			return nil, fmt.Errorf("itemIds must not contain empty values")
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	if len(unique) > MaxBulkItems {
+		return nil, fmt.Errorf("too many itemIds: %d. Maximum is %d", len(unique), MaxBulkItems)
+	}
+
+	return unique, nil
+}
+
+// MaxMessageLength is the maximum number of characters in a message body.
+const MaxMessageLength = 10000
+
+// snippetLength is the number of characters kept for an item's snippet.
+const snippetLength = 140
+
+// ValidateMessageContent trims and validates a message body.
+func ValidateMessageContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", fmt.Errorf("content must not be empty")
+	}
+	if n := utf8.RuneCountInString(content); n > MaxMessageLength {
+		return "", fmt.Errorf("content is too long: %d characters. Maximum is %d", n, MaxMessageLength)
+	}
+	return content, nil
+}
+
+// Snippet builds a single-line preview of a message body for the stream.
+func Snippet(content string) string {
+	snippet := strings.Join(strings.Fields(content), " ")
+	if utf8.RuneCountInString(snippet) <= snippetLength {
+		return snippet
+	}
+	runes := []rune(snippet)
+	return strings.TrimSpace(string(runes[:snippetLength])) + "…"
+}
+
+// MaxSnooze is the furthest ahead an item can be snoozed.
+const MaxSnooze = 365 * 24 * time.Hour
+
+// ErrInvalidSnooze means a snooze deadline is missing, in the past or too far ahead.
+var ErrInvalidSnooze = errors.New("invalid snooze")
+
+// ValidateSnoozeUntil checks that a snooze deadline is set, after now and at most MaxSnooze ahead.
+// Returns an error wrapping ErrInvalidSnooze otherwise.
+func ValidateSnoozeUntil(until *time.Time, now time.Time) (time.Time, error) {
+	if until == nil || until.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: until must not be empty", ErrInvalidSnooze)
+	}
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("%w: until must be in the future", ErrInvalidSnooze)
+	}
+	if until.Sub(now) > MaxSnooze {
+		return time.Time{}, fmt.Errorf("%w: until is too far ahead. Maximum is %d days", ErrInvalidSnooze, int(MaxSnooze.Hours()/24))
+	}
+	return until.UTC(), nil
+}
+
+// MaxSearchQueryLength is the maximum number of characters in a search query.
+const MaxSearchQueryLength = 256
+
+// ValidateSearchQuery trims and validates a free-text search query.
+func ValidateSearchQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", fmt.Errorf("q must not be empty")
+	}
+	if n := utf8.RuneCountInString(query); n > MaxSearchQueryLength {
+		return "", fmt.Errorf("q is too long: %d characters. Maximum is %d", n, MaxSearchQueryLength)
+	}
+	return query, nil
+}
+
+// ValidateFilter validates a filter preset and returns the filter it expands to.
 func ValidateFilter(filter string) (model.StreamFilter, error) {
-	switch filter {
-	case "", "all":
-		return model.FilterAll, nil
-	case "high":
-		return model.FilterHigh, nil
-	case "unread":
-		return model.FilterUnread, nil
+	switch model.FilterPreset(filter) {
+	case "", model.FilterAll:
+		return model.StreamFilter{}, nil
+	case model.FilterHigh:
+		return model.StreamFilter{Priorities: []model.Priority{model.PriorityHigh}}, nil
+	case model.FilterUnread:
+		unread := true
+		return model.StreamFilter{Unread: &unread}, nil
+	case model.FilterSnoozed:
+		return model.StreamFilter{Snoozed: true}, nil
+	case model.FilterArchived:
+		return model.StreamFilter{Archived: true}, nil
+	case model.FilterTrash:
+		return model.StreamFilter{Trashed: true}, nil
+	case model.FilterAwaitingReply:
+		return model.StreamFilter{LastSender: model.SenderUser}, nil
+	case model.FilterNeedsMyReply:
+		return model.StreamFilter{LastSender: model.SenderOther}, nil
+	default:
+		return model.StreamFilter{}, fmt.Errorf("invalid filter: %s. Valid values: all, high, unread, snoozed, archived, trash, awaiting_reply, needs_my_reply", filter)
+	}
+}
+
+// ValidateSort validates a stream sort order. An empty value means recent.
+func ValidateSort(sort string) (model.StreamSort, error) {
+	switch model.StreamSort(sort) {
+	case "", model.SortRecent:
+		return model.SortRecent, nil
+	case model.SortScore:
+		return model.SortScore, nil
 	default:
-		return "", fmt.Errorf("invalid filter: %s. Valid values: all, high, unread", filter)
+		return "", fmt.Errorf("invalid sort: %s. Valid values: recent, score", sort)
+	}
+}
+
+// StreamFilterParams holds the raw query parameters that make up a stream filter.
+// List parameters are comma-separated.
+type StreamFilterParams struct {
+	Preset         string // filter: all, high, unread, snoozed, archived, trash, awaiting_reply, needs_my_reply
+	Sources        string // source: email,slack,...
+	Priorities     string // priority: high,medium,low
+	Unread         string // unread: true, false
+	Since          string // since: RFC 3339 timestamp or YYYY-MM-DD
+	Until          string // until: RFC 3339 timestamp or YYYY-MM-DD
+	ParticipantID  string // participant: user ID
+	HasAttachments string // has_attachments: true, false
+	Labels         string // label: label IDs, matching any
+}
+
+// ParseStreamFilter validates the stream query parameters and combines them into a filter.
+// Explicit parameters take precedence over the fields set by the preset.
+func ParseStreamFilter(params StreamFilterParams) (model.StreamFilter, error) {
+	filter, err := ValidateFilter(params.Preset)
+	if err != nil {
+		return model.StreamFilter{}, err
+	}
+
+	if params.Sources != "" {
+		sources, err := parseList(params.Sources, "source", validSources)
+		if err != nil {
+			return model.StreamFilter{}, err
+		}
+		filter.Sources = make([]model.SourceType, len(sources))
+		for i, v := range sources {
+			filter.Sources[i] = model.SourceType(v)
+		}
+	}
+
+	if params.Priorities != "" {
+		priorities, err := parseList(params.Priorities, "priority", validPriorities)
+		if err != nil {
+			return model.StreamFilter{}, err
+		}
+		filter.Priorities = make([]model.Priority, len(priorities))
+		for i, v := range priorities {
+			filter.Priorities[i] = model.Priority(v)
+		}
+	}
+
+	if params.Unread != "" {
+		unread, err := parseBool(params.Unread, "unread")
+		if err != nil {
+			return model.StreamFilter{}, err
+		}
+		filter.Unread = &unread
+	}
+
+	if params.Since != "" {
+		since, err := parseTime(params.Since, "since")
+		if err != nil {
+			return model.StreamFilter{}, err
+		}
+		filter.Since = &since
+	}
+
+	if params.Until != "" {
+		until, err := parseTime(params.Until, "until")
+		if err != nil {
+			return model.StreamFilter{}, err
+		}
+		filter.Until = &until
+	}
+
+	if filter.Since != nil && filter.Until != nil && !filter.Since.Before(*filter.Until) {
+		return model.StreamFilter{}, fmt.Errorf("invalid date range: since must be before until")
+	}
+
+	if participant := strings.TrimSpace(params.ParticipantID); participant != "" {
+		filter.ParticipantID = &participant
+	}
+
+	if params.HasAttachments != "" {
+		hasAttachments, err := parseBool(params.HasAttachments, "has_attachments")
+		if err != nil {
+			return model.StreamFilter{}, err
+		}
+		filter.HasAttachments = &hasAttachments
+	}
+
+	if params.Labels != "" {
+		labels, err := parseIDs(params.Labels, "label")
+		if err != nil {
+			return model.StreamFilter{}, err
+		}
+		filter.Labels = labels
+	}
+
+	return filter.Normalize(), nil
+}
+
+// validSources lists the accepted values for the source parameter.
+var validSources = toStrings(model.SourceTypes)
+
+// validPriorities lists the accepted values for the priority parameter.
+var validPriorities = toStrings(model.Priorities)
+
+// toStrings converts a list of string-based values to plain strings.
+func toStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// parseList splits a comma-separated parameter and checks every value against the allowed set.
+func parseList(raw, name string, allowed []string) ([]string, error) {
+	var values []string
+	for _, part := range strings.Split(raw, ",") {
+		value := strings.TrimSpace(part)
+		if value == "" {
+			continue
+		}
+		if !contains(allowed, value) {
+			return nil, fmt.Errorf("invalid %s: %s. Valid values: %s", name, value, strings.Join(allowed, ", "))
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("invalid %s: at least one value is required", name)
+	}
+	return values, nil
+}
+
+// uuidPattern matches the canonical UUID form used for database IDs.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isUUID reports whether id is a UUID.
+func isUUID(id string) bool {
+	return uuidPattern.MatchString(id)
+}
+
+// parseIDs splits a comma-separated parameter of UUIDs, lowercasing each.
+func parseIDs(raw, name string) ([]string, error) {
+	var values []string
+	for _, part := range strings.Split(raw, ",") {
+		value := strings.TrimSpace(part)
+		if value == "" {
+			continue
+		}
+		if !isUUID(value) {
+			return nil, fmt.Errorf("invalid %s: %s", name, value)
+		}
+		values = append(values, strings.ToLower(value))
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("invalid %s: at least one value is required", name)
+	}
+	return values, nil
+}
+
+// parseBool parses a true/false query parameter.
+func parseBool(raw, name string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid %s: %s. Valid values: true, false", name, raw)
+	}
+}
+
+// parseTime parses an RFC 3339 timestamp or a YYYY-MM-DD date (midnight UTC).
+func parseTime(raw, name string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s: %s. Use an RFC 3339 timestamp or YYYY-MM-DD", name, raw)
+}
+
+// contains reports whether value is in values.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
+	return false
 }
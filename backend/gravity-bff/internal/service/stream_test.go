@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +13,8 @@ import (
 
 	"github.com/mabidoli/gravity-bff/internal/config"
 	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/domain/repository"
+	"github.com/mabidoli/gravity-bff/internal/events"
 	"github.com/mabidoli/gravity-bff/pkg/logger"
 )
 
@@ -28,6 +32,15 @@ func (m *MockStreamRepository) GetStream(ctx context.Context, req model.StreamRe
 	return items.([]model.PriorityItem), args.Get(1).(*string), args.Error(2)
 }
 
+func (m *MockStreamRepository) SearchStream(ctx context.Context, req model.SearchRequest) ([]model.SearchResult, *string, error) {
+	args := m.Called(ctx, req)
+	results := args.Get(0)
+	if results == nil {
+		return nil, args.Get(1).(*string), args.Error(2)
+	}
+	return results.([]model.SearchResult), args.Get(1).(*string), args.Error(2)
+}
+
 func (m *MockStreamRepository) GetStreamItemByID(ctx context.Context, userID, itemID string) (*model.PriorityItem, error) {
 	args := m.Called(ctx, userID, itemID)
 	item := args.Get(0)
@@ -42,9 +55,122 @@ func (m *MockStreamRepository) GetParticipantsByItemID(ctx context.Context, item
 	return args.Get(0).([]model.User), args.Error(1)
 }
 
-func (m *MockStreamRepository) GetMessagesByItemID(ctx context.Context, itemID string) ([]model.Message, error) {
-	args := m.Called(ctx, itemID)
-	return args.Get(0).([]model.Message), args.Error(1)
+func (m *MockStreamRepository) GetParticipantsByItemIDs(ctx context.Context, itemIDs []string) (map[string][]model.User, error) {
+	args := m.Called(ctx, itemIDs)
+	return args.Get(0).(map[string][]model.User), args.Error(1)
+}
+
+func (m *MockStreamRepository) GetMessages(ctx context.Context, req model.MessagesRequest) ([]model.Message, *string, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]model.Message), args.Get(1).(*string), args.Error(2)
+}
+
+func (m *MockStreamRepository) SetReadState(ctx context.Context, userID string, itemIDs []string, unread bool) ([]model.CountChange, error) {
+	args := m.Called(ctx, userID, itemIDs, unread)
+	changes := args.Get(0)
+	if changes == nil {
+		return nil, args.Error(1)
+	}
+	return changes.([]model.CountChange), args.Error(1)
+}
+
+func (m *MockStreamRepository) GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CountsResponse), args.Error(1)
+}
+
+func (m *MockStreamRepository) GetChanges(ctx context.Context, userID string, since int64, limit int) ([]model.ItemChange, error) {
+	args := m.Called(ctx, userID, since, limit)
+	return args.Get(0).([]model.ItemChange), args.Error(1)
+}
+
+func (m *MockStreamRepository) CreateMessage(ctx context.Context, userID, itemID string, msg model.Message, snippet string) (*model.Message, error) {
+	args := m.Called(ctx, userID, itemID, msg, snippet)
+	stored := args.Get(0)
+	if stored == nil {
+		return nil, args.Error(1)
+	}
+	return stored.(*model.Message), args.Error(1)
+}
+
+func (m *MockStreamRepository) UpdateEvent(ctx context.Context, userID, itemID, messageID string, change repository.EventChange) (*model.EventUpdateResponse, error) {
+	args := m.Called(ctx, userID, itemID, messageID, change)
+	event, ok := args.Get(0).(*model.CalendarEvent)
+	if !ok || event == nil {
+		return nil, args.Error(1)
+	}
+	// Apply the change to a copy of the stubbed event, as the repository does
+	updated := *event
+	note, _, err := change(&updated)
+	if err != nil {
+		return nil, err
+	}
+	note.ID = "msg-note"
+	return &model.EventUpdateResponse{Event: updated, Message: note}, nil
+}
+
+func (m *MockStreamRepository) SetSnooze(ctx context.Context, userID, itemID string, until *time.Time) (bool, error) {
+	args := m.Called(ctx, userID, itemID, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStreamRepository) WakeSnoozed(ctx context.Context, now time.Time, limit int) ([]model.ItemRef, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]model.ItemRef), args.Error(1)
+}
+
+func (m *MockStreamRepository) SetFollowUp(ctx context.Context, userID, itemID string, at *time.Time) (*model.FollowUpResponse, error) {
+	args := m.Called(ctx, userID, itemID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.FollowUpResponse), args.Error(1)
+}
+
+func (m *MockStreamRepository) ResurfaceFollowUps(ctx context.Context, now time.Time, limit int) ([]model.ItemRef, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]model.ItemRef), args.Error(1)
+}
+
+func (m *MockStreamRepository) SetItemState(ctx context.Context, userID string, itemIDs []string, state model.ItemState) ([]string, error) {
+	args := m.Called(ctx, userID, itemIDs, state)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStreamRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) ([]model.ItemRef, error) {
+	args := m.Called(ctx, deletedBefore, limit)
+	return args.Get(0).([]model.ItemRef), args.Error(1)
+}
+
+func (m *MockStreamRepository) GetPinnedItems(ctx context.Context, userID string, filter model.StreamFilter) ([]model.PriorityItem, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).([]model.PriorityItem), args.Error(1)
+}
+
+func (m *MockStreamRepository) ListPinnedItemIDs(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStreamRepository) PinItem(ctx context.Context, userID, itemID string) (bool, error) {
+	args := m.Called(ctx, userID, itemID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStreamRepository) UnpinItem(ctx context.Context, userID, itemID string) (bool, error) {
+	args := m.Called(ctx, userID, itemID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStreamRepository) SetPinOrder(ctx context.Context, userID string, itemIDs []string) error {
+	args := m.Called(ctx, userID, itemIDs)
+	return args.Error(0)
 }
 
 // MockCache is a mock implementation of Cache.
@@ -85,6 +211,52 @@ func (m *MockCache) Delete(ctx context.Context, keys ...string) error {
 	return args.Error(0)
 }
 
+func (m *MockCache) InvalidateUserCache(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockCache) PinVersion(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCache) BumpPinVersion(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockCache) GetCounts(ctx context.Context, userID string) (*model.CountsResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CountsResponse), args.Error(1)
+}
+
+func (m *MockCache) SetCounts(ctx context.Context, userID string, counts *model.CountsResponse, ttl time.Duration) error {
+	args := m.Called(ctx, userID, counts, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCache) GetBriefing(ctx context.Context, key string) (*model.BriefingResponse, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BriefingResponse), args.Error(1)
+}
+
+func (m *MockCache) SetBriefing(ctx context.Context, key string, briefing *model.BriefingResponse, ttl time.Duration) error {
+	args := m.Called(ctx, key, briefing, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCache) AdjustCounts(ctx context.Context, userID string, delta *model.CountsResponse) error {
+	args := m.Called(ctx, userID, delta)
+	return args.Error(0)
+}
+
 func (m *MockCache) Ping(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -94,14 +266,26 @@ func (m *MockCache) Ping(ctx context.Context) error {
 func newTestConfig() *config.Config {
 	return &config.Config{
 		Cache: config.CacheConfig{
-			StreamTTL: 2 * time.Minute,
-			ItemTTL:   5 * time.Minute,
+			StreamTTL:   2 * time.Minute,
+			ItemTTL:     5 * time.Minute,
+			CountsTTL:   10 * time.Minute,
+			BriefingTTL: 5 * time.Minute,
 		},
 	}
 }
 
 func newTestService(repo *MockStreamRepository, cache *MockCache) *StreamService {
-	return NewStreamService(repo, cache, newTestConfig(), logger.New())
+	return NewStreamService(repo, cache, events.NopPublisher{}, newTestConfig(), logger.New())
+}
+
+// recordingPublisher captures published events for assertions.
+type recordingPublisher struct {
+	published []events.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, userID string, evt events.Event) error {
+	p.published = append(p.published, evt)
+	return nil
 }
 
 // Tests for GetStream
@@ -122,11 +306,11 @@ func TestStreamService_GetStream_CacheHit(t *testing.T) {
 
 	req := model.StreamRequest{
 		UserID: "user-123",
-		Filter: model.FilterAll,
 		Limit:  20,
 	}
 
 	// Cache returns data (hit)
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
 	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(expectedResponse, nil)
 
 	// Act
@@ -155,15 +339,16 @@ func TestStreamService_GetStream_CacheMiss(t *testing.T) {
 
 	req := model.StreamRequest{
 		UserID: "user-123",
-		Filter: model.FilterAll,
 		Limit:  20,
 	}
 
 	// Cache miss
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
 	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
 	// Repository returns data
 	mockRepo.On("GetStream", mock.Anything, req).Return(expectedItems, (*string)(nil), nil)
 	// Cache set
+	mockRepo.On("GetPinnedItems", mock.Anything, mock.Anything, mock.Anything).Return([]model.PriorityItem{}, nil)
 	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
@@ -187,11 +372,11 @@ func TestStreamService_GetStream_RepositoryError(t *testing.T) {
 
 	req := model.StreamRequest{
 		UserID: "user-123",
-		Filter: model.FilterAll,
 		Limit:  20,
 	}
 
 	// Cache miss
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
 	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
 	// Repository returns error
 	mockRepo.On("GetStream", mock.Anything, req).Return(nil, (*string)(nil), errors.New("database error"))
@@ -213,14 +398,15 @@ func TestStreamService_GetStream_DefaultLimit(t *testing.T) {
 
 	req := model.StreamRequest{
 		UserID: "user-123",
-		Filter: model.FilterAll,
 		Limit:  0, // Should default to 20
 	}
 
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
 	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
 	mockRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(r model.StreamRequest) bool {
 		return r.Limit == 20
 	})).Return([]model.PriorityItem{}, (*string)(nil), nil)
+	mockRepo.On("GetPinnedItems", mock.Anything, mock.Anything, mock.Anything).Return([]model.PriorityItem{}, nil)
 	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
@@ -239,14 +425,15 @@ func TestStreamService_GetStream_MaxLimit(t *testing.T) {
 
 	req := model.StreamRequest{
 		UserID: "user-123",
-		Filter: model.FilterAll,
 		Limit:  500, // Should be capped to 100
 	}
 
+	mockCache.On("PinVersion", mock.Anything, mock.Anything).Return(int64(0), nil)
 	mockCache.On("GetStream", mock.Anything, mock.Anything).Return(nil, nil)
 	mockRepo.On("GetStream", mock.Anything, mock.MatchedBy(func(r model.StreamRequest) bool {
 		return r.Limit == 100
 	})).Return([]model.PriorityItem{}, (*string)(nil), nil)
+	mockRepo.On("GetPinnedItems", mock.Anything, mock.Anything, mock.Anything).Return([]model.PriorityItem{}, nil)
 	mockCache.On("SetStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
@@ -257,6 +444,32 @@ func TestStreamService_GetStream_MaxLimit(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// Tests for SearchStream
+func TestStreamService_SearchStream_DefaultLimit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	req := model.SearchRequest{UserID: "user-123", Query: "budget"}
+
+	mockRepo.On("SearchStream", mock.Anything, mock.MatchedBy(func(r model.SearchRequest) bool {
+		return r.Limit == 20 && r.Query == "budget"
+	})).Return([]model.SearchResult{{PriorityItem: model.PriorityItem{ID: "item-1"}}}, (*string)(nil), nil)
+
+	// Act
+	result, err := svc.SearchStream(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Data))
+	assert.Nil(t, result.NextCursor)
+
+	// Search results are never cached
+	mockCache.AssertNotCalled(t, "GetStream", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
 // Tests for GetStreamItemDetails
 func TestStreamService_GetStreamItemDetails_CacheHit(t *testing.T) {
 	// Arrange
@@ -351,20 +564,62 @@ func TestStreamService_GetStreamItemDetails_NotFound(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestStreamService_GetMessages(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	req := model.MessagesRequest{UserID: "user-123", ItemID: "item-1", Limit: 50}
+	nextCursor := "cursor-1"
+	mockRepo.On("GetMessages", mock.Anything, req).Return([]model.Message{{ID: "msg-1"}}, &nextCursor, nil)
+
+	// Act
+	result, err := svc.GetMessages(context.Background(), req)
+
+	// Assert: pages are read straight from the repository
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Message{{ID: "msg-1"}}, result.Data)
+	assert.Same(t, &nextCursor, result.NextCursor)
+	mockCache.AssertNotCalled(t, "GetStreamItem", mock.Anything, mock.Anything)
+}
+
+func TestStreamService_GetMessages_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	svc := newTestService(mockRepo, new(MockCache))
+
+	mockRepo.On("GetMessages", mock.Anything, mock.Anything).Return(nil, nil, nil)
+
+	// Act
+	result, err := svc.GetMessages(context.Background(), model.MessagesRequest{UserID: "user-123", ItemID: "item-9"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
 // Tests for ValidateFilter
 func TestValidateFilter(t *testing.T) {
+	unread := true
+
 	tests := []struct {
 		name     string
 		input    string
 		expected model.StreamFilter
 		hasError bool
 	}{
-		{"empty string defaults to all", "", model.FilterAll, false},
-		{"all filter", "all", model.FilterAll, false},
-		{"high filter", "high", model.FilterHigh, false},
-		{"unread filter", "unread", model.FilterUnread, false},
-		{"invalid filter", "invalid", "", true},
-		{"uppercase invalid", "HIGH", "", true},
+		{"empty string defaults to all", "", model.StreamFilter{}, false},
+		{"all filter", "all", model.StreamFilter{}, false},
+		{"high filter", "high", model.StreamFilter{Priorities: []model.Priority{model.PriorityHigh}}, false},
+		{"unread filter", "unread", model.StreamFilter{Unread: &unread}, false},
+		{"snoozed filter", "snoozed", model.StreamFilter{Snoozed: true}, false},
+		{"archived filter", "archived", model.StreamFilter{Archived: true}, false},
+		{"trash filter", "trash", model.StreamFilter{Trashed: true}, false},
+		{"awaiting reply filter", "awaiting_reply", model.StreamFilter{LastSender: model.SenderUser}, false},
+		{"needs my reply filter", "needs_my_reply", model.StreamFilter{LastSender: model.SenderOther}, false},
+		{"invalid filter", "invalid", model.StreamFilter{}, true},
+		{"uppercase invalid", "HIGH", model.StreamFilter{}, true},
 	}
 
 	for _, tt := range tests {
@@ -380,3 +635,550 @@ func TestValidateFilter(t *testing.T) {
 		})
 	}
 }
+
+// Tests for ParseStreamFilter
+func TestParseStreamFilter(t *testing.T) {
+	t.Run("combines dimensions", func(t *testing.T) {
+		result, err := ParseStreamFilter(StreamFilterParams{
+			Sources:        "slack, email",
+			Priorities:     "high,medium",
+			Unread:         "true",
+			Since:          "2026-01-05",
+			Until:          "2026-01-12T00:00:00Z",
+			ParticipantID:  "user-42",
+			HasAttachments: "false",
+			Labels:         "7d2c9e14-6a3b-4f5c-8d9e-0f1a2b3c4d5e, 0B6F4A8E-3C1D-4E2F-9A7B-5C6D7E8F9A0B",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []model.SourceType{model.SourceEmail, model.SourceSlack}, result.Sources)
+		assert.Equal(t, []model.Priority{model.PriorityHigh, model.PriorityMedium}, result.Priorities)
+		assert.True(t, *result.Unread)
+		assert.Equal(t, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), *result.Since)
+		assert.Equal(t, time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), *result.Until)
+		assert.Equal(t, "user-42", *result.ParticipantID)
+		assert.False(t, *result.HasAttachments)
+		assert.Equal(t, []string{"0b6f4a8e-3c1d-4e2f-9a7b-5c6d7e8f9a0b", "7d2c9e14-6a3b-4f5c-8d9e-0f1a2b3c4d5e"}, result.Labels)
+	})
+
+	t.Run("explicit parameters override preset", func(t *testing.T) {
+		result, err := ParseStreamFilter(StreamFilterParams{
+			Preset:     "high",
+			Priorities: "low",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []model.Priority{model.PriorityLow}, result.Priorities)
+	})
+
+	invalid := []struct {
+		name   string
+		params StreamFilterParams
+	}{
+		{"invalid preset", StreamFilterParams{Preset: "starred"}},
+		{"invalid source", StreamFilterParams{Sources: "email,fax"}},
+		{"invalid priority", StreamFilterParams{Priorities: "urgent"}},
+		{"empty list", StreamFilterParams{Sources: " , "}},
+		{"invalid unread", StreamFilterParams{Unread: "maybe"}},
+		{"invalid since", StreamFilterParams{Since: "last week"}},
+		{"invalid label", StreamFilterParams{Labels: "launch"}},
+		{"inverted range", StreamFilterParams{Since: "2026-01-12", Until: "2026-01-05"}},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStreamFilter(tt.params)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// Tests for GetCounts
+func TestStreamService_GetCounts_CacheMiss(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	counts := model.NewCountsResponse()
+	counts.Add(model.CountedItem{Source: model.SourceEmail, Unread: true}, 1)
+	mockCache.On("GetCounts", mock.Anything, "user-123").Return(nil, nil)
+	mockRepo.On("GetCounts", mock.Anything, "user-123").Return(counts, nil)
+	mockCache.On("SetCounts", mock.Anything, "user-123", counts, 10*time.Minute).Return(nil)
+
+	// Act
+	result, err := svc.GetCounts(context.Background(), "user-123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Unread)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_GetCounts_CacheError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	counts := model.NewCountsResponse()
+	mockCache.On("GetCounts", mock.Anything, "user-123").Return(nil, errors.New("redis down"))
+	mockRepo.On("GetCounts", mock.Anything, "user-123").Return(counts, nil)
+	mockCache.On("SetCounts", mock.Anything, "user-123", counts, mock.Anything).Return(errors.New("redis down"))
+
+	// Act
+	result, err := svc.GetCounts(context.Background(), "user-123")
+
+	// Assert: the database is the fallback
+	assert.NoError(t, err)
+	assert.Same(t, counts, result)
+}
+
+func TestStreamService_SetReadState_AdjustFailureDropsCounts(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	mockRepo.On("SetReadState", mock.Anything, "user-123", []string{"item-1"}, true).
+		Return([]model.CountChange{{ItemID: "item-1"}}, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+	mockCache.On("AdjustCounts", mock.Anything, "user-123", mock.Anything).Return(errors.New("redis down"))
+	mockCache.On("Delete", mock.Anything, []string{"counts:user-123"}).Return(nil)
+
+	// Act
+	_, err := svc.SetReadState(context.Background(), model.ReadStateRequest{UserID: "user-123", ItemIDs: []string{"item-1"}, Unread: true})
+
+	// Assert
+	assert.NoError(t, err)
+	mockCache.AssertExpectations(t)
+}
+
+// Tests for SetReadState
+func TestStreamService_SetReadState_InvalidatesCache(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewStreamService(mockRepo, mockCache, publisher, newTestConfig(), logger.New())
+
+	req := model.ReadStateRequest{
+		UserID:  "user-123",
+		ItemIDs: []string{"item-1", "item-2"},
+		Unread:  false,
+	}
+
+	before := model.CountedItem{Source: model.SourceSlack, Unread: true, High: true, LabelIDs: []string{"label-1"}}
+	after := before
+	after.Unread = false
+	mockRepo.On("SetReadState", mock.Anything, "user-123", []string{"item-1", "item-2"}, false).
+		Return([]model.CountChange{{ItemID: "item-1", Before: &before, After: &after}}, nil)
+	mockCache.On("AdjustCounts", mock.Anything, "user-123", &model.CountsResponse{
+		StreamCounts: model.StreamCounts{Unread: -1},
+		Sources:      map[model.SourceType]model.StreamCounts{model.SourceSlack: {Unread: -1}},
+		Labels:       map[string]model.StreamCounts{"label-1": {Unread: -1}},
+	}).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	result, err := svc.SetReadState(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"item-1"}, result.ItemIDs)
+	assert.False(t, result.Unread)
+
+	// One item.read event per updated item
+	assert.Equal(t, 1, len(publisher.published))
+	assert.Equal(t, events.ItemRead, publisher.published[0].Type)
+	assert.Equal(t, "item-1", publisher.published[0].ItemID)
+	assert.JSONEq(t, `{"unread":false}`, string(publisher.published[0].Data))
+
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_SetReadState_NothingUpdated(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	req := model.ReadStateRequest{
+		UserID:  "user-123",
+		ItemIDs: []string{"missing"},
+		Unread:  true,
+	}
+
+	mockRepo.On("SetReadState", mock.Anything, "user-123", []string{"missing"}, true).
+		Return([]model.CountChange{}, nil)
+
+	// Act
+	result, err := svc.SetReadState(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.ItemIDs)
+
+	// Cache should be left alone when nothing changed
+	mockCache.AssertNotCalled(t, "InvalidateUserCache", mock.Anything, mock.Anything)
+	mockCache.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestStreamService_SetReadState_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	req := model.ReadStateRequest{UserID: "user-123", ItemIDs: []string{"item-1"}}
+
+	mockRepo.On("SetReadState", mock.Anything, "user-123", []string{"item-1"}, false).
+		Return(nil, errors.New("database error"))
+
+	// Act
+	result, err := svc.SetReadState(context.Background(), req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "database error")
+}
+
+// Tests for SendMessage
+func TestStreamService_SendMessage_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewStreamService(mockRepo, mockCache, publisher, newTestConfig(), logger.New())
+
+	req := model.SendMessageRequest{
+		UserID:  "user-123",
+		ItemID:  "item-1",
+		Content: "Sounds good, see you then",
+	}
+
+	mockRepo.On("CreateMessage", mock.Anything, "user-123", "item-1", mock.MatchedBy(func(msg model.Message) bool {
+		return msg.SenderType == model.SenderUser &&
+			msg.ContentType == model.ContentText &&
+			msg.Content == "Sounds good, see you then" &&
+			!msg.Timestamp.IsZero()
+	}), "Sounds good, see you then").Return(&model.Message{ID: "msg-1", Content: "Sounds good, see you then"}, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+
+	// Act
+	result, err := svc.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", result.ID)
+
+	assert.Equal(t, 1, len(publisher.published))
+	assert.Equal(t, events.MessageCreated, publisher.published[0].Type)
+	assert.Equal(t, "item-1", publisher.published[0].ItemID)
+
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_SendMessage_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	req := model.SendMessageRequest{UserID: "user-123", ItemID: "missing", Content: "Hi"}
+
+	mockRepo.On("CreateMessage", mock.Anything, "user-123", "missing", mock.Anything, "Hi").Return(nil, nil)
+
+	// Act
+	result, err := svc.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockCache.AssertNotCalled(t, "InvalidateUserCache", mock.Anything, mock.Anything)
+}
+
+// Tests for ValidateMessageContent
+func TestValidateMessageContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		hasError bool
+	}{
+		{"plain text", "Hello", "Hello", false},
+		{"trims whitespace", "  Hello \n", "Hello", false},
+		{"empty", "", "", true},
+		{"whitespace only", " \t\n ", "", true},
+		{"too long", strings.Repeat("a", MaxMessageLength+1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateMessageContent(tt.input)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, "Hello there", Snippet("Hello\n  there"))
+
+	long := Snippet(strings.Repeat("word ", 100))
+	assert.True(t, strings.HasSuffix(long, "…"))
+	assert.LessOrEqual(t, len([]rune(long)), snippetLength+1)
+}
+
+// Tests for ValidateItemIDs
+func TestValidateItemIDs(t *testing.T) {
+	tooMany := make([]string, MaxBulkItems+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("item-%d", i)
+	}
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+		hasError bool
+	}{
+		{"single item", []string{"item-1"}, []string{"item-1"}, false},
+		{"duplicates removed", []string{"item-1", "item-2", "item-1"}, []string{"item-1", "item-2"}, false},
+		{"empty list", []string{}, nil, true},
+		{"empty value", []string{"item-1", ""}, nil, true},
+		{"too many items", tooMany, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateItemIDs(tt.input)
+
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+// Tests for snoozing
+var testSnoozeNow = time.Date(2026, 3, 6, 17, 0, 0, 0, time.UTC)
+
+func TestStreamService_SnoozeItem(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewStreamService(mockRepo, mockCache, publisher, newTestConfig(), logger.New())
+	svc.now = func() time.Time { return testSnoozeNow }
+
+	monday := time.Date(2026, 3, 9, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	mondayUTC := monday.UTC()
+	mockRepo.On("SetSnooze", mock.Anything, "user-123", "item-1", &mondayUTC).Return(true, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"counts:user-123"}).Return(nil)
+
+	// Act
+	result, err := svc.SnoozeItem(context.Background(), model.SnoozeRequest{UserID: "user-123", ItemID: "item-1", Until: &monday})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "item-1", result.ItemID)
+	assert.Equal(t, mondayUTC, *result.SnoozedUntil)
+	assert.Equal(t, 1, len(publisher.published))
+	assert.Equal(t, events.ItemSnoozed, publisher.published[0].Type)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_SnoozeItem_Invalid(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	svc := NewStreamService(mockRepo, new(MockCache), events.NopPublisher{}, newTestConfig(), logger.New())
+	svc.now = func() time.Time { return testSnoozeNow }
+
+	// Act
+	result, err := svc.SnoozeItem(context.Background(), model.SnoozeRequest{UserID: "user-123", ItemID: "item-1", Until: &testSnoozeNow})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidSnooze)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "SetSnooze", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStreamService_UnsnoozeItem_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	svc := newTestService(mockRepo, mockCache)
+
+	mockRepo.On("SetSnooze", mock.Anything, "user-123", "nonexistent", (*time.Time)(nil)).Return(false, nil)
+
+	// Act
+	result, err := svc.UnsnoozeItem(context.Background(), "user-123", "nonexistent")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockCache.AssertNotCalled(t, "InvalidateUserCache", mock.Anything, mock.Anything)
+}
+
+func TestStreamService_WakeSnoozed(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	scoringRepo := new(MockScoringRepository)
+	publisher := &recordingPublisher{}
+	cfg := newTestConfig()
+	cfg.Snooze = config.SnoozeConfig{WakeInterval: time.Minute, BatchSize: 2}
+	svc := NewStreamService(mockRepo, mockCache, publisher, cfg, logger.New())
+	svc.now = func() time.Time { return testSnoozeNow }
+	svc.SetScorer(newTestScoringService(scoringRepo, mockCache, events.NopPublisher{}))
+
+	// A full batch means there may be more due items, so a second query runs
+	mockRepo.On("WakeSnoozed", mock.Anything, testSnoozeNow, 2).Return([]model.ItemRef{
+		{ItemID: "item-1", UserID: "user-1"},
+		{ItemID: "item-2", UserID: "user-2"},
+	}, nil).Once()
+	mockRepo.On("WakeSnoozed", mock.Anything, testSnoozeNow, 2).Return([]model.ItemRef{
+		{ItemID: "item-3", UserID: "user-1"},
+	}, nil).Once()
+	scoringRepo.On("GetScoreSignals", mock.Anything, mock.Anything, testScoringNow).Return([]model.ScoreSignals{}, nil)
+	scoringRepo.On("UpdateScores", mock.Anything, []model.ItemScore{}).Return(nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	n, err := svc.WakeSnoozed(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 3, len(publisher.published))
+	for _, evt := range publisher.published {
+		assert.Equal(t, events.ItemWoken, evt.Type)
+	}
+	scoringRepo.AssertCalled(t, "GetScoreSignals", mock.Anything, []string{"item-1", "item-2"}, testScoringNow)
+	scoringRepo.AssertCalled(t, "GetScoreSignals", mock.Anything, []string{"item-3"}, testScoringNow)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamService_WakeSnoozed_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	svc := newTestService(mockRepo, new(MockCache))
+
+	mockRepo.On("WakeSnoozed", mock.Anything, mock.Anything, 500).Return([]model.ItemRef{}, errors.New("database error"))
+
+	// Act
+	n, err := svc.WakeSnoozed(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestValidateSnoozeUntil(t *testing.T) {
+	past := testSnoozeNow.Add(-time.Minute)
+	soon := testSnoozeNow.Add(time.Hour)
+	tooFar := testSnoozeNow.Add(MaxSnooze + time.Hour)
+
+	tests := []struct {
+		name     string
+		input    *time.Time
+		hasError bool
+	}{
+		{"future time", &soon, false},
+		{"missing", nil, true},
+		{"in the past", &past, true},
+		{"now", &testSnoozeNow, true},
+		{"too far ahead", &tooFar, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateSnoozeUntil(tt.input, testSnoozeNow)
+
+			if tt.hasError {
+				assert.ErrorIs(t, err, ErrInvalidSnooze)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, *tt.input, result)
+			}
+		})
+	}
+}
+
+// Tests for archive and trash
+func TestStreamService_SetItemState(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	publisher := &recordingPublisher{}
+	svc := NewStreamService(mockRepo, mockCache, publisher, newTestConfig(), logger.New())
+
+	mockRepo.On("SetItemState", mock.Anything, "user-123", []string{"item-1", "item-2"}, model.ItemTrashed).
+		Return([]string{"item-1"}, nil)
+	mockCache.On("InvalidateUserCache", mock.Anything, "user-123").Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1"}).Return(nil)
+	mockCache.On("Delete", mock.Anything, []string{"counts:user-123"}).Return(nil)
+
+	// Act
+	result, err := svc.SetItemState(context.Background(), model.ItemStateRequest{
+		UserID:  "user-123",
+		ItemIDs: []string{"item-1", "item-2"},
+		State:   model.ItemTrashed,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"item-1"}, result.ItemIDs)
+	assert.Equal(t, model.ItemTrashed, result.State)
+	assert.Equal(t, 1, len(publisher.published))
+	assert.Equal(t, events.ItemTrashed, publisher.published[0].Type)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestStreamService_PurgeTrash(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	mockCache := new(MockCache)
+	cfg := newTestConfig()
+	cfg.Trash = config.TrashConfig{Retention: 30 * 24 * time.Hour, PurgeInterval: time.Hour, BatchSize: 2}
+	svc := NewStreamService(mockRepo, mockCache, events.NopPublisher{}, cfg, logger.New())
+	svc.now = func() time.Time { return testSnoozeNow }
+
+	cutoff := testSnoozeNow.Add(-30 * 24 * time.Hour)
+	mockRepo.On("PurgeTrash", mock.Anything, cutoff, 2).Return([]model.ItemRef{
+		{ItemID: "item-1", UserID: "user-1"},
+		{ItemID: "item-2", UserID: "user-2"},
+	}, nil).Once()
+	mockRepo.On("PurgeTrash", mock.Anything, cutoff, 2).Return([]model.ItemRef{}, nil).Once()
+	mockCache.On("Delete", mock.Anything, []string{"item:item-1", "item:item-2"}).Return(nil)
+
+	// Act
+	n, err := svc.PurgeTrash(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
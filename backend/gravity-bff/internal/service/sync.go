@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+// ErrInvalidSyncToken means a sync token was not issued by Sync.
+var ErrInvalidSyncToken = errors.New("invalid sync token")
+
+// Sync retrieves the items created, updated and deleted since the sync token
+// in the request, or every item for an empty token, along with the token to
+// pass on the next call. Items are returned in any state, including archived,
+// trashed and snoozed, so that clients can mirror the whole stream.
+// Returns an error wrapping ErrInvalidSyncToken if the token cannot be decoded.
+func (s *StreamService) Sync(ctx context.Context, req model.SyncRequest) (*model.SyncResponse, error) {
+	since, err := decodeSyncToken(req.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra change to determine if there are more
+	changes, err := s.repo.GetChanges(ctx, req.UserID, since, req.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes: %w", err)
+	}
+
+	response := &model.SyncResponse{
+		Created: []model.PriorityItem{},
+		Updated: []model.PriorityItem{},
+		Deleted: []string{},
+		Token:   encodeSyncToken(since),
+		HasMore: len(changes) > req.Limit,
+	}
+	if response.HasMore {
+		changes = changes[:req.Limit]
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.Item == nil:
+			response.Deleted = append(response.Deleted, change.ItemID)
+		case change.Created:
+			response.Created = append(response.Created, *change.Item)
+		default:
+			response.Updated = append(response.Updated, *change.Item)
+		}
+	}
+	if len(changes) > 0 {
+		response.Token = encodeSyncToken(changes[len(changes)-1].Seq)
+	}
+
+	return response, nil
+}
+
+// encodeSyncToken encodes a change sequence number as an opaque sync token.
+func encodeSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(seq, 10)))
+}
+
+// decodeSyncToken decodes a sync token into a change sequence number.
+// An empty token decodes to 0, the start of the change feed.
+func decodeSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: since is malformed", ErrInvalidSyncToken)
+	}
+	seq, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("%w: since is malformed", ErrInvalidSyncToken)
+	}
+	return seq, nil
+}
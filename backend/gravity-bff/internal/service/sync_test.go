@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mabidoli/gravity-bff/internal/domain/model"
+)
+
+func TestSyncToken_RoundTrip(t *testing.T) {
+	seq, err := decodeSyncToken(encodeSyncToken(42))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), seq)
+
+	seq, err = decodeSyncToken("")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), seq)
+
+	for _, token := range []string{"not base64!", "YWJj", encodeSyncToken(-1)} {
+		_, err := decodeSyncToken(token)
+		assert.True(t, errors.Is(err, ErrInvalidSyncToken), token)
+	}
+}
+
+func TestStreamService_Sync_SplitsChanges(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	svc := newTestService(mockRepo, new(MockCache))
+
+	changes := []model.ItemChange{
+		{Seq: 11, ItemID: "item-1", Item: &model.PriorityItem{ID: "item-1"}},
+		{Seq: 12, ItemID: "item-2", Created: true, Item: &model.PriorityItem{ID: "item-2"}},
+		{Seq: 14, ItemID: "item-3"},
+	}
+	mockRepo.On("GetChanges", mock.Anything, "user-123", int64(10), 101).Return(changes, nil)
+
+	// Act
+	response, err := svc.Sync(context.Background(), model.SyncRequest{
+		UserID: "user-123",
+		Since:  encodeSyncToken(10),
+		Limit:  100,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []model.PriorityItem{{ID: "item-2"}}, response.Created)
+	assert.Equal(t, []model.PriorityItem{{ID: "item-1"}}, response.Updated)
+	assert.Equal(t, []string{"item-3"}, response.Deleted)
+	assert.Equal(t, encodeSyncToken(14), response.Token)
+	assert.False(t, response.HasMore)
+}
+
+func TestStreamService_Sync_HasMore(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	svc := newTestService(mockRepo, new(MockCache))
+
+	changes := []model.ItemChange{
+		{Seq: 1, ItemID: "item-1", Created: true, Item: &model.PriorityItem{ID: "item-1"}},
+		{Seq: 2, ItemID: "item-2", Created: true, Item: &model.PriorityItem{ID: "item-2"}},
+	}
+	mockRepo.On("GetChanges", mock.Anything, "user-123", int64(0), 2).Return(changes, nil)
+
+	// Act
+	response, err := svc.Sync(context.Background(), model.SyncRequest{UserID: "user-123", Limit: 1})
+
+	// Assert: the extra change is held back for the next call
+	assert.NoError(t, err)
+	assert.True(t, response.HasMore)
+	assert.Len(t, response.Created, 1)
+	assert.Equal(t, encodeSyncToken(1), response.Token)
+}
+
+func TestStreamService_Sync_NoChangesKeepsToken(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	svc := newTestService(mockRepo, new(MockCache))
+
+	since := encodeSyncToken(7)
+	mockRepo.On("GetChanges", mock.Anything, "user-123", int64(7), 101).Return([]model.ItemChange{}, nil)
+
+	// Act
+	response, err := svc.Sync(context.Background(), model.SyncRequest{UserID: "user-123", Since: since, Limit: 100})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, since, response.Token)
+	assert.NotNil(t, response.Created)
+	assert.NotNil(t, response.Updated)
+	assert.NotNil(t, response.Deleted)
+}
+
+func TestStreamService_Sync_InvalidToken(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockStreamRepository)
+	svc := newTestService(mockRepo, new(MockCache))
+
+	// Act
+	response, err := svc.Sync(context.Background(), model.SyncRequest{UserID: "user-123", Since: "%%%", Limit: 100})
+
+	// Assert
+	assert.Nil(t, response)
+	assert.True(t, errors.Is(err, ErrInvalidSyncToken))
+	mockRepo.AssertNotCalled(t, "GetChanges", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
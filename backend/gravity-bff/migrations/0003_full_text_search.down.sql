@@ -0,0 +1,7 @@
+-- Rollback: Remove full-text search columns and indexes
+
+DROP INDEX IF EXISTS idx_messages_search;
+ALTER TABLE messages DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_priority_items_search;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,28 @@
+-- Migration: Full-text search over priority items and messages
+-- Adds generated tsvector columns and GIN indexes used by GET /v2/stream/search
+
+-- ============================================================================
+-- Priority Items
+-- Title ranks above snippet
+-- ============================================================================
+ALTER TABLE priority_items
+    ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(snippet, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_priority_items_search ON priority_items USING GIN (search_vector);
+
+-- ============================================================================
+-- Messages
+-- Plain content ranks above the HTML body; tags are stripped before indexing
+-- ============================================================================
+ALTER TABLE messages
+    ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(content, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(regexp_replace(full_content_html, '<[^>]*>', ' ', 'g'), '')), 'D')
+    ) STORED;
+
+CREATE INDEX idx_messages_search ON messages USING GIN (search_vector);
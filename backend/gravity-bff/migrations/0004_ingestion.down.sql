@@ -0,0 +1,10 @@
+-- Rollback: Remove external IDs used by webhook ingestion
+
+DROP INDEX IF EXISTS idx_users_external_id;
+ALTER TABLE users DROP COLUMN IF EXISTS external_id;
+
+DROP INDEX IF EXISTS idx_messages_external_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS external_id;
+
+DROP INDEX IF EXISTS idx_priority_items_external_id;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS external_id;
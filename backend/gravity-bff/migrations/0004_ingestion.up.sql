@@ -0,0 +1,23 @@
+-- Migration: Webhook ingestion
+-- External IDs let connectors upsert items, messages and contacts idempotently
+
+-- ============================================================================
+-- Priority Items
+-- Unique per owner and source; NULL for items not created by a connector
+-- ============================================================================
+ALTER TABLE priority_items ADD COLUMN external_id VARCHAR(255);
+CREATE UNIQUE INDEX idx_priority_items_external_id ON priority_items (user_id, source, external_id);
+
+-- ============================================================================
+-- Messages
+-- Unique per item; replies sent from Gravity have no external ID
+-- ============================================================================
+ALTER TABLE messages ADD COLUMN external_id VARCHAR(255);
+CREATE UNIQUE INDEX idx_messages_external_id ON messages (item_id, external_id);
+
+-- ============================================================================
+-- Users
+-- Source-qualified ID (e.g. 'slack:U024BE7LH') for contacts without an email
+-- ============================================================================
+ALTER TABLE users ADD COLUMN external_id VARCHAR(255);
+CREATE UNIQUE INDEX idx_users_external_id ON users (external_id);
@@ -0,0 +1,7 @@
+-- Rollback: Remove priority scoring
+
+DROP INDEX IF EXISTS idx_priority_items_scored_at;
+DROP INDEX IF EXISTS idx_priority_items_user_score;
+
+ALTER TABLE priority_items DROP COLUMN IF EXISTS scored_at;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS score;
@@ -0,0 +1,15 @@
+-- Migration: Priority scoring
+-- Items carry a numeric score; priority becomes the score's bucket
+
+-- ============================================================================
+-- Priority Items
+-- score is 0-100; scored_at is NULL until the item is first scored
+-- ============================================================================
+ALTER TABLE priority_items ADD COLUMN score DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE priority_items ADD COLUMN scored_at TIMESTAMPTZ;
+
+-- Stream sorted by score (sort=score), with the same tie-breakers as the keyset cursor
+CREATE INDEX idx_priority_items_user_score ON priority_items (user_id, score DESC, item_timestamp DESC, id DESC);
+
+-- Scheduled rescoring picks the items scored longest ago
+CREATE INDEX idx_priority_items_scored_at ON priority_items (scored_at NULLS FIRST);
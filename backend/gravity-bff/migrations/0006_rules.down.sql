@@ -0,0 +1,6 @@
+-- Rollback: Remove triage rules
+
+ALTER TABLE priority_items DROP COLUMN IF EXISTS priority_override;
+
+DROP TRIGGER IF EXISTS update_rules_updated_at ON rules;
+DROP TABLE IF EXISTS rules;
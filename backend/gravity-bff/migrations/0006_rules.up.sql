@@ -0,0 +1,32 @@
+-- Migration: Triage rules
+-- Users define rules that set priority and read state on matching items
+
+-- ============================================================================
+-- Rules Table
+-- Conditions and actions are JSON documents validated by the API
+-- ============================================================================
+CREATE TABLE rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL, -- Clerk user ID
+    name VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    position INTEGER NOT NULL DEFAULT 0, -- Rules apply in ascending order; later rules win
+    match VARCHAR(10) NOT NULL DEFAULT 'all', -- 'all', 'any'
+    conditions JSONB NOT NULL,
+    actions JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rules_user_position ON rules (user_id, position, created_at);
+
+CREATE TRIGGER update_rules_updated_at
+    BEFORE UPDATE ON rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- Priority Items
+-- A priority set by a rule; scoring keeps the item in this bucket while set
+-- ============================================================================
+ALTER TABLE priority_items ADD COLUMN priority_override VARCHAR(50);
@@ -0,0 +1,4 @@
+-- Rollback: Remove snoozed items
+
+DROP INDEX IF EXISTS idx_priority_items_snoozed_until;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS snoozed_until;
@@ -0,0 +1,12 @@
+-- Migration: Snoozed items
+-- Snoozed items are hidden from the stream until they wake up at snoozed_until
+
+-- ============================================================================
+-- Priority Items
+-- Woken items are unsnoozed, marked unread and moved to the top of the stream
+-- ============================================================================
+ALTER TABLE priority_items ADD COLUMN snoozed_until TIMESTAMPTZ;
+
+-- The wake job scans due items across all users
+CREATE INDEX idx_priority_items_snoozed_until ON priority_items (snoozed_until)
+    WHERE snoozed_until IS NOT NULL;
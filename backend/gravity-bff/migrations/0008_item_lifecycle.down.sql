@@ -0,0 +1,5 @@
+-- Rollback: Remove archive and trash
+
+DROP INDEX IF EXISTS idx_priority_items_deleted_at;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,14 @@
+-- Migration: Archive and trash
+-- Archived items leave the stream but stay searchable; trashed items are
+-- soft-deleted and purged after a retention period
+
+-- ============================================================================
+-- Priority Items
+-- Restoring an item clears both columns
+-- ============================================================================
+ALTER TABLE priority_items ADD COLUMN archived_at TIMESTAMPTZ;
+ALTER TABLE priority_items ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- The purge job scans expired trash across all users
+CREATE INDEX idx_priority_items_deleted_at ON priority_items (deleted_at)
+    WHERE deleted_at IS NOT NULL;
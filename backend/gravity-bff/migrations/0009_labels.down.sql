@@ -0,0 +1,5 @@
+-- Rollback: Remove labels
+
+DROP TABLE IF EXISTS priority_item_labels;
+DROP TRIGGER IF EXISTS update_labels_updated_at ON labels;
+DROP TABLE IF EXISTS labels;
@@ -0,0 +1,36 @@
+-- Migration: Labels
+-- User-owned labels for organizing priority items
+
+-- ============================================================================
+-- Labels Table
+-- Names are unique per user, ignoring case
+-- ============================================================================
+CREATE TABLE labels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL, -- Clerk user ID
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7), -- Hex color, e.g. '#4f46e5'
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_labels_user_name ON labels (user_id, LOWER(name));
+
+CREATE TRIGGER update_labels_updated_at
+    BEFORE UPDATE ON labels
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- Priority Item Labels (Junction Table)
+-- Links priority items to the labels applied to them
+-- ============================================================================
+CREATE TABLE priority_item_labels (
+    item_id UUID NOT NULL REFERENCES priority_items(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, label_id)
+);
+
+-- Index for label-scoped streams
+CREATE INDEX idx_item_labels_label_id ON priority_item_labels (label_id, item_id);
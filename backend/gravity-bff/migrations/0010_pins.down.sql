@@ -0,0 +1,4 @@
+-- Rollback: Remove pinned items
+
+DROP INDEX IF EXISTS idx_priority_items_user_pinned;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS pin_position;
@@ -0,0 +1,11 @@
+-- Migration: Pinned items
+-- Pinned items are shown above the stream in a user-defined order
+
+-- ============================================================================
+-- Priority Items
+-- pin_position orders a user's pinned items; NULL means not pinned
+-- ============================================================================
+ALTER TABLE priority_items ADD COLUMN pin_position INTEGER;
+
+CREATE INDEX idx_priority_items_user_pinned ON priority_items (user_id, pin_position)
+    WHERE pin_position IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Rollback: Remove delta sync
+
+DROP TRIGGER IF EXISTS touch_labels_items ON labels;
+DROP FUNCTION IF EXISTS touch_label_items();
+DROP TRIGGER IF EXISTS touch_priority_item_labels_item ON priority_item_labels;
+DROP FUNCTION IF EXISTS touch_labeled_item();
+DROP TRIGGER IF EXISTS record_priority_items_tombstone ON priority_items;
+DROP FUNCTION IF EXISTS record_item_tombstone();
+DROP TABLE IF EXISTS sync_tombstones;
+DROP TRIGGER IF EXISTS assign_priority_items_change_seq ON priority_items;
+DROP FUNCTION IF EXISTS assign_item_change_seq();
+DROP INDEX IF EXISTS idx_priority_items_user_change_seq;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS created_seq;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS change_seq;
+DROP FUNCTION IF EXISTS next_change_seq(VARCHAR);
+DROP TABLE IF EXISTS sync_sequences;
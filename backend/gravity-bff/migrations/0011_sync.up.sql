@@ -0,0 +1,151 @@
+-- Migration: Delta sync
+-- Every change to a user's items gets the next number in a per-user change
+-- sequence, so clients can ask for everything that changed since a sequence number
+
+-- ============================================================================
+-- Sync Sequences Table
+-- The last change sequence number handed out per user. Taking the next number
+-- locks the user's row until commit, so changes commit in sequence order.
+-- ============================================================================
+CREATE TABLE sync_sequences (
+    user_id VARCHAR(255) PRIMARY KEY, -- Clerk user ID
+    last_seq BIGINT NOT NULL
+);
+
+CREATE OR REPLACE FUNCTION next_change_seq(p_user_id VARCHAR)
+RETURNS BIGINT AS $$
+    INSERT INTO sync_sequences (user_id, last_seq)
+    VALUES (p_user_id, 1)
+    ON CONFLICT (user_id) DO UPDATE SET last_seq = sync_sequences.last_seq + 1
+    RETURNING last_seq;
+$$ LANGUAGE sql;
+
+-- ============================================================================
+-- Priority Items
+-- change_seq is the sequence number of the item's last change, created_seq
+-- the one of its creation. Existing items are numbered in creation order.
+-- ============================================================================
+ALTER TABLE priority_items ADD COLUMN change_seq BIGINT;
+ALTER TABLE priority_items ADD COLUMN created_seq BIGINT;
+
+ALTER TABLE priority_items DISABLE TRIGGER update_priority_items_updated_at;
+
+WITH numbered AS (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at, id) AS seq
+    FROM priority_items
+)
+UPDATE priority_items p
+SET change_seq = numbered.seq, created_seq = numbered.seq
+FROM numbered
+WHERE p.id = numbered.id;
+
+ALTER TABLE priority_items ENABLE TRIGGER update_priority_items_updated_at;
+
+INSERT INTO sync_sequences (user_id, last_seq)
+SELECT user_id, MAX(change_seq) FROM priority_items GROUP BY user_id;
+
+ALTER TABLE priority_items ALTER COLUMN change_seq SET NOT NULL;
+ALTER TABLE priority_items ALTER COLUMN created_seq SET NOT NULL;
+
+CREATE INDEX idx_priority_items_user_change_seq ON priority_items (user_id, change_seq);
+
+-- Numbers every insert and every update that changes what clients see, like
+-- the updated_at trigger. Rescoring rewrites score and scored_at on each pass;
+-- priority changes count, score drift alone does not. search_vector is left
+-- out because generated columns are not computed yet in BEFORE triggers.
+-- Statements that set change_seq themselves (to touch an item) keep their number.
+CREATE OR REPLACE FUNCTION assign_item_change_seq()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.change_seq IS DISTINCT FROM OLD.change_seq THEN
+            RETURN NEW;
+        END IF;
+        IF (to_jsonb(NEW) - 'score' - 'scored_at' - 'updated_at' - 'search_vector')
+           = (to_jsonb(OLD) - 'score' - 'scored_at' - 'updated_at' - 'search_vector') THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+
+    NEW.change_seq := next_change_seq(NEW.user_id);
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER assign_priority_items_change_seq
+    BEFORE INSERT OR UPDATE ON priority_items
+    FOR EACH ROW
+    EXECUTE FUNCTION assign_item_change_seq();
+
+-- ============================================================================
+-- Sync Tombstones Table
+-- Records permanently deleted items, so clients can drop them
+-- ============================================================================
+CREATE TABLE sync_tombstones (
+    user_id VARCHAR(255) NOT NULL, -- Clerk user ID
+    item_id UUID NOT NULL,
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, change_seq)
+);
+
+CREATE OR REPLACE FUNCTION record_item_tombstone()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, item_id, change_seq)
+    VALUES (OLD.user_id, OLD.id, next_change_seq(OLD.user_id));
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER record_priority_items_tombstone
+    AFTER DELETE ON priority_items
+    FOR EACH ROW
+    EXECUTE FUNCTION record_item_tombstone();
+
+-- ============================================================================
+-- Label Changes
+-- Items carry their labels, so applying, removing, renaming or recoloring a
+-- label is a change to the labeled items
+-- ============================================================================
+CREATE OR REPLACE FUNCTION touch_labeled_item()
+RETURNS TRIGGER AS $$
+DECLARE
+    link priority_item_labels;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        link := OLD;
+    ELSE
+        link := NEW;
+    END IF;
+
+    -- Finds nothing when the link goes because the item is being deleted
+    UPDATE priority_items
+    SET change_seq = next_change_seq(user_id)
+    WHERE id = link.item_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER touch_priority_item_labels_item
+    AFTER INSERT OR DELETE ON priority_item_labels
+    FOR EACH ROW
+    EXECUTE FUNCTION touch_labeled_item();
+
+CREATE OR REPLACE FUNCTION touch_label_items()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE priority_items
+    SET change_seq = next_change_seq(user_id)
+    WHERE id IN (SELECT item_id FROM priority_item_labels WHERE label_id = NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER touch_labels_items
+    AFTER UPDATE OF name, color ON labels
+    FOR EACH ROW
+    EXECUTE FUNCTION touch_label_items();
@@ -0,0 +1,4 @@
+-- Rollback: Remove AI insights
+
+DROP INDEX IF EXISTS idx_insight_queue_queued_at;
+DROP TABLE IF EXISTS insight_queue;
@@ -0,0 +1,15 @@
+-- Migration: AI insights
+-- Insights are generated in the background after ingest and stored on each
+-- item's latest message (messages.ai_insights)
+
+-- ============================================================================
+-- Insight Queue Table
+-- Items waiting for insights on their latest message. Queueing an item that
+-- is already waiting moves it to the back of the queue.
+-- ============================================================================
+CREATE TABLE insight_queue (
+    item_id UUID PRIMARY KEY REFERENCES priority_items(id) ON DELETE CASCADE,
+    queued_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_insight_queue_queued_at ON insight_queue (queued_at);
@@ -0,0 +1,9 @@
+-- Rollback: Remove calendar events
+
+DROP TRIGGER IF EXISTS sync_messages_calendar_event ON messages;
+DROP FUNCTION IF EXISTS sync_calendar_event();
+DROP INDEX IF EXISTS idx_calendar_events_user_event;
+DROP INDEX IF EXISTS idx_calendar_events_item_start;
+DROP INDEX IF EXISTS idx_calendar_events_user_start;
+DROP TABLE IF EXISTS calendar_events;
+DROP FUNCTION IF EXISTS event_time(JSONB, TEXT);
@@ -0,0 +1,73 @@
+-- Migration: Calendar events
+-- Event times are promoted out of messages.event_details into indexed
+-- columns, so that calendar queries can find overlapping events
+
+-- Reads a timestamp field of event details, or NULL if it is missing or malformed
+CREATE OR REPLACE FUNCTION event_time(details JSONB, field TEXT)
+RETURNS TIMESTAMPTZ AS $$
+BEGIN
+    RETURN (details->>field)::timestamptz;
+EXCEPTION WHEN data_exception THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- ============================================================================
+-- Calendar Events Table
+-- One row per message with event details. An event appearing in several
+-- messages has a row for each; event_id ties them together and the latest
+-- message_timestamp is its current version. Maintained by a trigger on messages.
+-- ============================================================================
+CREATE TABLE calendar_events (
+    message_id UUID PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES priority_items(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL, -- Clerk user ID
+    event_id TEXT NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    message_timestamp TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_calendar_events_user_start ON calendar_events (user_id, start_time);
+CREATE INDEX idx_calendar_events_item_start ON calendar_events (item_id, start_time);
+CREATE INDEX idx_calendar_events_user_event ON calendar_events (user_id, event_id, message_timestamp DESC);
+
+INSERT INTO calendar_events (message_id, item_id, user_id, event_id, start_time, end_time, message_timestamp)
+SELECT m.id, m.item_id, p.user_id, COALESCE(m.event_details->>'id', m.id::text),
+       event_time(m.event_details, 'startTime'), event_time(m.event_details, 'endTime'), m.message_timestamp
+FROM messages m
+JOIN priority_items p ON p.id = m.item_id
+WHERE event_time(m.event_details, 'startTime') IS NOT NULL
+  AND event_time(m.event_details, 'endTime') IS NOT NULL;
+
+-- Keeps calendar_events in step with messages. Messages whose event details
+-- are removed or lack valid times have no row.
+CREATE OR REPLACE FUNCTION sync_calendar_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_start TIMESTAMPTZ := event_time(NEW.event_details, 'startTime');
+    v_end TIMESTAMPTZ := event_time(NEW.event_details, 'endTime');
+BEGIN
+    IF v_start IS NULL OR v_end IS NULL THEN
+        DELETE FROM calendar_events WHERE message_id = NEW.id;
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO calendar_events (message_id, item_id, user_id, event_id, start_time, end_time, message_timestamp)
+    SELECT NEW.id, NEW.item_id, p.user_id, COALESCE(NEW.event_details->>'id', NEW.id::text),
+           v_start, v_end, NEW.message_timestamp
+    FROM priority_items p
+    WHERE p.id = NEW.item_id
+    ON CONFLICT (message_id) DO UPDATE SET
+        event_id = EXCLUDED.event_id,
+        start_time = EXCLUDED.start_time,
+        end_time = EXCLUDED.end_time,
+        message_timestamp = EXCLUDED.message_timestamp;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER sync_messages_calendar_event
+    AFTER INSERT OR UPDATE OF event_details, message_timestamp ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_calendar_event();
@@ -0,0 +1,9 @@
+-- Rollback: Remove reply tracking and follow-ups
+
+DROP TRIGGER IF EXISTS sync_messages_last_sender ON messages;
+DROP FUNCTION IF EXISTS sync_last_sender();
+DROP FUNCTION IF EXISTS refresh_last_sender(UUID);
+DROP INDEX IF EXISTS idx_priority_items_follow_up_at;
+DROP INDEX IF EXISTS idx_priority_items_user_last_sender;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS follow_up_at;
+ALTER TABLE priority_items DROP COLUMN IF EXISTS last_sender_type;
@@ -0,0 +1,68 @@
+-- Migration: Reply tracking and follow-ups
+-- Items remember who wrote their latest message, so the stream can tell which
+-- conversations await a reply from the user and which await one from others
+
+-- ============================================================================
+-- Priority Items
+-- last_sender_type is the sender_type of the latest non-system message.
+-- follow_up_at is when an item awaiting a reply resurfaces if nobody answers;
+-- it is cleared as soon as someone else writes.
+-- ============================================================================
+ALTER TABLE priority_items ADD COLUMN last_sender_type VARCHAR(50); -- 'user', 'other'
+ALTER TABLE priority_items ADD COLUMN follow_up_at TIMESTAMPTZ;
+
+-- Items that get a last sender count as changed, so synced clients learn it
+ALTER TABLE priority_items DISABLE TRIGGER update_priority_items_updated_at;
+
+UPDATE priority_items p
+SET last_sender_type = (
+    SELECT m.sender_type FROM messages m
+    WHERE m.item_id = p.id AND m.sender_type <> 'system'
+    ORDER BY m.message_timestamp DESC, m.id DESC
+    LIMIT 1
+);
+
+ALTER TABLE priority_items ENABLE TRIGGER update_priority_items_updated_at;
+
+CREATE INDEX idx_priority_items_user_last_sender ON priority_items (user_id, last_sender_type);
+
+-- The follow-up job scans due items across all users
+CREATE INDEX idx_priority_items_follow_up_at ON priority_items (follow_up_at)
+    WHERE follow_up_at IS NOT NULL;
+
+-- Recomputes an item's last sender, clearing its follow-up once someone else wrote last
+CREATE OR REPLACE FUNCTION refresh_last_sender(p_item_id UUID)
+RETURNS VOID AS $$
+    UPDATE priority_items p
+    SET last_sender_type = latest.sender_type,
+        follow_up_at = CASE WHEN latest.sender_type = 'user' THEN p.follow_up_at END
+    FROM (
+        SELECT (
+            SELECT m.sender_type FROM messages m
+            WHERE m.item_id = p_item_id AND m.sender_type <> 'system'
+            ORDER BY m.message_timestamp DESC, m.id DESC
+            LIMIT 1
+        ) AS sender_type
+    ) latest
+    WHERE p.id = p_item_id
+      AND p.last_sender_type IS DISTINCT FROM latest.sender_type;
+$$ LANGUAGE sql;
+
+-- Keeps last_sender_type in step with the messages of an item
+CREATE OR REPLACE FUNCTION sync_last_sender()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        PERFORM refresh_last_sender(OLD.item_id);
+    END IF;
+    IF TG_OP <> 'DELETE' AND (TG_OP = 'INSERT' OR NEW.item_id <> OLD.item_id) THEN
+        PERFORM refresh_last_sender(NEW.item_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER sync_messages_last_sender
+    AFTER INSERT OR DELETE OR UPDATE OF item_id, sender_type, message_timestamp ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_last_sender();
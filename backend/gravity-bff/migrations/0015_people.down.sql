@@ -0,0 +1,3 @@
+-- Rollback: Remove the people directory
+
+DROP TABLE IF EXISTS vip_contacts;
@@ -0,0 +1,14 @@
+-- Migration: People directory
+-- The people a user interacts with are the participants of their items;
+-- the user can mark some of them as VIPs, whose items score higher
+
+-- ============================================================================
+-- VIP Contacts
+-- contact_id is a users row that takes part in the user's items
+-- ============================================================================
+CREATE TABLE vip_contacts (
+    user_id VARCHAR(255) NOT NULL, -- Clerk user ID
+    contact_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, contact_id)
+);
@@ -0,0 +1,3 @@
+-- Rollback: Remove local calendar event edits
+
+ALTER TABLE messages DROP COLUMN IF EXISTS event_edits;
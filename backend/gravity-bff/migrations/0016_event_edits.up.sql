@@ -0,0 +1,10 @@
+-- Migration: Local calendar event edits
+-- Reschedules and RSVPs made in Gravity are kept apart from the event as the
+-- source sent it, so that redelivering the message does not undo them
+
+-- ============================================================================
+-- Messages
+-- event_edits holds the event_details fields changed locally (startTime,
+-- endTime, rsvps); they are applied over event_details on every redelivery
+-- ============================================================================
+ALTER TABLE messages ADD COLUMN event_edits JSONB;
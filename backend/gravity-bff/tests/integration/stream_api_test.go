@@ -7,12 +7,17 @@
 package integration
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,11 +32,18 @@ import (
 	"github.com/mabidoli/gravity-bff/internal/cache"
 	"github.com/mabidoli/gravity-bff/internal/config"
 	"github.com/mabidoli/gravity-bff/internal/domain/model"
+	"github.com/mabidoli/gravity-bff/internal/events"
+	"github.com/mabidoli/gravity-bff/internal/ingestion"
+	"github.com/mabidoli/gravity-bff/internal/insights"
 	"github.com/mabidoli/gravity-bff/internal/repository"
+	"github.com/mabidoli/gravity-bff/internal/scoring"
 	"github.com/mabidoli/gravity-bff/internal/service"
 	"github.com/mabidoli/gravity-bff/pkg/logger"
 )
 
+// testIngestSecret signs webhook deliveries in integration tests.
+const testIngestSecret = "test-ingest-secret"
+
 var (
 	testDB    *pgxpool.Pool
 	testRedis *redis.Client
@@ -119,20 +131,73 @@ func setupTestApp() *fiber.App {
 	log := logger.New()
 	cfg := &config.Config{
 		Cache: config.CacheConfig{
-			StreamTTL: 2 * time.Minute,
-			ItemTTL:   5 * time.Minute,
+			StreamTTL:   2 * time.Minute,
+			ItemTTL:     5 * time.Minute,
+			BriefingTTL: 5 * time.Minute,
+		},
+		Calendar: config.CalendarConfig{
+			FeedSecret:        "test-feed-secret",
+			FeedPast:          30 * 24 * time.Hour,
+			FeedFuture:        365 * 24 * time.Hour,
+			RecurrenceHorizon: 90 * 24 * time.Hour,
+		},
+		Scoring: config.ScoringConfig{
+			Interval:  15 * time.Minute,
+			BatchSize: 100,
+		},
+		Snooze: config.SnoozeConfig{
+			WakeInterval: time.Minute,
+			BatchSize:    100,
+		},
+		Trash: config.TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+			BatchSize:     100,
+		},
+		Insights: config.InsightsConfig{
+			Provider:  insights.RuleBasedName,
+			Interval:  15 * time.Second,
+			BatchSize: 50,
 		},
 	}
 
 	// Initialize layers
 	redisCache := cache.NewRedisCache(testRedis)
 	streamRepo := repository.NewPgStreamRepository(testDB)
-	streamService := service.NewStreamService(streamRepo, redisCache, cfg, log)
+	eventBroker := events.NewRedisBroker(testRedis, 100, time.Hour)
+	streamService := service.NewStreamService(streamRepo, redisCache, eventBroker, cfg, log)
+	ingestRepo := repository.NewPgIngestRepository(testDB)
+	connectors := ingestion.NewRegistry(ingestion.NewJSONConnector(model.SourceSlack, testIngestSecret))
+	ingestService := service.NewIngestService(ingestRepo, connectors, redisCache, eventBroker, log)
+	ruleService := service.NewRuleService(repository.NewPgRuleRepository(testDB), log)
+	ingestService.SetTriager(ruleService)
+	scorer := scoring.New(scoring.DefaultWeights(), scoring.DefaultThresholds())
+	scoringService := service.NewScoringService(repository.NewPgScoringRepository(testDB), scorer, redisCache, eventBroker, cfg, log)
+	ingestService.SetScorer(scoringService)
+	streamService.SetScorer(scoringService)
+	calendarRepo := repository.NewPgCalendarRepository(testDB)
+	calendarService := service.NewCalendarService(calendarRepo, ingestService, cfg, log)
+	ingestService.SetConflictChecker(calendarService)
+	streamService.SetConflictChecker(calendarService)
+	labelService := service.NewLabelService(repository.NewPgLabelRepository(testDB), redisCache, eventBroker, log)
+	insightService := service.NewInsightService(repository.NewPgInsightRepository(testDB), insights.NewRuleBased(), streamService, redisCache, eventBroker, cfg, log)
+	ingestService.SetInsighter(insightService)
+	briefingService := service.NewBriefingService(streamRepo, calendarRepo, redisCache, cfg, log)
+	peopleService := service.NewPeopleService(repository.NewPgUserRepository(testDB), streamRepo, log)
+	peopleService.SetScorer(scoringService)
 
 	healthHandler := handler.NewHealthHandler()
 	streamHandler := handler.NewStreamHandler(streamService, log)
+	eventsHandler := handler.NewEventsHandler(eventBroker, 15*time.Second, log)
+	ingestHandler := handler.NewIngestHandler(ingestService, log)
+	calendarHandler := handler.NewCalendarHandler(calendarService, log)
+	ruleHandler := handler.NewRuleHandler(ruleService, log)
+	labelHandler := handler.NewLabelHandler(labelService, log)
+	insightHandler := handler.NewInsightHandler(insightService, log)
+	briefingHandler := handler.NewBriefingHandler(briefingService, log)
+	peopleHandler := handler.NewPeopleHandler(peopleService, log)
 
-	router := api.NewRouter(healthHandler, streamHandler, log)
+	router := api.NewRouter(healthHandler, streamHandler, eventsHandler, ingestHandler, calendarHandler, ruleHandler, labelHandler, insightHandler, briefingHandler, peopleHandler, log)
 
 	app := fiber.New()
 	router.Setup(app)
@@ -184,9 +249,15 @@ func seedTestData(ctx context.Context) error {
 }
 
 func cleanupTestData(ctx context.Context) {
+	testDB.Exec(ctx, "DELETE FROM rules WHERE user_id LIKE 'test-user-%'")
+	testDB.Exec(ctx, "DELETE FROM labels WHERE user_id LIKE 'test-user-%'")
+	testDB.Exec(ctx, "DELETE FROM priority_items WHERE user_id LIKE 'test-user-%' AND external_id IS NOT NULL")
 	testDB.Exec(ctx, "DELETE FROM messages WHERE id LIKE 'msg-%'")
 	testDB.Exec(ctx, "DELETE FROM priority_item_participants WHERE item_id LIKE 'item-%'")
 	testDB.Exec(ctx, "DELETE FROM priority_items WHERE id LIKE 'item-%'")
+	// After the items, whose deletion records tombstones
+	testDB.Exec(ctx, "DELETE FROM sync_tombstones WHERE user_id LIKE 'test-user-%'")
+	testDB.Exec(ctx, "DELETE FROM sync_sequences WHERE user_id LIKE 'test-user-%'")
 	testDB.Exec(ctx, "DELETE FROM users WHERE id LIKE 'test-user-%'")
 }
 
@@ -276,6 +347,45 @@ func TestGetStream_FilterUnread_Integration(t *testing.T) {
 	}
 }
 
+func TestGetStream_SortByScore_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	// The oldest item scores highest, so score order differs from recency order
+	_, err := testDB.Exec(ctx, `
+		UPDATE priority_items SET score = CASE id
+			WHEN 'item-1' THEN 20 WHEN 'item-2' THEN 40 WHEN 'item-3' THEN 80 END
+		WHERE id IN ('item-1', 'item-2', 'item-3')
+	`)
+	require.NoError(t, err)
+	defer testDB.Exec(ctx, "UPDATE priority_items SET score = 0 WHERE id IN ('item-1', 'item-2', 'item-3')")
+
+	fetch := func(url string) model.StreamResponse {
+		req := httptest.NewRequest("GET", url, nil)
+		req.Header.Set("Authorization", "Bearer test-user-1")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.StreamResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+		return result
+	}
+
+	first := fetch("/v2/stream?sort=score&limit=2")
+	require.Equal(t, 2, len(first.Data))
+	assert.Equal(t, "item-3", first.Data[0].ID)
+	assert.Equal(t, 80.0, first.Data[0].Score)
+	assert.Equal(t, "item-2", first.Data[1].ID)
+	require.NotNil(t, first.NextCursor)
+
+	second := fetch("/v2/stream?sort=score&limit=2&cursor=" + *first.NextCursor)
+	require.GreaterOrEqual(t, len(second.Data), 1)
+	assert.Equal(t, "item-1", second.Data[0].ID)
+}
+
 func TestGetStreamItem_Integration(t *testing.T) {
 	testRedis.FlushDB(context.Background())
 
@@ -296,6 +406,262 @@ func TestGetStreamItem_Integration(t *testing.T) {
 	assert.Equal(t, "High Priority Email", result.Title)
 }
 
+func TestGetMessages_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	// 24 older messages, an hour apart, before the seeded msg-1
+	_, err := testDB.Exec(ctx, `
+		INSERT INTO messages (id, item_id, sender_id, sender_type, content_type, content, message_timestamp)
+		SELECT 'msg-page-' || n, 'item-1', 'test-user-1', 'other', 'text', 'Message ' || n, NOW() - n * INTERVAL '1 hour'
+		FROM generate_series(1, 24) AS n
+	`)
+	require.NoError(t, err)
+	defer testDB.Exec(ctx, "DELETE FROM messages WHERE id LIKE 'msg-page-%'")
+
+	get := func(path string, dest interface{}) {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer test-user-1")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, dest))
+	}
+
+	// The item carries its latest messages, oldest first, and a cursor for the rest
+	var item model.PriorityItem
+	get("/v2/stream/item-1", &item)
+	require.Len(t, item.Messages, model.ItemMessagesLimit)
+	assert.Equal(t, "msg-1", item.Messages[len(item.Messages)-1].ID)
+	require.NotNil(t, item.MessagesCursor)
+
+	// Older pages follow on from the cursor without overlap
+	seen := map[string]bool{}
+	for _, msg := range item.Messages {
+		seen[msg.ID] = true
+	}
+	cursor := item.MessagesCursor
+	for cursor != nil {
+		var page model.MessagesResponse
+		get("/v2/stream/item-1/messages?limit=3&before="+url.QueryEscape(*cursor), &page)
+		require.NotEmpty(t, page.Data)
+		for i, msg := range page.Data {
+			assert.False(t, seen[msg.ID], msg.ID)
+			seen[msg.ID] = true
+			if i > 0 {
+				assert.True(t, page.Data[i-1].Timestamp.Before(msg.Timestamp))
+			}
+		}
+		cursor = page.NextCursor
+	}
+	assert.Len(t, seen, 25)
+}
+
+func TestRegenerateInsights_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	req := httptest.NewRequest("POST", "/v2/stream/item-1/insights/regenerate", nil)
+	req.Header.Set("Authorization", "Bearer test-user-1")
+
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result model.InsightsResponse
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "msg-1", result.MessageID)
+	require.NotEmpty(t, result.Data)
+	assert.Equal(t, "msg-1-summary", result.Data[0].ID)
+
+	// The insights are stored on the latest message
+	req = httptest.NewRequest("GET", "/v2/stream/item-1", nil)
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	resp, err = testApp.Test(req, -1)
+	require.NoError(t, err)
+
+	var item model.PriorityItem
+	body, _ = io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &item))
+	require.NotEmpty(t, item.Messages)
+	assert.Equal(t, result.Data, item.Messages[len(item.Messages)-1].AIInsights)
+
+	// Another user's item is not found
+	req = httptest.NewRequest("POST", "/v2/stream/item-1/insights/regenerate", nil)
+	req.Header.Set("Authorization", "Bearer test-user-2")
+	resp, err = testApp.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestDraftRefineAndSend_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	post := func(path, body string) *http.Response {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer test-user-1")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// Generate the insights of msg-1, which include a draft reply
+	resp := post("/v2/stream/item-1/insights/regenerate", "")
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	draftPath := "/v2/stream/item-1/messages/msg-1/insights/msg-1-reply"
+
+	resp = post(draftPath+"/refine", `{"instruction": "shorter"}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var refined model.AIInsight
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &refined))
+	require.Len(t, refined.Versions, 2)
+	assert.Equal(t, "shorter", refined.Versions[1].Instruction)
+
+	resp = post(draftPath+"/send", "")
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var msg model.Message
+	body, _ = io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &msg))
+	defer testDB.Exec(ctx, "DELETE FROM messages WHERE id = $1", msg.ID)
+	assert.Equal(t, refined.Content, msg.Content)
+	assert.Equal(t, model.SenderUser, msg.SenderType)
+
+	// A sent draft can no longer be changed or sent again
+	resp = post(draftPath+"/send", "")
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestEventRescheduleAndRSVP_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	_, err := testDB.Exec(ctx, `
+		INSERT INTO messages (id, item_id, sender_type, content_type, content, message_timestamp, event_details)
+		VALUES ('msg-event', 'item-2', 'other', 'event', 'Design review', NOW() - INTERVAL '1 hour',
+			'{"id": "evt-1", "title": "Design review", "startTime": "2026-03-02T15:00:00Z", "endTime": "2026-03-02T16:00:00Z",
+			  "attendees": [{"id": "", "name": "Ana Lima", "email": "ana@example.com"}]}')
+	`)
+	require.NoError(t, err)
+	defer testDB.Exec(ctx, "DELETE FROM messages WHERE item_id = 'item-2'")
+
+	send := func(method, path, body string) *http.Response {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer test-user-1")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+	eventPath := "/v2/stream/item-2/messages/msg-event/event"
+
+	resp := send("PATCH", eventPath, `{"startTime": "2026-03-03T10:00:00Z", "endTime": "2026-03-03T09:00:00Z"}`)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	resp = send("PATCH", eventPath, `{"startTime": "2026-03-03T10:00:00Z", "endTime": "2026-03-03T11:00:00Z"}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var rescheduled model.EventUpdateResponse
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &rescheduled))
+	assert.True(t, rescheduled.Event.StartTime.Equal(time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, model.SenderSystem, rescheduled.Message.SenderType)
+
+	resp = send("POST", eventPath+"/rsvp", `{"attendee": "ANA@example.com", "status": "declined"}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var responded model.EventUpdateResponse
+	body, _ = io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &responded))
+	require.Len(t, responded.Event.RSVPs, 1)
+	assert.Equal(t, model.RSVPDeclined, responded.Event.RSVPs[0].Status)
+	assert.True(t, responded.Event.StartTime.Equal(rescheduled.Event.StartTime))
+
+	// Both changes are in the thread, after the event
+	var notes int
+	require.NoError(t, testDB.QueryRow(ctx,
+		"SELECT COUNT(*) FROM messages WHERE item_id = 'item-2' AND sender_type = 'system'").Scan(&notes))
+	assert.Equal(t, 2, notes)
+
+	resp = send("POST", "/v2/stream/item-1/messages/msg-1/event/rsvp", `{"attendee": "ana@example.com", "status": "accepted"}`)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestEventEditsSurviveRedelivery_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Hour)
+	ics := func(summary string) string {
+		return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\n" +
+			"UID:redelivery-integration@example.com\r\n" +
+			"DTSTART:" + start.Format("20060102T150405Z") + "\r\n" +
+			"DURATION:PT30M\r\n" +
+			"SUMMARY:" + summary + "\r\n" +
+			"ATTENDEE;CN=Alice:mailto:alice@example.com\r\n" +
+			"END:VEVENT\r\nEND:VCALENDAR\r\n"
+	}
+	send := func(method, path, contentType, body string) *http.Response {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer test-user-1")
+		req.Header.Set("Content-Type", contentType)
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+	importICS := func(summary string) string {
+		resp := send("POST", "/v2/calendar/import", "text/calendar", ics(summary))
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var imported model.IngestResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &imported))
+		require.Equal(t, 1, len(imported.Results))
+		return imported.Results[0].ItemID
+	}
+	event := func(itemID string) (string, model.CalendarEvent) {
+		var messageID string
+		var raw []byte
+		require.NoError(t, testDB.QueryRow(ctx,
+			"SELECT id, event_details FROM messages WHERE item_id = $1 AND event_details IS NOT NULL", itemID).Scan(&messageID, &raw))
+
+		var details model.CalendarEvent
+		require.NoError(t, json.Unmarshal(raw, &details))
+		return messageID, details
+	}
+
+	itemID := importICS("Planning")
+	messageID, _ := event(itemID)
+	eventPath := "/v2/stream/" + itemID + "/messages/" + messageID + "/event"
+
+	moved := start.Add(2 * time.Hour)
+	resp := send("PATCH", eventPath, "application/json",
+		`{"startTime": "`+moved.Format(time.RFC3339)+`", "endTime": "`+moved.Add(30*time.Minute).Format(time.RFC3339)+`"}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	resp = send("POST", eventPath+"/rsvp", "application/json", `{"attendee": "alice@example.com", "status": "accepted"}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Redelivery updates what the source changed and keeps the local edits
+	assert.Equal(t, itemID, importICS("Quarterly planning"))
+	_, details := event(itemID)
+	assert.Equal(t, "Quarterly planning", details.Title)
+	assert.True(t, details.StartTime.Equal(moved))
+	require.Len(t, details.RSVPs, 1)
+	assert.Equal(t, model.RSVPAccepted, details.RSVPs[0].Status)
+
+	var slotStart time.Time
+	require.NoError(t, testDB.QueryRow(ctx,
+		"SELECT start_time FROM calendar_events WHERE message_id = $1", messageID).Scan(&slotStart))
+	assert.True(t, slotStart.Equal(moved))
+}
+
 func TestGetStreamItem_NotFound_Integration(t *testing.T) {
 	req := httptest.NewRequest("GET", "/v2/stream/nonexistent-item", nil)
 	req.Header.Set("Authorization", "Bearer test-user-1")
@@ -326,3 +692,865 @@ func TestGetStream_CacheHit_Integration(t *testing.T) {
 	// Cache hit should be fast (under 50ms typically)
 	assert.Less(t, duration, 100*time.Millisecond)
 }
+
+func TestSearchStream_Integration(t *testing.T) {
+	req := httptest.NewRequest("GET", "/v2/stream/search?q=important", nil)
+	req.Header.Set("Authorization", "Bearer test-user-1")
+
+	resp, err := testApp.Test(req, -1)
+
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result model.SearchResponse
+	err = json.Unmarshal(body, &result)
+
+	assert.NoError(t, err)
+	require.GreaterOrEqual(t, len(result.Data), 1)
+	assert.Equal(t, "item-1", result.Data[0].ID)
+	assert.NotNil(t, result.Data[0].Highlights.Snippet)
+}
+
+func TestIngestWebhook_Integration(t *testing.T) {
+	body := []byte(`{"items":[{
+		"userId": "test-user-1",
+		"externalId": "C024BE91L/1700000000.000100",
+		"title": "#deploys",
+		"priority": "high",
+		"unread": true,
+		"timestamp": "2026-01-05T09:00:00Z",
+		"participants": [{"name": "Deploy Bot", "externalId": "slack:B01"}],
+		"messages": [{
+			"externalId": "1700000000.000100",
+			"from": {"name": "Deploy Bot", "externalId": "slack:B01"},
+			"content": "Deploy of api v1.42 finished",
+			"timestamp": "2026-01-05T09:00:00Z"
+		}]
+	}]}`)
+
+	deliver := func() model.IngestResponse {
+		req := httptest.NewRequest("POST", "/v2/ingest/slack", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(ingestion.SignatureHeader, ingestion.Sign([]byte(testIngestSecret), body))
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.IngestResponse
+		respBody, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(respBody, &result))
+		require.Equal(t, 1, len(result.Results))
+		return result
+	}
+
+	first := deliver()
+	assert.True(t, first.Results[0].Created)
+	assert.Equal(t, 1, len(first.Results[0].NewMessages))
+
+	// Ingested items are scored right away
+	var scoredAt *time.Time
+	err := testDB.QueryRow(context.Background(), "SELECT scored_at FROM priority_items WHERE id = $1", first.Results[0].ItemID).Scan(&scoredAt)
+	require.NoError(t, err)
+	assert.NotNil(t, scoredAt)
+
+	// Redelivery is idempotent
+	second := deliver()
+	assert.False(t, second.Results[0].Created)
+	assert.Equal(t, first.Results[0].ItemID, second.Results[0].ItemID)
+	assert.Empty(t, second.Results[0].NewMessages)
+}
+
+func TestIngestWebhook_InvalidSignature_Integration(t *testing.T) {
+	req := httptest.NewRequest("POST", "/v2/ingest/slack", bytes.NewReader([]byte(`{"items":[]}`)))
+	req.Header.Set(ingestion.SignatureHeader, "sha256=00")
+
+	resp, err := testApp.Test(req, -1)
+
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestCalendarImportAndFeed_Integration(t *testing.T) {
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+	ics := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\n" +
+		"UID:standup-integration@example.com\r\n" +
+		"DTSTART:" + start.Format("20060102T150405Z") + "\r\n" +
+		"DURATION:PT15M\r\n" +
+		"RRULE:FREQ=DAILY;COUNT=3\r\n" +
+		"SUMMARY:Standup\r\n" +
+		"ATTENDEE;CN=Alice:mailto:alice@example.com\r\n" +
+		"END:VEVENT\r\nEND:VCALENDAR\r\n"
+
+	req := httptest.NewRequest("POST", "/v2/calendar/import", strings.NewReader(ics))
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	req.Header.Set("Content-Type", "text/calendar")
+
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var imported model.IngestResponse
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &imported))
+	assert.Equal(t, 3, len(imported.Results))
+
+	// Fetch the feed URL, then the feed itself without a bearer token
+	req = httptest.NewRequest("GET", "/v2/calendar/feed", nil)
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	resp, err = testApp.Test(req, -1)
+	require.NoError(t, err)
+
+	var feed model.CalendarFeedResponse
+	body, _ = io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &feed))
+
+	req = httptest.NewRequest("GET", "/v2/calendar.ics?token="+url.QueryEscape(feed.Token), nil)
+	resp, err = testApp.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(t, 3, strings.Count(string(body), "SUMMARY:Standup"))
+
+	// Occurrences in the trash leave the feed
+	req = httptest.NewRequest("POST", "/v2/stream/"+imported.Results[0].ItemID+"/trash", nil)
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	resp, err = testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/v2/calendar.ics?token="+url.QueryEscape(feed.Token), nil)
+	resp, err = testApp.Test(req, -1)
+	require.NoError(t, err)
+
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(t, 2, strings.Count(string(body), "SUMMARY:Standup"))
+}
+
+func TestCalendarConflictsAndFreeBusy_Integration(t *testing.T) {
+	ctx := context.Background()
+	day := time.Now().UTC().Add(5 * 24 * time.Hour).Truncate(24 * time.Hour)
+	event := func(uid, summary string, start time.Time, minutes int) string {
+		return "BEGIN:VEVENT\r\nUID:" + uid + "\r\n" +
+			"DTSTART:" + start.Format("20060102T150405Z") + "\r\n" +
+			"DURATION:PT" + strconv.Itoa(minutes) + "M\r\n" +
+			"SUMMARY:" + summary + "\r\nEND:VEVENT\r\n"
+	}
+	ics := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+		event("review-integration@example.com", "Design review", day.Add(10*time.Hour), 60) +
+		event("one-on-one-integration@example.com", "1:1", day.Add(10*time.Hour+30*time.Minute), 60) +
+		"END:VCALENDAR\r\n"
+
+	req := httptest.NewRequest("POST", "/v2/calendar/import", strings.NewReader(ics))
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	req.Header.Set("Content-Type", "text/calendar")
+
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var imported model.IngestResponse
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &imported))
+	require.Equal(t, 2, len(imported.Results))
+	itemIDs := []string{imported.Results[0].ItemID, imported.Results[1].ItemID}
+	defer testDB.Exec(ctx, "DELETE FROM priority_items WHERE id = ANY($1)", itemIDs)
+
+	// Both events are annotated with the other one
+	for i, other := range []string{"1:1", "Design review"} {
+		var raw []byte
+		require.NoError(t, testDB.QueryRow(ctx,
+			"SELECT ai_insights FROM messages WHERE item_id = $1 AND event_details IS NOT NULL", itemIDs[i]).Scan(&raw))
+		var stored []model.AIInsight
+		require.NoError(t, json.Unmarshal(raw, &stored))
+		require.NotEmpty(t, stored)
+		assert.Equal(t, "Conflict", stored[0].Label)
+		assert.Contains(t, stored[0].Content, `"`+other+`"`)
+	}
+
+	req = httptest.NewRequest("GET", "/v2/calendar/freebusy?from="+day.Format(time.RFC3339)+"&to="+day.Add(24*time.Hour).Format(time.RFC3339), nil)
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	resp, err = testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var freeBusy model.FreeBusyResponse
+	body, _ = io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &freeBusy))
+	require.Equal(t, 1, len(freeBusy.Busy))
+	assert.True(t, freeBusy.Busy[0].Start.Equal(day.Add(10*time.Hour)))
+	assert.True(t, freeBusy.Busy[0].End.Equal(day.Add(11*time.Hour+30*time.Minute)))
+}
+
+func TestRules_Integration(t *testing.T) {
+	send := func(method, url, body string) *http.Response {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer test-user-1")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+
+	ruleBody := `{
+		"name": "Slack is noise",
+		"conditions": [{"field": "source", "op": "eq", "value": "slack"}],
+		"actions": [{"type": "set_priority", "priority": "low"}, {"type": "mark_read"}]
+	}`
+
+	// Dry run finds the seeded Slack item without changing anything
+	resp := send("POST", "/v2/rules/dry-run", ruleBody)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var dryRun model.RuleDryRunResponse
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &dryRun))
+	require.GreaterOrEqual(t, len(dryRun.Matches), 1)
+	for _, item := range dryRun.Matches {
+		assert.Equal(t, model.SourceSlack, item.Source)
+	}
+
+	// Create the rule, then ingest a Slack item it applies to
+	resp = send("POST", "/v2/rules", ruleBody)
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	var rule model.Rule
+	body, _ = io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &rule))
+	defer send("DELETE", "/v2/rules/"+rule.ID, "")
+
+	ingestBody := []byte(`{"items":[{
+		"userId": "test-user-1",
+		"externalId": "C024BE91L/1700000000.000200",
+		"title": "#random",
+		"priority": "high",
+		"unread": true,
+		"timestamp": "2026-01-05T12:00:00Z",
+		"messages": [{"externalId": "1700000000.000200", "content": "lunch?"}]
+	}]}`)
+	req := httptest.NewRequest("POST", "/v2/ingest/slack", bytes.NewReader(ingestBody))
+	req.Header.Set(ingestion.SignatureHeader, ingestion.Sign([]byte(testIngestSecret), ingestBody))
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var ingested model.IngestResponse
+	body, _ = io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &ingested))
+
+	var priority string
+	var unread bool
+	err = testDB.QueryRow(context.Background(), "SELECT priority, is_unread FROM priority_items WHERE id = $1", ingested.Results[0].ItemID).Scan(&priority, &unread)
+	require.NoError(t, err)
+	assert.Equal(t, "low", priority)
+	assert.False(t, unread)
+
+	// Invalid rules are rejected
+	resp = send("POST", "/v2/rules", `{"name": "Bad", "conditions": [], "actions": []}`)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSnooze_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	fetch := func(url string) []string {
+		req := httptest.NewRequest("GET", url, nil)
+		req.Header.Set("Authorization", "Bearer test-user-1")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.StreamResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return ids
+	}
+
+	// Snoozing hides the item from the default stream
+	until := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	req := httptest.NewRequest("POST", "/v2/stream/item-3/snooze", strings.NewReader(`{"until":"`+until+`"}`))
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	defer testDB.Exec(ctx, "UPDATE priority_items SET snoozed_until = NULL WHERE id = 'item-3'")
+
+	assert.NotContains(t, fetch("/v2/stream"), "item-3")
+	assert.Equal(t, []string{"item-3"}, fetch("/v2/stream?filter=snoozed"))
+
+	// Once due, the wake job returns it unread at the top of the stream
+	_, err = testDB.Exec(ctx, "UPDATE priority_items SET snoozed_until = NOW() - INTERVAL '1 minute', is_unread = FALSE WHERE id = 'item-3'")
+	require.NoError(t, err)
+
+	cfg := &config.Config{Snooze: config.SnoozeConfig{WakeInterval: time.Minute, BatchSize: 100}}
+	streamService := service.NewStreamService(repository.NewPgStreamRepository(testDB), cache.NewRedisCache(testRedis), events.NopPublisher{}, cfg, logger.New())
+	woken, err := streamService.WakeSnoozed(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, woken, 1)
+
+	ids := fetch("/v2/stream")
+	require.NotEmpty(t, ids)
+	assert.Equal(t, "item-3", ids[0])
+
+	var unread bool
+	require.NoError(t, testDB.QueryRow(ctx, "SELECT is_unread FROM priority_items WHERE id = 'item-3'").Scan(&unread))
+	assert.True(t, unread)
+}
+
+func TestBriefing_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	// Snooze item-3 until tomorrow morning
+	tomorrow := time.Now().UTC().Add(24 * time.Hour).Truncate(24 * time.Hour)
+	_, err := testDB.Exec(ctx, "UPDATE priority_items SET snoozed_until = $1 WHERE id = 'item-3'", tomorrow.Add(9*time.Hour))
+	require.NoError(t, err)
+	defer testDB.Exec(ctx, "UPDATE priority_items SET snoozed_until = NULL WHERE id = 'item-3'")
+
+	req := httptest.NewRequest("GET", "/v2/briefing?date="+tomorrow.Format("2006-01-02")+"&tz=UTC", nil)
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var briefing model.BriefingResponse
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &briefing))
+	assert.Equal(t, tomorrow.Format("2006-01-02"), briefing.Date)
+
+	ids := func(items []model.PriorityItem) []string {
+		out := make([]string, len(items))
+		for i, item := range items {
+			out[i] = item.ID
+		}
+		return out
+	}
+	// item-1 is unread, high priority and its latest message is from someone else
+	assert.Equal(t, []string{"item-1"}, ids(briefing.HighPriority))
+	assert.Equal(t, []string{"item-3"}, ids(briefing.WakingToday))
+	assert.Contains(t, ids(briefing.NeedsReply), "item-1")
+
+	exists, err := testRedis.Exists(ctx, cache.BriefingKey("test-user-1", briefing.Date, "UTC")).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), exists)
+}
+
+func TestFollowUps_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	fetch := func(url string) []string {
+		req := httptest.NewRequest("GET", url, nil)
+		req.Header.Set("Authorization", "Bearer test-user-1")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.StreamResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return ids
+	}
+	post := func(path, body string) *http.Response {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer test-user-1")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// msg-1 is from someone else, so nothing can be followed up yet
+	assert.Contains(t, fetch("/v2/stream?filter=needs_my_reply"), "item-1")
+	assert.NotContains(t, fetch("/v2/stream?filter=awaiting_reply"), "item-1")
+	assert.Equal(t, fiber.StatusConflict, post("/v2/stream/item-1/follow-up", `{"days":2}`).StatusCode)
+
+	// Replying makes the item await an answer
+	resp := post("/v2/stream/item-1/messages", `{"content":"Any news?"}`)
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var msg model.Message
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &msg))
+	defer testDB.Exec(ctx, "DELETE FROM messages WHERE id = $1", msg.ID)
+
+	assert.Contains(t, fetch("/v2/stream?filter=awaiting_reply"), "item-1")
+	assert.NotContains(t, fetch("/v2/stream?filter=needs_my_reply"), "item-1")
+
+	resp = post("/v2/stream/item-1/follow-up", `{"days":2}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	defer testDB.Exec(ctx, "UPDATE priority_items SET follow_up_at = NULL WHERE id = 'item-1'")
+
+	// Once due without a reply, the job resurfaces it unread, out of the archive
+	_, err := testDB.Exec(ctx, `
+		UPDATE priority_items
+		SET follow_up_at = NOW() - INTERVAL '1 minute', is_unread = FALSE, archived_at = NOW()
+		WHERE id = 'item-1'
+	`)
+	require.NoError(t, err)
+	defer testDB.Exec(ctx, "UPDATE priority_items SET archived_at = NULL, is_unread = TRUE WHERE id = 'item-1'")
+
+	cfg := &config.Config{FollowUp: config.FollowUpConfig{Interval: time.Minute, BatchSize: 100}}
+	streamService := service.NewStreamService(repository.NewPgStreamRepository(testDB), cache.NewRedisCache(testRedis), events.NopPublisher{}, cfg, logger.New())
+	resurfaced, err := streamService.ResurfaceFollowUps(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, resurfaced, 1)
+
+	var unread, archived bool
+	require.NoError(t, testDB.QueryRow(ctx,
+		"SELECT is_unread, archived_at IS NOT NULL FROM priority_items WHERE id = 'item-1'").Scan(&unread, &archived))
+	assert.True(t, unread)
+	assert.False(t, archived)
+
+	// An answer from someone else clears a pending follow-up
+	resp = post("/v2/stream/item-1/follow-up", `{"days":2}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	_, err = testDB.Exec(ctx, `
+		INSERT INTO messages (id, item_id, sender_id, sender_type, content_type, content, message_timestamp)
+		VALUES ('msg-2', 'item-1', 'test-user-1', 'other', 'text', 'Yes, done.', NOW() + INTERVAL '1 second')
+	`)
+	require.NoError(t, err)
+	defer testDB.Exec(ctx, "DELETE FROM messages WHERE id = 'msg-2'")
+
+	var followUpAt *time.Time
+	require.NoError(t, testDB.QueryRow(ctx, "SELECT follow_up_at FROM priority_items WHERE id = 'item-1'").Scan(&followUpAt))
+	assert.Nil(t, followUpAt)
+}
+
+func TestArchiveTrashRestore_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	send := func(method, url string) *http.Response {
+		req := httptest.NewRequest(method, url, nil)
+		req.Header.Set("Authorization", "Bearer test-user-1")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+	streamIDs := func(url string) []string {
+		resp := send("GET", url)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.StreamResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return ids
+	}
+	searchIDs := func(q string) []string {
+		resp := send("GET", "/v2/stream/search?q="+q)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.SearchResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return ids
+	}
+	defer testDB.Exec(ctx, "UPDATE priority_items SET archived_at = NULL, deleted_at = NULL WHERE id = 'item-1'")
+
+	// Archived items leave the stream but stay searchable
+	require.Equal(t, fiber.StatusOK, send("POST", "/v2/stream/item-1/archive").StatusCode)
+	assert.NotContains(t, streamIDs("/v2/stream"), "item-1")
+	assert.Contains(t, streamIDs("/v2/stream?filter=archived"), "item-1")
+	assert.Contains(t, searchIDs("important"), "item-1")
+
+	// Trashed items leave search too
+	require.Equal(t, fiber.StatusOK, send("POST", "/v2/stream/item-1/trash").StatusCode)
+	assert.NotContains(t, streamIDs("/v2/stream?filter=archived"), "item-1")
+	assert.Contains(t, streamIDs("/v2/stream?filter=trash"), "item-1")
+	assert.NotContains(t, searchIDs("important"), "item-1")
+
+	// Restoring returns the item to the stream
+	require.Equal(t, fiber.StatusOK, send("POST", "/v2/stream/item-1/restore").StatusCode)
+	assert.Contains(t, streamIDs("/v2/stream"), "item-1")
+	assert.Empty(t, streamIDs("/v2/stream?filter=trash"))
+}
+
+func TestPurgeTrash_Integration(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := testDB.Exec(ctx, `
+		INSERT INTO priority_items (id, user_id, title, source, priority, item_timestamp, deleted_at) VALUES
+		('item-expired', 'test-user-1', 'Old newsletter', 'email', 'low', NOW() - INTERVAL '90 days', NOW() - INTERVAL '31 days'),
+		('item-recent', 'test-user-1', 'Recent newsletter', 'email', 'low', NOW() - INTERVAL '2 days', NOW() - INTERVAL '1 day')
+		ON CONFLICT (id) DO NOTHING
+	`)
+	require.NoError(t, err)
+	defer testDB.Exec(ctx, "DELETE FROM priority_items WHERE id IN ('item-expired', 'item-recent')")
+
+	cfg := &config.Config{Trash: config.TrashConfig{Retention: 30 * 24 * time.Hour, PurgeInterval: time.Hour, BatchSize: 100}}
+	streamService := service.NewStreamService(repository.NewPgStreamRepository(testDB), cache.NewRedisCache(testRedis), events.NopPublisher{}, cfg, logger.New())
+
+	purged, err := streamService.PurgeTrash(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	var remaining []string
+	rows, err := testDB.Query(ctx, "SELECT id FROM priority_items WHERE id IN ('item-expired', 'item-recent')")
+	require.NoError(t, err)
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		remaining = append(remaining, id)
+	}
+	rows.Close()
+	assert.Equal(t, []string{"item-recent"}, remaining)
+}
+
+func TestLabels_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	send := func(method, url, body string) *http.Response {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer test-user-1")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+	stream := func(url string) model.StreamResponse {
+		resp := send("GET", url, "")
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.StreamResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+		return result
+	}
+
+	// Create a label; names are unique ignoring case
+	resp := send("POST", "/v2/labels", `{"name": "Launch", "color": "#4F46E5"}`)
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	var label model.Label
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &label))
+	assert.Equal(t, "#4f46e5", *label.Color)
+
+	resp = send("POST", "/v2/labels", `{"name": "launch"}`)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// Apply it and filter the stream by it
+	resp = send("POST", "/v2/stream/item-1/labels", `{"labelIds": ["`+label.ID+`"]}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	labeled := stream("/v2/stream?label=" + label.ID)
+	require.Len(t, labeled.Data, 1)
+	assert.Equal(t, "item-1", labeled.Data[0].ID)
+	require.Len(t, labeled.Data[0].Labels, 1)
+	assert.Equal(t, "Launch", labeled.Data[0].Labels[0].Name)
+
+	// Renaming shows up on the item
+	resp = send("PUT", "/v2/labels/"+label.ID, `{"name": "Q3 launch"}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Q3 launch", stream("/v2/stream?label=" + label.ID).Data[0].Labels[0].Name)
+
+	// Removing it empties the label's stream
+	resp = send("DELETE", "/v2/stream/item-1/labels/"+label.ID, "")
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, stream("/v2/stream?label="+label.ID).Data)
+
+	resp = send("DELETE", "/v2/labels/"+label.ID, "")
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	resp = send("GET", "/v2/stream?label=not-a-uuid", "")
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestPeople_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+
+	send := func(method, url string, body []byte) *http.Response {
+		req := httptest.NewRequest(method, url, bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer test-user-1")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+	ingest := func(body string) string {
+		req := httptest.NewRequest("POST", "/v2/ingest/slack", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(ingestion.SignatureHeader, ingestion.Sign([]byte(testIngestSecret), []byte(body)))
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.IngestResponse
+		respBody, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(respBody, &result))
+		require.Equal(t, 1, len(result.Results))
+		return result.Results[0].ItemID
+	}
+	item := func(externalID, timestamp string, participants ...string) string {
+		return `{"items":[{
+			"userId": "test-user-1",
+			"externalId": "` + externalID + `",
+			"title": "` + externalID + `",
+			"timestamp": "` + timestamp + `",
+			"participants": [` + strings.Join(participants, ",") + `]
+		}]}`
+	}
+	people := func(url string) []model.Person {
+		resp := send("GET", url, nil)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.PeopleResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+		return result.Data
+	}
+
+	// Grace shares two recent items; Alan one older item
+	grace := `{"name": "Grace Hopper", "externalId": "slack:UPEOPLE1"}`
+	alan := `{"name": "Alan Turing", "externalId": "slack:UPEOPLE2"}`
+	now := time.Now().UTC()
+	ingest(item("people/1", now.Add(-time.Hour).Format(time.RFC3339), grace))
+	ingest(item("people/2", now.Add(-2*time.Hour).Format(time.RFC3339), grace, alan))
+	alanItem := ingest(item("people/3", now.Add(-72*time.Hour).Format(time.RFC3339), alan))
+	defer testDB.Exec(ctx, "DELETE FROM users WHERE external_id IN ('slack:UPEOPLE1', 'slack:UPEOPLE2')")
+
+	ranked := people("/v2/people")
+	require.GreaterOrEqual(t, len(ranked), 2)
+	assert.Equal(t, "Grace Hopper", ranked[0].Name)
+	assert.Equal(t, 2, ranked[0].ItemCount)
+	assert.Equal(t, "Alan Turing", ranked[1].Name)
+	alanID := ranked[1].ID
+
+	// Pages continue where the previous one ended
+	resp := send("GET", "/v2/people?limit=1", nil)
+	var page model.PeopleResponse
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &page))
+	require.NotNil(t, page.NextCursor)
+	assert.Equal(t, "Alan Turing", people("/v2/people?limit=1&cursor=" + *page.NextCursor)[0].Name)
+
+	// A profile lists the items shared with the person
+	resp = send("GET", "/v2/people/"+alanID, nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var profile model.PersonResponse
+	body, _ = io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(body, &profile))
+	assert.Equal(t, 2, len(profile.RecentItems))
+	assert.False(t, profile.VIP)
+
+	// Marking a VIP raises the score of their items right away
+	var before float64
+	require.NoError(t, testDB.QueryRow(ctx, "SELECT score FROM priority_items WHERE id = $1", alanItem).Scan(&before))
+
+	resp = send("POST", "/v2/people/"+alanID+"/vip", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	defer testDB.Exec(ctx, "DELETE FROM vip_contacts WHERE user_id = 'test-user-1'")
+
+	var after float64
+	require.NoError(t, testDB.QueryRow(ctx, "SELECT score FROM priority_items WHERE id = $1", alanItem).Scan(&after))
+	assert.Greater(t, after, before)
+
+	vips := people("/v2/people?vip=true")
+	require.Len(t, vips, 1)
+	assert.Equal(t, alanID, vips[0].ID)
+
+	resp = send("DELETE", "/v2/people/"+alanID+"/vip", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, people("/v2/people?vip=true"))
+
+	// People outside the user's items are not found
+	resp = send("GET", "/v2/people/00000000-0000-0000-0000-000000000000", nil)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestPins_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+	defer testDB.Exec(ctx, "UPDATE priority_items SET pin_position = NULL WHERE user_id = 'test-user-1'")
+
+	send := func(method, url, body string) *http.Response {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer test-user-1")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+	ids := func(items []model.PriorityItem) []string {
+		out := make([]string, len(items))
+		for i, item := range items {
+			out[i] = item.ID
+		}
+		return out
+	}
+	stream := func() model.StreamResponse {
+		resp := send("GET", "/v2/stream", "")
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.StreamResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+		return result
+	}
+
+	// Cache the first page before pinning
+	assert.Empty(t, stream().Pinned)
+
+	// Pinned items move out of the paginated data into the pinned section
+	require.Equal(t, fiber.StatusOK, send("POST", "/v2/stream/item-3/pin", "").StatusCode)
+	require.Equal(t, fiber.StatusOK, send("POST", "/v2/stream/item-2/pin", "").StatusCode)
+
+	result := stream()
+	assert.Equal(t, []string{"item-3", "item-2"}, ids(result.Pinned))
+	assert.NotContains(t, ids(result.Data), "item-3")
+	assert.True(t, result.Pinned[0].Pinned)
+
+	// Reordering changes the pinned section
+	resp := send("PATCH", "/v2/stream/pins", `{"itemIds": ["item-2", "item-3"]}`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"item-2", "item-3"}, ids(stream().Pinned))
+
+	resp = send("PATCH", "/v2/stream/pins", `{"itemIds": ["item-2"]}`)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	// Unpinning returns the item to the stream
+	require.Equal(t, fiber.StatusOK, send("DELETE", "/v2/stream/item-3/pin", "").StatusCode)
+	result = stream()
+	assert.Equal(t, []string{"item-2"}, ids(result.Pinned))
+	assert.Contains(t, ids(result.Data), "item-3")
+}
+
+func TestCounts_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+	defer testDB.Exec(ctx, "UPDATE priority_items SET is_unread = (id <> 'item-2'), archived_at = NULL WHERE user_id = 'test-user-1'")
+
+	send := func(method, url string) *http.Response {
+		req := httptest.NewRequest(method, url, nil)
+		req.Header.Set("Authorization", "Bearer test-user-1")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+	counts := func() model.CountsResponse {
+		resp := send("GET", "/v2/stream/counts")
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.CountsResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+		return result
+	}
+
+	result := counts()
+	assert.Equal(t, model.StreamCounts{Total: 3, Unread: 2, High: 1}, result.StreamCounts)
+	assert.Equal(t, model.StreamCounts{Total: 1, Unread: 1, High: 1}, result.Sources[model.SourceEmail])
+	assert.Equal(t, model.StreamCounts{Total: 1}, result.Sources[model.SourceTask])
+
+	// Read state is applied to the cached counts
+	require.Equal(t, fiber.StatusOK, send("POST", "/v2/stream/item-1/read").StatusCode)
+	exists, err := testRedis.Exists(ctx, cache.CountsKey("test-user-1")).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), exists)
+
+	result = counts()
+	assert.Equal(t, model.StreamCounts{Total: 3, Unread: 1, High: 1}, result.StreamCounts)
+	assert.Equal(t, model.StreamCounts{Total: 1, High: 1}, result.Sources[model.SourceEmail])
+
+	// Archiving drops the counts, which are then recomputed without the item
+	require.Equal(t, fiber.StatusOK, send("POST", "/v2/stream/item-3/archive").StatusCode)
+	result = counts()
+	assert.Equal(t, model.StreamCounts{Total: 2, High: 1}, result.StreamCounts)
+	assert.NotContains(t, result.Sources, model.SourceSlack)
+}
+
+func TestSync_Integration(t *testing.T) {
+	ctx := context.Background()
+	testRedis.FlushDB(ctx)
+	defer testDB.Exec(ctx, "UPDATE priority_items SET is_unread = (id <> 'item-2') WHERE user_id = 'test-user-1'")
+
+	sync := func(since string) model.SyncResponse {
+		req := httptest.NewRequest("GET", "/v2/sync?since="+since, nil)
+		req.Header.Set("Authorization", "Bearer test-user-1")
+
+		resp, err := testApp.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result model.SyncResponse
+		body, _ := io.ReadAll(resp.Body)
+		require.NoError(t, json.Unmarshal(body, &result))
+		return result
+	}
+
+	// A full sync returns every item as created
+	result := sync("")
+	assert.Len(t, result.Created, 3)
+	assert.Empty(t, result.Updated)
+	assert.False(t, result.HasMore)
+	token := result.Token
+
+	// Nothing changed, so the token stays the same
+	result = sync(token)
+	assert.Empty(t, result.Created)
+	assert.Equal(t, token, result.Token)
+
+	// Marking an item read is an update
+	req := httptest.NewRequest("POST", "/v2/stream/item-1/read", nil)
+	req.Header.Set("Authorization", "Bearer test-user-1")
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	result = sync(token)
+	require.Len(t, result.Updated, 1)
+	assert.Equal(t, "item-1", result.Updated[0].ID)
+	assert.False(t, result.Updated[0].IsUnread)
+	token = result.Token
+
+	// An item created and deleted since the last sync is reported as deleted
+	const itemID = "5f0c9a7e-2b4d-4c6e-8f1a-3b5d7e9f1a2c"
+	_, err = testDB.Exec(ctx, `
+		INSERT INTO priority_items (id, user_id, title, source, priority, is_unread, snippet, item_timestamp)
+		VALUES ($1, 'test-user-1', 'Short-lived', 'email', 'low', true, '', NOW())
+	`, itemID)
+	require.NoError(t, err)
+	_, err = testDB.Exec(ctx, "DELETE FROM priority_items WHERE id = $1", itemID)
+	require.NoError(t, err)
+
+	result = sync(token)
+	assert.Empty(t, result.Created)
+	assert.Empty(t, result.Updated)
+	assert.Equal(t, []string{itemID}, result.Deleted)
+}